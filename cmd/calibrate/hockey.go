@@ -0,0 +1,254 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/calibration"
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+	hockeyStrat "github.com/charleschow/hft-trading/internal/core/strategy/hockey"
+)
+
+// hockeyRows reads every regulation snapshot of a game with a known
+// winner. Shootout games are left out: the training outcome does not say
+// who won the shootout.
+const hockeyRows = `SELECT t.game_id, COALESCE(t.league,''), COALESCE(t.period,''),
+	t.home_score, t.away_score, t.time_remain,
+	t.pregame_home_pct, t.pregame_away_pct,
+	COALESCE(t.kalshi_home_pct_l,0), COALESCE(t.kalshi_away_pct_l,0),
+	o.actual_outcome
+FROM training_snapshots t
+JOIN (SELECT game_id, MAX(actual_outcome) AS actual_outcome FROM training_snapshots
+      WHERE actual_outcome IN ('home_win','away_win') GROUP BY game_id) o ON o.game_id = t.game_id
+WHERE t.event_type != 'GAME FINISH' AND t.pregame_home_pct > 0 AND t.time_remain > 0`
+
+type hockeyRow struct {
+	hs         *hockeyState.HockeyState
+	kalshiHome float64
+	kalshiAway float64
+	homeWon    bool
+}
+
+var hockeyBuckets = []string{"50-60", "40-50", "30-40", "20-30", "10-20", " 0-10"}
+
+func hockeyBucket(t float64) string {
+	switch {
+	case t > 50:
+		return "50-60"
+	case t > 40:
+		return "40-50"
+	case t > 30:
+		return "30-40"
+	case t > 20:
+		return "20-30"
+	case t > 10:
+		return "10-20"
+	default:
+		return " 0-10"
+	}
+}
+
+// fitHockey fits, in order, the V2 decay constants, the Poisson share
+// scale and the recalibration of the live model built from both.
+func fitHockey(dbPath, ratesPath string, opts options) (config.HockeyModelParams, error) {
+	rates, err := config.LoadScoringRates(ratesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v (using the default scoring rate)\n", err)
+	} else {
+		hockeyStrat.SetLeagueRates(rates.GoalsPer60(), rates.DefaultGoalsPer60)
+	}
+
+	rows, err := loadHockeyRows(dbPath)
+	if err != nil {
+		return config.HockeyModelParams{}, err
+	}
+	if len(rows) == 0 {
+		return config.HockeyModelParams{}, fmt.Errorf("no usable snapshots in %s", dbPath)
+	}
+
+	var fitRows, reportRows []hockeyRow
+	for _, r := range rows {
+		if opts.holdout > 0 && heldOut(r.hs.EID, opts.holdout) {
+			reportRows = append(reportRows, r)
+		} else {
+			fitRows = append(fitRows, r)
+		}
+	}
+	if opts.holdout <= 0 {
+		reportRows = fitRows
+	}
+
+	hockeyStrat.SetModelParams(hockeyStrat.ModelParams{})
+	held := hockeySamples(reportRows)
+	for i := range held {
+		held[i].base = held[i].fit
+	}
+
+	decay := fitDecay(fitRows)
+	scale := fitShareScale(fitRows, decay)
+	hockeyStrat.SetModelParams(hockeyStrat.ModelParams{Decay: decay, ShareScale: scale})
+
+	rp := fitTable(hockeySamples(fitRows), func(s sample) float64 { return s.fit }, opts.minSamples)
+	table := calibration.TableFrom(rp)
+	for i, s := range hockeySamples(reportRows) {
+		held[i].fit = table.For(s.league).Apply(s.fit)
+	}
+
+	fmt.Printf("hockey: decay k=%.4f a=%.4f theta=%.4f eta=%.4f lambda=%.4f, share scale %.4f, %d league recalibrations\n\n",
+		decay.K, decay.A, decay.Theta, decay.Eta, decay.Lambda, scale, len(rp.Leagues))
+	report("Hockey", held, hockeyBucket, hockeyBuckets, opts.bins)
+
+	return config.HockeyModelParams{
+		Decay: config.DecayParams{
+			K: round4(decay.K), A: round4(decay.A), Theta: round4(decay.Theta),
+			Eta: round4(decay.Eta), Lambda: round4(decay.Lambda),
+		},
+		ShareScale: round4(scale),
+		Recal:      rp,
+	}, nil
+}
+
+func loadHockeyRows(path string) ([]hockeyRow, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer db.Close()
+
+	rs, err := db.Query(hockeyRows)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", path, err)
+	}
+	defer rs.Close()
+
+	var rows []hockeyRow
+	for rs.Next() {
+		var game, league, period, outcome string
+		var r hockeyRow
+		hs := &hockeyState.HockeyState{}
+		if err := rs.Scan(&game, &league, &period, &hs.HomeScore, &hs.AwayScore, &hs.TimeLeft,
+			&hs.HomeStrength, &hs.AwayStrength, &r.kalshiHome, &r.kalshiAway, &outcome); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		hs.EID, hs.League, hs.Period = game, strings.ToLower(strings.TrimSpace(league)), period
+		if hs.IsOVERTIME() || hs.IsFinished() {
+			continue
+		}
+		r.hs = hs
+		r.homeWon = outcome == "home_win"
+		rows = append(rows, r)
+	}
+	return rows, rs.Err()
+}
+
+// hockeySamples prices every row with the installed parameters, one
+// sample per side, the price in fit.
+func hockeySamples(rows []hockeyRow) []sample {
+	out := make([]sample, 0, 2*len(rows))
+	for _, r := range rows {
+		home, away := hockeyStrat.RawModel(r.hs)
+		homeWon := 0.0
+		if r.homeWon {
+			homeWon = 1
+		}
+		out = append(out,
+			sample{game: r.hs.EID, league: r.hs.League, minLeft: r.hs.TimeLeft,
+				outcome: homeWon, fit: home / 100, market: r.kalshiHome},
+			sample{game: r.hs.EID, league: r.hs.League, minLeft: r.hs.TimeLeft,
+				outcome: 1 - homeWon, fit: away / 100, market: r.kalshiAway},
+		)
+	}
+	return out
+}
+
+// decayRange bounds each fitted V2 constant to this factor of its default
+// either way, so a thin or lopsided sample cannot switch a term off.
+const decayRange = 4.0
+
+// fitDecay coordinate-searches the V2 constants for the lowest log-loss,
+// halving the relative step whenever no single change improves it.
+func fitDecay(rows []hockeyRow) hockeyStrat.DecayParams {
+	d := hockeyStrat.DefaultDecay
+	p := [5]float64{d.K, d.A, d.Theta, d.Eta, d.Lambda}
+	def := p
+	toDecay := func(p [5]float64) hockeyStrat.DecayParams {
+		return hockeyStrat.DecayParams{K: p[0], A: p[1], Theta: p[2], Eta: p[3], Lambda: p[4]}
+	}
+
+	best := v2Loss(rows, toDecay(p))
+	for step := 0.2; step > 1e-3; {
+		improved := false
+		for i := range p {
+			for _, dir := range []float64{1, -1} {
+				cand := p
+				cand[i] *= 1 + dir*step
+				if cand[i] < def[i]/decayRange || cand[i] > def[i]*decayRange {
+					continue
+				}
+				if loss := v2Loss(rows, toDecay(cand)); loss < best {
+					p, best, improved = cand, loss, true
+				}
+			}
+		}
+		if !improved {
+			step /= 2
+		}
+	}
+	return toDecay(p)
+}
+
+func v2Loss(rows []hockeyRow, d hockeyStrat.DecayParams) float64 {
+	hockeyStrat.SetModelParams(hockeyStrat.ModelParams{Decay: d})
+	return sideLoss(rows, func(hs *hockeyState.HockeyState, strength, lead float64) float64 {
+		return hockeyStrat.ProjectedOddsV2(strength, hs.TimeLeft, lead)
+	})
+}
+
+// fitShareScale golden-section searches the share scale that minimizes the
+// log-loss of the league-rate Poisson model.
+func fitShareScale(rows []hockeyRow, decay hockeyStrat.DecayParams) float64 {
+	loss := func(scale float64) float64 {
+		hockeyStrat.SetModelParams(hockeyStrat.ModelParams{Decay: decay, ShareScale: scale})
+		return sideLoss(rows, func(hs *hockeyState.HockeyState, strength, lead float64) float64 {
+			return hockeyStrat.ProjectedOddsV3PP(hs.League, strength, hs.TimeLeft, lead,
+				hockeyStrat.PowerPlay{}, hockeyStrat.PowerPlay{})
+		})
+	}
+
+	invPhi := (math.Sqrt(5) - 1) / 2
+	lo, hi := 0.5, 2.0
+	x1, x2 := hi-invPhi*(hi-lo), lo+invPhi*(hi-lo)
+	f1, f2 := loss(x1), loss(x2)
+	for hi-lo > 1e-3 {
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - invPhi*(hi-lo)
+			f1 = loss(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + invPhi*(hi-lo)
+			f2 = loss(x2)
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// sideLoss is the mean log-loss of model over both sides of every row.
+func sideLoss(rows []hockeyRow, model func(hs *hockeyState.HockeyState, strength, lead float64) float64) float64 {
+	var sum float64
+	for _, r := range rows {
+		lead := float64(r.hs.Lead())
+		home := math.Max(1e-6, math.Min(1-1e-6, model(r.hs, r.hs.HomeStrength, lead)))
+		away := math.Max(1e-6, math.Min(1-1e-6, model(r.hs, r.hs.AwayStrength, -lead)))
+		if r.homeWon {
+			sum -= math.Log(home) + math.Log(1-away)
+		} else {
+			sum -= math.Log(1-home) + math.Log(away)
+		}
+	}
+	return sum / float64(2*len(rows))
+}
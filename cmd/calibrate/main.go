@@ -0,0 +1,177 @@
+// Command calibrate fits the hockey and soccer model parameters from the
+// training databases, reports how well calibrated the models are before
+// and after the fit, and writes a new version of the parameter file the
+// strategies load at startup (MODEL_PARAMS_PATH).
+//
+// Games are split into a fitting set and a holdout set by game ID; the
+// reports are computed on the holdout so the fitted numbers are not
+// graded on the data that produced them.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"math"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	_ "modernc.org/sqlite"
+
+	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/calibration"
+)
+
+const header = `# Model parameters — fitted by cmd/calibrate from the training databases.
+#
+# Regenerate with:
+#   go run ./cmd/calibrate
+#
+# version:  increases by one on every fit
+# hockey.decay:          ProjectedOddsV2 constants
+# hockey.share_scale:    tilt of the Poisson scoring share away from 50/50
+# hockey.recalibration:  σ(a·logit(p) + b) on the live regulation price
+# soccer.win_recalibration / draw_recalibration: the same, on the 1X2 prices
+#
+# Recalibrations have a default and per-league overrides (lowercase league
+# keys) for leagues with enough samples. A zero value keeps the compiled-in
+# default.
+
+`
+
+// options are the command's flags.
+type options struct {
+	holdout    float64
+	minSamples int
+	bins       int
+}
+
+// sample is one binary prediction: the model's probability that an
+// outcome happens, before (base) and after (fit) calibration, against
+// the Kalshi price at the time when there was one.
+type sample struct {
+	game    string
+	league  string
+	minLeft float64
+	outcome float64
+	base    float64
+	fit     float64
+	market  float64
+}
+
+func main() {
+	sport := flag.String("sport", "all", "which model to fit: hockey, soccer, or all")
+	hockeyDB := flag.String("hockey-db", "data/hockey_training.db", "hockey training DB")
+	soccerDB := flag.String("soccer-db", "data/soccer_training.db", "soccer training DB")
+	ratesPath := flag.String("rates", "internal/config/hockey_scoring_rates.yaml", "hockey league scoring rates")
+	out := flag.String("out", "internal/config/model_params.yaml", "parameter file to update")
+	holdout := flag.Float64("holdout", 0.2, "share of games held out for the reports (0 reports on the fitting set)")
+	minSamples := flag.Int("min-samples", 500, "samples a league needs for its own recalibration")
+	bins := flag.Int("bins", 10, "reliability diagram bins")
+	dryRun := flag.Bool("n", false, "report only; do not write the parameter file")
+	flag.Parse()
+
+	if *sport != "hockey" && *sport != "soccer" && *sport != "all" {
+		fatalf("unknown sport %q (use hockey, soccer, or all)", *sport)
+	}
+	opts := options{holdout: *holdout, minSamples: *minSamples, bins: *bins}
+
+	params, err := config.LoadModelParams(*out)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fatalf("%v", err)
+	}
+
+	if *sport == "hockey" || *sport == "all" {
+		hp, err := fitHockey(*hockeyDB, *ratesPath, opts)
+		if err != nil {
+			fatalf("hockey: %v", err)
+		}
+		params.Hockey = hp
+	}
+	if *sport == "all" {
+		fmt.Println()
+	}
+	if *sport == "soccer" || *sport == "all" {
+		sp, err := fitSoccer(*soccerDB, opts)
+		if err != nil {
+			fatalf("soccer: %v", err)
+		}
+		params.Soccer = sp
+	}
+
+	if *dryRun {
+		return
+	}
+
+	params.Version++
+	params.FittedAt = time.Now().UTC().Format(time.RFC3339)
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(params); err != nil {
+		fatalf("encode params: %v", err)
+	}
+	enc.Close()
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		fatalf("write %s: %v", *out, err)
+	}
+	fmt.Printf("\nwrote model params version %d to %s\n", params.Version, *out)
+}
+
+// heldOut deterministically assigns a game to the holdout set.
+func heldOut(game string, frac float64) bool {
+	h := fnv.New32a()
+	h.Write([]byte(game))
+	return float64(h.Sum32()%1000) < frac*1000
+}
+
+// fitTable fits a default recalibration on preds and one per league with
+// at least minSamples of them.
+func fitTable(samples []sample, pred func(sample) float64, minSamples int) config.RecalParams {
+	byLeague := make(map[string][]sample)
+	for _, s := range samples {
+		byLeague[s.league] = append(byLeague[s.league], s)
+	}
+
+	rp := config.RecalParams{
+		Default: fitLogistic(samples, pred),
+		Leagues: make(map[string]config.LogisticParams),
+	}
+	for league, ls := range byLeague {
+		if league == "" || len(ls) < minSamples {
+			continue
+		}
+		rp.Leagues[league] = fitLogistic(ls, pred)
+	}
+	return rp
+}
+
+func fitLogistic(samples []sample, pred func(sample) float64) config.LogisticParams {
+	preds := make([]float64, len(samples))
+	outcomes := make([]float64, len(samples))
+	for i, s := range samples {
+		preds[i] = pred(s)
+		outcomes[i] = s.outcome
+	}
+	l := calibration.FitLogistic(preds, outcomes)
+	return config.LogisticParams{A: round4(l.A), B: round4(l.B), Samples: len(samples)}
+}
+
+func round4(v float64) float64 {
+	r := math.Round(v*1e4) / 1e4
+	if r == 0 {
+		return 0 // no "-0" in the file
+	}
+	return r
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
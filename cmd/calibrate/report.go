@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/charleschow/hft-trading/internal/core/calibration"
+)
+
+const barWidth = 40
+
+// report prints Brier score and log-loss of the base and fitted models by
+// league and by time bucket, with the Kalshi price's Brier score on the
+// samples that had one, then a reliability diagram of the fitted model.
+func report(title string, samples []sample, bucket func(float64) string, buckets []string, bins int) {
+	games := make(map[string]bool)
+	for _, s := range samples {
+		games[s.game] = true
+	}
+	fmt.Printf("=== %s — %d games, %d samples ===\n", title, len(games), len(samples))
+
+	byLeague := make(map[string][]sample)
+	byTime := make(map[string][]sample)
+	for _, s := range samples {
+		byLeague[s.league] = append(byLeague[s.league], s)
+		byTime[bucket(s.minLeft)] = append(byTime[bucket(s.minLeft)], s)
+	}
+	leagues := make([]string, 0, len(byLeague))
+	for l := range byLeague {
+		leagues = append(leagues, l)
+	}
+	sort.Strings(leagues)
+
+	fmt.Println("\nBy league:")
+	tw := newTable("LEAGUE")
+	for _, l := range leagues {
+		scoreRow(tw, displayLeague(l), byLeague[l])
+	}
+	scoreRow(tw, "ALL", samples)
+	tw.Flush()
+
+	fmt.Println("\nBy minutes left:")
+	tw = newTable("MIN LEFT")
+	for _, b := range buckets {
+		if ss := byTime[b]; len(ss) > 0 {
+			scoreRow(tw, b, ss)
+		}
+	}
+	tw.Flush()
+
+	fmt.Println("\nReliability (fitted model):")
+	preds, outcomes := columns(samples, func(s sample) float64 { return s.fit })
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  BIN\tN\tPRED\tOBS\t")
+	for _, b := range calibration.Reliability(preds, outcomes, bins) {
+		if b.Count == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "  %.2f-%.2f\t%d\t%.3f\t%.3f\t%s\n",
+			b.Lo, b.Hi, b.Count, b.Predicted, b.Observed, bar(b.Predicted, b.Observed))
+	}
+	tw.Flush()
+}
+
+func newTable(first string) *tabwriter.Writer {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  %s\tN\tBRIER base\tBRIER fit\tLOGLOSS base\tLOGLOSS fit\tKALSHI N\tKALSHI BRIER\t\n", first)
+	return tw
+}
+
+func scoreRow(tw *tabwriter.Writer, label string, samples []sample) {
+	base, outcomes := columns(samples, func(s sample) float64 { return s.base })
+	fit, _ := columns(samples, func(s sample) float64 { return s.fit })
+
+	var mPreds, mOutcomes []float64
+	for _, s := range samples {
+		if s.market > 0 {
+			mPreds = append(mPreds, s.market)
+			mOutcomes = append(mOutcomes, s.outcome)
+		}
+	}
+	market := "-"
+	if len(mPreds) > 0 {
+		market = fmt.Sprintf("%.4f", calibration.Brier(mPreds, mOutcomes))
+	}
+
+	fmt.Fprintf(tw, "  %s\t%d\t%.4f\t%.4f\t%.4f\t%.4f\t%d\t%s\t\n",
+		label, len(samples),
+		calibration.Brier(base, outcomes), calibration.Brier(fit, outcomes),
+		calibration.LogLoss(base, outcomes), calibration.LogLoss(fit, outcomes),
+		len(mPreds), market)
+}
+
+func columns(samples []sample, pred func(sample) float64) (preds, outcomes []float64) {
+	preds = make([]float64, len(samples))
+	outcomes = make([]float64, len(samples))
+	for i, s := range samples {
+		preds[i] = pred(s)
+		outcomes[i] = s.outcome
+	}
+	return preds, outcomes
+}
+
+// bar draws the observed rate as a bar with the predicted rate marked by
+// '|': a calibrated bin ends at the marker.
+func bar(pred, obs float64) string {
+	b := []byte(strings.Repeat("#", int(obs*barWidth+0.5)) + strings.Repeat(" ", barWidth+1))
+	b[min(barWidth, int(pred*barWidth+0.5))] = '|'
+	return strings.TrimRight(string(b), " ")
+}
+
+func displayLeague(league string) string {
+	if league == "" {
+		return "(none)"
+	}
+	return league
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/calibration"
+	soccerState "github.com/charleschow/hft-trading/internal/core/state/game/soccer"
+	soccerStrat "github.com/charleschow/hft-trading/internal/core/strategy/soccer"
+)
+
+// soccerRows reads every regulation snapshot of a match with a known
+// regulation result.
+const soccerRows = `SELECT t.game_id, COALESCE(t.league,''), COALESCE(t.half,''),
+	t.home_score, t.away_score, t.time_remain,
+	COALESCE(t.red_cards_home,0), COALESCE(t.red_cards_away,0),
+	t.pregame_home_pct, COALESCE(t.pregame_draw_pct,0), t.pregame_away_pct, COALESCE(t.pregame_g0,0),
+	COALESCE(t.kalshi_home_pct_l,0), COALESCE(t.kalshi_draw_pct_l,0), COALESCE(t.kalshi_away_pct_l,0),
+	o.actual_outcome
+FROM soccer_training t
+JOIN (SELECT game_id, MAX(actual_outcome) AS actual_outcome FROM soccer_training
+      WHERE actual_outcome IN ('home_win','draw','away_win') GROUP BY game_id) o ON o.game_id = t.game_id
+WHERE t.event_type != 'GAME FINISH' AND t.pregame_home_pct > 0 AND t.time_remain > 0`
+
+// soccerOutcomes are the 1X2 outcomes in sample order.
+var soccerOutcomes = [3]string{"home_win", "draw", "away_win"}
+
+type soccerRow struct {
+	ss      *soccerState.SoccerState
+	kalshi  [3]float64
+	outcome string
+}
+
+var soccerBuckets = []string{"75-90", "60-75", "45-60", "30-45", "15-30", " 0-15"}
+
+func soccerBucket(t float64) string {
+	switch {
+	case t > 75:
+		return "75-90"
+	case t > 60:
+		return "60-75"
+	case t > 45:
+		return "45-60"
+	case t > 30:
+		return "30-45"
+	case t > 15:
+		return "15-30"
+	default:
+		return " 0-15"
+	}
+}
+
+// fitSoccer fits the home/away and draw recalibrations of the in-play 1X2
+// model. The reports score each outcome as its own binary prediction,
+// after the three recalibrated prices are renormalized as the strategy
+// does.
+func fitSoccer(dbPath string, opts options) (config.SoccerModelParams, error) {
+	rows, err := loadSoccerRows(dbPath)
+	if err != nil {
+		return config.SoccerModelParams{}, err
+	}
+	if len(rows) == 0 {
+		return config.SoccerModelParams{}, fmt.Errorf("no usable snapshots in %s", dbPath)
+	}
+
+	var fitRows, reportRows []soccerRow
+	for _, r := range rows {
+		if opts.holdout > 0 && heldOut(r.ss.EID, opts.holdout) {
+			reportRows = append(reportRows, r)
+		} else {
+			fitRows = append(fitRows, r)
+		}
+	}
+	if opts.holdout <= 0 {
+		reportRows = fitRows
+	}
+
+	var wins, draws []sample
+	for i, s := range soccerSamples(fitRows) {
+		if i%3 == 1 {
+			draws = append(draws, s)
+		} else {
+			wins = append(wins, s)
+		}
+	}
+	base := func(s sample) float64 { return s.base }
+	winRP := fitTable(wins, base, opts.minSamples)
+	drawRP := fitTable(draws, base, opts.minSamples)
+	winT, drawT := calibration.TableFrom(winRP), calibration.TableFrom(drawRP)
+
+	held := soccerSamples(reportRows)
+	for i := 0; i < len(held); i += 3 {
+		league := held[i].league
+		h := winT.For(league).Apply(held[i].base)
+		d := drawT.For(league).Apply(held[i+1].base)
+		a := winT.For(league).Apply(held[i+2].base)
+		if total := h + d + a; total > 0 {
+			h, d, a = h/total, d/total, a/total
+		}
+		held[i].fit, held[i+1].fit, held[i+2].fit = h, d, a
+	}
+
+	fmt.Printf("soccer: win recalibration a=%.4f b=%.4f, draw a=%.4f b=%.4f, %d/%d league overrides\n\n",
+		winRP.Default.A, winRP.Default.B, drawRP.Default.A, drawRP.Default.B, len(winRP.Leagues), len(drawRP.Leagues))
+	report("Soccer", held, soccerBucket, soccerBuckets, opts.bins)
+
+	return config.SoccerModelParams{WinRecal: winRP, DrawRecal: drawRP}, nil
+}
+
+func loadSoccerRows(path string) ([]soccerRow, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+	defer db.Close()
+
+	rs, err := db.Query(soccerRows)
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", path, err)
+	}
+	defer rs.Close()
+
+	var rows []soccerRow
+	for rs.Next() {
+		var game, league, half string
+		var r soccerRow
+		ss := &soccerState.SoccerState{}
+		if err := rs.Scan(&game, &league, &half, &ss.HomeScore, &ss.AwayScore, &ss.TimeLeft,
+			&ss.HomeRedCards, &ss.AwayRedCards,
+			&ss.HomeStrength, &ss.DrawPct, &ss.AwayStrength, &ss.G0,
+			&r.kalshi[0], &r.kalshi[1], &r.kalshi[2], &r.outcome); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		ss.EID, ss.League, ss.Half = game, strings.ToLower(strings.TrimSpace(league)), half
+		if ss.IsRegulationOver() {
+			continue
+		}
+		r.ss = ss
+		rows = append(rows, r)
+	}
+	return rows, rs.Err()
+}
+
+// soccerSamples prices every row with the raw model, three samples per
+// row in soccerOutcomes order, the price in base.
+func soccerSamples(rows []soccerRow) []sample {
+	out := make([]sample, 0, 3*len(rows))
+	for _, r := range rows {
+		home, draw, away := soccerStrat.ModelProbs(r.ss)
+		for i, p := range [3]float64{home, draw, away} {
+			s := sample{
+				game: r.ss.EID, league: r.ss.League, minLeft: r.ss.TimeLeft,
+				base: p, fit: p, market: r.kalshi[i],
+			}
+			if r.outcome == soccerOutcomes[i] {
+				s.outcome = 1
+			}
+			out = append(out, s)
+		}
+	}
+	return out
+}
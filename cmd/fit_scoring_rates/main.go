@@ -0,0 +1,170 @@
+// Command fit_scoring_rates fits each hockey league's regulation scoring
+// rate from the final scores in the training DB and writes the table the
+// hockey models load (HOCKEY_SCORING_RATES_PATH).
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+	_ "modernc.org/sqlite"
+
+	"github.com/charleschow/hft-trading/internal/config"
+)
+
+// finalScores reads the last row of every game that has an outcome.
+const finalScores = `SELECT COALESCE(t.league,''), t.home_score, t.away_score, COALESCE(t.period,'')
+FROM training_snapshots t
+JOIN (SELECT game_id, MAX(id) AS id FROM training_snapshots
+      WHERE actual_outcome IS NOT NULL AND actual_outcome != ''
+      GROUP BY game_id) f ON t.id = f.id`
+
+const header = `# Hockey scoring rates — total regulation goals per 60 minutes, by league.
+#
+# Consumed by the hockey Poisson models (V3/V4). Leagues are matched on the
+# lowercased GoalServe competition name; anything not listed uses
+# default_goals_per_60.
+#
+# Regenerate from data/hockey_training.db with:
+#   go run ./cmd/fit_scoring_rates
+#
+# leagues.<league>:
+#   goals_per_60: average regulation goals (both teams) per game
+#   games:        finished games the rate was fitted from
+
+`
+
+type tally struct {
+	games int
+	goals int
+}
+
+func main() {
+	dbPath := flag.String("db", "data/hockey_training.db", "hockey training DB")
+	out := flag.String("out", "internal/config/hockey_scoring_rates.yaml", "rates file to write")
+	minGames := flag.Int("min-games", 20, "leagues with fewer finished games use the default rate")
+	dryRun := flag.Bool("n", false, "print the fitted rates without writing the file")
+	flag.Parse()
+
+	db, err := sql.Open("sqlite", *dbPath)
+	if err != nil {
+		fatalf("open %s: %v", *dbPath, err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(finalScores)
+	if err != nil {
+		fatalf("query final scores: %v", err)
+	}
+	defer rows.Close()
+
+	leagues := make(map[string]*tally)
+	var all tally
+	for rows.Next() {
+		var league, period string
+		var home, away int
+		if err := rows.Scan(&league, &home, &away, &period); err != nil {
+			fatalf("scan: %v", err)
+		}
+		goals := regulationGoals(home, away, period)
+		league = strings.ToLower(strings.TrimSpace(league))
+		t := leagues[league]
+		if t == nil {
+			t = &tally{}
+			leagues[league] = t
+		}
+		t.games++
+		t.goals += goals
+		all.games++
+		all.goals += goals
+	}
+	if err := rows.Err(); err != nil {
+		fatalf("read rows: %v", err)
+	}
+	if all.games == 0 {
+		fatalf("no finished games in %s", *dbPath)
+	}
+
+	rates := config.ScoringRates{
+		DefaultGoalsPer60: round2(float64(all.goals) / float64(all.games)),
+		Leagues:           make(map[string]config.LeagueRate),
+	}
+
+	names := make([]string, 0, len(leagues))
+	for name := range leagues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LEAGUE\tGAMES\tGOALS/60\t")
+	for _, name := range names {
+		t := leagues[name]
+		rate := round2(float64(t.goals) / float64(t.games))
+		note := ""
+		if name == "" || t.games < *minGames {
+			note = "(default)"
+		} else {
+			rates.Leagues[name] = config.LeagueRate{GoalsPer60: rate, Games: t.games}
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%.2f\t%s\n", displayName(name), t.games, rate, note)
+	}
+	fmt.Fprintf(tw, "ALL\t%d\t%.2f\t\n", all.games, rates.DefaultGoalsPer60)
+	tw.Flush()
+
+	if *dryRun {
+		return
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(rates); err != nil {
+		fatalf("encode rates: %v", err)
+	}
+	enc.Close()
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		fatalf("write %s: %v", *out, err)
+	}
+	fmt.Printf("\nwrote %d league rates to %s\n", len(rates.Leagues), *out)
+}
+
+// regulationGoals strips the deciding goal GoalServe adds to the final
+// score of a game won in overtime or a shootout.
+func regulationGoals(home, away int, period string) int {
+	total := home + away
+	if home == away {
+		return total
+	}
+	p := strings.ToLower(period)
+	for _, s := range []string{"overtime", "after ot", "shootout", "penalties", "after so"} {
+		if strings.Contains(p, s) {
+			return total - 1
+		}
+	}
+	return total
+}
+
+func displayName(league string) string {
+	if league == "" {
+		return "(none)"
+	}
+	return league
+}
+
+func round2(v float64) float64 {
+	return float64(int(v*100+0.5)) / 100
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
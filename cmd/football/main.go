@@ -1,7 +1,9 @@
 package main
 
 import (
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/goalserve_http"
 	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/odds"
 	"github.com/charleschow/hft-trading/internal/core/strategy"
 	footballStrat "github.com/charleschow/hft-trading/internal/core/strategy/football"
 	"github.com/charleschow/hft-trading/internal/events"
@@ -15,5 +17,11 @@ func main() {
 		BuildStrategy: func(cfg *config.Config) strategy.Strategy {
 			return footballStrat.NewStrategy()
 		},
+		BuildPregameProvider: func(cfg *config.Config) strategy.PregameProvider {
+			client := goalserve_http.NewPregameClient(cfg.GoalserveAPIKey)
+			return func() ([]odds.PregameOdds, error) {
+				return client.FetchFootballPregame()
+			}
+		},
 	})
 }
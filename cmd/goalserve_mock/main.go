@@ -17,6 +17,10 @@
 // Usage:
 //
 //	go run cmd/goalserve_mock/main.go
+//
+// To run without the real Kalshi API, start cmd/kalshi_mock first and set
+// KALSHI_BASE_URL=http://localhost:9300 — its default markets include one
+// game per sport.
 package main
 
 import (
@@ -87,7 +91,7 @@ type gameInfo struct {
 
 func main() {
 	fmt.Println("=== GoalServe Mock ===")
-	fmt.Println("Fetching active Kalshi markets...\n")
+	fmt.Print("Fetching active Kalshi markets...\n\n")
 
 	soccerGame, hockeyGame, footballGame := discoverGames()
 
@@ -129,7 +133,7 @@ func main() {
 
 func runSoccerMocks(g *gameInfo) {
 	fmt.Printf("── Soccer Mock 1: %s vs %s (%s) ──\n", g.homeTeam, g.awayTeam, g.league)
-	fmt.Println("  Sequence: 0-0 → 1-0 → RC → 2-0 → [false drop 1-0, rejected] → 2-1 → Finished 2-1\n")
+	fmt.Print("  Sequence: 0-0 → 1-0 → RC → 2-0 → [false drop 1-0, rejected] → 2-1 → Finished 2-1\n\n")
 
 	eid := fmt.Sprintf("MOCK-SOC-%d", time.Now().Unix())
 	runSoccerGame(eid, g.homeTeam, g.awayTeam, g.league,
@@ -153,7 +157,7 @@ func runSoccerMocks(g *gameInfo) {
 	)
 
 	fmt.Printf("\n── Soccer Mock 2: %s vs %s (%s) ──\n", g.homeTeam, g.awayTeam, g.league)
-	fmt.Println("  Sequence: 0-0 → 1-0 → 2-0 → [2-1 overturned → back to 2-0] → 2-1 → Finished 2-1\n")
+	fmt.Print("  Sequence: 0-0 → 1-0 → 2-0 → [2-1 overturned → back to 2-0] → 2-1 → Finished 2-1\n\n")
 
 	eid2 := fmt.Sprintf("MOCK-SOC2-%d", time.Now().Unix())
 	runSoccerOverturnGame(eid2, g.homeTeam, g.awayTeam, g.league)
@@ -161,7 +165,7 @@ func runSoccerMocks(g *gameInfo) {
 
 func runHockeyMocks(g *gameInfo) {
 	fmt.Printf("── Hockey Mock 1: %s vs %s (%s) ──\n", g.homeTeam, g.awayTeam, g.league)
-	fmt.Println("  OT game with false alarm: 0-0 → 1-0 → 1-1 → PPG 2-1 → [false drop 1-1, rejected] → 2-2 → OT 3-2\n")
+	fmt.Print("  OT game with false alarm: 0-0 → 1-0 → 1-1 → PPG 2-1 → [false drop 1-1, rejected] → 2-2 → OT 3-2\n\n")
 
 	hEid := fmt.Sprintf("MOCK-HOC-%d", time.Now().Unix())
 	runHockeyGame(hEid, g.homeTeam, g.awayTeam, g.league,
@@ -191,7 +195,7 @@ func runHockeyMocks(g *gameInfo) {
 	)
 
 	fmt.Printf("\n── Hockey Mock 2: %s vs %s (%s) ──\n", g.homeTeam, g.awayTeam, g.league)
-	fmt.Println("  Overturn game: 0-0 → 1-0 → 2-0 → [3-0 overturned → back to 2-0] → 3-0 → Finished 3-0\n")
+	fmt.Print("  Overturn game: 0-0 → 1-0 → 2-0 → [3-0 overturned → back to 2-0] → 3-0 → Finished 3-0\n\n")
 
 	hEid2 := fmt.Sprintf("MOCK-HOC2-%d", time.Now().Unix())
 	runHockeyOverturnGame(hEid2, g.homeTeam, g.awayTeam, g.league)
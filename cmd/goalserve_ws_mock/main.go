@@ -14,6 +14,11 @@
 //	GOALSERVE_WS_ENABLED=true
 //	GOALSERVE_WS_AUTH_URL=http://localhost:9200/auth
 //	GOALSERVE_WS_URL=ws://localhost:9200/ws
+//
+// To run fully offline, start cmd/kalshi_mock and set
+// KALSHI_BASE_URL=http://localhost:9300 for both this mock and the
+// trading processes (plus KALSHI_WS_URL=ws://localhost:9300/trade-api/ws/v2
+// for the latter).
 package main
 
 import (
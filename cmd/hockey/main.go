@@ -2,9 +2,11 @@ package main
 
 import (
 	"io"
+	"strings"
 
 	"github.com/charleschow/hft-trading/internal/adapters/outbound/goalserve_http"
 	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/calibration"
 	"github.com/charleschow/hft-trading/internal/core/odds"
 	"github.com/charleschow/hft-trading/internal/core/state/game"
 	"github.com/charleschow/hft-trading/internal/core/strategy"
@@ -12,6 +14,7 @@ import (
 	"github.com/charleschow/hft-trading/internal/core/training"
 	"github.com/charleschow/hft-trading/internal/events"
 	"github.com/charleschow/hft-trading/internal/process"
+	"github.com/charleschow/hft-trading/internal/telemetry"
 )
 
 func main() {
@@ -19,8 +22,40 @@ func main() {
 		Sport:    events.SportHockey,
 		SportKey: "hockey",
 		BuildStrategy: func(cfg *config.Config) strategy.Strategy {
+			rates, err := config.LoadScoringRates(cfg.HockeyScoringRatesPath)
+			if err != nil {
+				telemetry.Warnf("hockey scoring rates: %v (using NHL default)", err)
+			} else {
+				hockeyStrat.SetLeagueRates(rates.GoalsPer60(), rates.DefaultGoalsPer60)
+			}
+			if mp, err := config.LoadModelParams(cfg.ModelParamsPath); err != nil {
+				telemetry.Warnf("hockey model params: %v (using compiled-in defaults)", err)
+			} else {
+				d := mp.Hockey.Decay
+				hockeyStrat.SetModelParams(hockeyStrat.ModelParams{
+					Decay:      hockeyStrat.DecayParams{K: d.K, A: d.A, Theta: d.Theta, Eta: d.Eta, Lambda: d.Lambda},
+					ShareScale: mp.Hockey.ShareScale,
+					Recal:      calibration.TableFrom(mp.Hockey.Recal),
+				})
+				telemetry.Infof("hockey model params version %d loaded", mp.Version)
+			}
 			return hockeyStrat.NewStrategy()
 		},
+		BuildShadows: func(cfg *config.Config) []strategy.Shadow {
+			var shadows []strategy.Shadow
+			for _, id := range strings.Split(cfg.ShadowStrategies, ",") {
+				switch id = strings.TrimSpace(id); id {
+				case "":
+				case "v4":
+					shadows = append(shadows, strategy.Shadow{
+						ID: id, Strategy: hockeyStrat.NewStrategyWithModel(hockeyStrat.PoissonModel),
+					})
+				default:
+					telemetry.Warnf("hockey shadow strategy %q unknown, skipped", id)
+				}
+			}
+			return shadows
+		},
 		BuildPregameProvider: func(cfg *config.Config) strategy.PregameProvider {
 			client := goalserve_http.NewPregameClient(cfg.GoalserveAPIKey)
 			return func() ([]odds.PregameOdds, error) {
@@ -0,0 +1,795 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution"
+)
+
+type market struct {
+	kalshi_http.Market
+	series    string
+	yesBid    int
+	yesAsk    int
+	expiresAt time.Time
+}
+
+func (m *market) snapshot() kalshi_http.Market {
+	out := m.Market
+	out.Status = "active"
+	out.ExpectedExpirationTime = m.expiresAt.UTC().Format(time.RFC3339)
+	out.CloseTime = out.ExpectedExpirationTime
+	out.YesBidDollars = centsToDollars(m.yesBid)
+	out.YesAskDollars = centsToDollars(m.yesAsk)
+	out.NoBidDollars = centsToDollars(100 - m.yesAsk)
+	out.NoAskDollars = centsToDollars(100 - m.yesBid)
+	return out
+}
+
+// order is the wire shape returned by the order endpoints. It embeds the
+// fields the client decodes plus the extra ones Kalshi also sends.
+type order struct {
+	kalshi_http.OrderDetail
+	Action         string `json:"action"`
+	Type           string `json:"type"`
+	CreatedTime    string `json:"created_time"`
+	ExpirationTime string `json:"expiration_time,omitempty"`
+	OrderGroupID   string `json:"order_group_id,omitempty"`
+
+	limit     int
+	expiresAt time.Time
+}
+
+type position struct {
+	yes, no     int
+	exposure    int
+	feesPaid    int
+	totalTraded int
+}
+
+type exchange struct {
+	mu        sync.Mutex
+	markets   map[string]*market
+	tickers   []string // market tickers in script order
+	orders    map[string]*order
+	positions map[string]*position
+	fills     []kalshi_http.Fill
+	groups    map[string]*orderGroup
+	balance   int
+	seq       int
+
+	// onPrice is called (without mu held) after a market's book changes.
+	onPrice func(m kalshi_http.Market)
+
+	// userFeed carries fill and user_order messages to the WS hub. Written
+	// with mu held, so sends never block.
+	userFeed chan userMsg
+}
+
+// userMsg is one message for the authenticated user channels.
+type userMsg struct {
+	typ string // "fill" or "user_order"
+	msg map[string]any
+}
+
+func newExchange(s *script, balance int) *exchange {
+	ex := &exchange{
+		markets:   make(map[string]*market),
+		orders:    make(map[string]*order),
+		positions: make(map[string]*position),
+		groups:    make(map[string]*orderGroup),
+		balance:   balance,
+		userFeed:  make(chan userMsg, 1024),
+	}
+	now := time.Now()
+	for _, e := range s.Events {
+		for _, sm := range e.Markets {
+			ex.markets[sm.Ticker] = &market{
+				Market: kalshi_http.Market{
+					Ticker:            sm.Ticker,
+					EventTicker:       e.EventTicker,
+					Title:             e.Title,
+					YesSubTitle:       sm.YesSubTitle,
+					NoSubTitle:        sm.YesSubTitle,
+					MutuallyExclusive: true,
+				},
+				series:    e.Series,
+				yesBid:    sm.YesBid,
+				yesAsk:    sm.YesAsk,
+				expiresAt: now.Add(time.Duration(e.ExpiresInMin) * time.Minute),
+			}
+			ex.tickers = append(ex.tickers, sm.Ticker)
+		}
+	}
+	return ex
+}
+
+// run drives price movement: scripted moves fire at their offsets and,
+// when tick > 0, every market takes a bounded random step each interval.
+func (ex *exchange) run(tick time.Duration, step int, schedule []scriptedMove) {
+	start := time.Now()
+	for _, mv := range schedule {
+		time.AfterFunc(time.Until(start.Add(time.Duration(mv.AfterSec)*time.Second)), func() {
+			fmt.Fprintf(os.Stderr, "[script] %s -> %d/%d¢\n", mv.Ticker, mv.YesBid, mv.YesAsk)
+			ex.setBook(mv.Ticker, mv.YesBid, mv.YesAsk)
+		})
+	}
+	go ex.expireLoop()
+	if tick <= 0 || step <= 0 {
+		return
+	}
+	t := time.NewTicker(tick)
+	defer t.Stop()
+	for range t.C {
+		for _, tk := range ex.tickers {
+			delta := rand.Intn(2*step+1) - step
+			if delta == 0 {
+				continue
+			}
+			ex.mu.Lock()
+			m := ex.markets[tk]
+			bid, ask := m.yesBid+delta, m.yesAsk+delta
+			ex.mu.Unlock()
+			if bid < 1 || ask > 99 {
+				continue
+			}
+			ex.setBook(tk, bid, ask)
+		}
+	}
+}
+
+func (ex *exchange) setBook(ticker string, bid, ask int) {
+	ex.mu.Lock()
+	m, ok := ex.markets[ticker]
+	if !ok {
+		ex.mu.Unlock()
+		return
+	}
+	m.yesBid, m.yesAsk = bid, ask
+	ex.matchResting(m)
+	snap := m.snapshot()
+	ex.mu.Unlock()
+
+	if ex.onPrice != nil {
+		ex.onPrice(snap)
+	}
+}
+
+// expireLoop cancels resting orders once their expiration_ts passes, even
+// when the market is quiet.
+func (ex *exchange) expireLoop() {
+	for range time.Tick(time.Second) {
+		now := time.Now()
+		ex.mu.Lock()
+		for _, o := range ex.orders {
+			if o.Status == "resting" && !o.expiresAt.IsZero() && now.After(o.expiresAt) {
+				o.RemainingCount = 0
+				o.Status = "canceled"
+				ex.publishOrder(o)
+			}
+		}
+		ex.mu.Unlock()
+	}
+}
+
+// matchResting fills resting orders the new book has crossed, as maker at
+// the order's limit. Caller must hold mu.
+func (ex *exchange) matchResting(m *market) {
+	now := time.Now()
+	for _, o := range ex.orders {
+		if o.Status != "resting" || o.Ticker != m.Ticker {
+			continue
+		}
+		if !o.expiresAt.IsZero() && now.After(o.expiresAt) {
+			continue // expireLoop will cancel it
+		}
+		if ask := askFor(m, o.Side); ask <= o.limit {
+			ex.fill(m, o, o.limit, o.RemainingCount, true)
+			ex.publishOrder(o)
+		}
+	}
+}
+
+// fill executes n contracts of o at price. Caller must hold mu.
+func (ex *exchange) fill(m *market, o *order, price, n int, maker bool) {
+	if n > o.RemainingCount {
+		n = o.RemainingCount
+	}
+	if n <= 0 {
+		return
+	}
+	cost := price * n
+	var fee int
+	if maker {
+		fee = execution.FeeCents(false, n, price)
+		o.MakerFillCost += cost
+		o.MakerFees += fee
+	} else {
+		fee = execution.FeeCents(true, n, price)
+		o.TakerFillCost += cost
+		o.TakerFees += fee
+	}
+	o.FillCount += n
+	o.RemainingCount -= n
+	if o.RemainingCount == 0 {
+		o.Status = "executed"
+	}
+
+	ex.balance -= cost + fee
+	m.Volume += int64(n)
+
+	p := ex.positions[m.Ticker]
+	if p == nil {
+		p = &position{}
+		ex.positions[m.Ticker] = p
+	}
+	if o.Side == "yes" {
+		p.yes += n
+	} else {
+		p.no += n
+	}
+	p.exposure += cost
+	p.feesPaid += fee
+	p.totalTraded += n
+
+	if g := ex.groups[o.OrderGroupID]; g != nil {
+		ex.groupMatched(g, n)
+	}
+
+	fmt.Fprintf(os.Stderr, "[fill] %s %s %s x%d @ %d¢ (fee %d¢)\n", o.OrderID, o.Ticker, o.Side, n, price, fee)
+
+	yesPrice := price
+	if o.Side == "no" {
+		yesPrice = 100 - price
+	}
+	ex.seq++
+	ex.fills = append(ex.fills, kalshi_http.Fill{
+		TradeID:     fmt.Sprintf("mock-trade-%06d", ex.seq),
+		OrderID:     o.OrderID,
+		Ticker:      o.Ticker,
+		Side:        o.Side,
+		Action:      o.Action,
+		Count:       n,
+		YesPrice:    yesPrice,
+		NoPrice:     100 - yesPrice,
+		IsTaker:     !maker,
+		CreatedTime: time.Now().UTC().Format(time.RFC3339),
+	})
+	ex.publish("fill", map[string]any{
+		"trade_id":          ex.fills[len(ex.fills)-1].TradeID,
+		"order_id":          o.OrderID,
+		"client_order_id":   o.ClientOrderID,
+		"market_ticker":     o.Ticker,
+		"is_taker":          !maker,
+		"side":              o.Side,
+		"action":            o.Action,
+		"yes_price":         yesPrice,
+		"yes_price_dollars": centsToDollars(yesPrice),
+		"count":             n,
+		"count_fp":          fmt.Sprintf("%d.00", n),
+		"ts":                time.Now().Unix(),
+	})
+}
+
+// publishOrder queues o's current state for user_orders subscribers.
+// Caller must hold mu.
+func (ex *exchange) publishOrder(o *order) {
+	ex.publish("user_order", map[string]any{
+		"order_id":                o.OrderID,
+		"client_order_id":         o.ClientOrderID,
+		"ticker":                  o.Ticker,
+		"side":                    o.Side,
+		"status":                  o.Status,
+		"yes_price_dollars":       centsToDollars(o.YesPrice),
+		"fill_count_fp":           fmt.Sprintf("%d.00", o.FillCount),
+		"remaining_count_fp":      fmt.Sprintf("%d.00", o.RemainingCount),
+		"taker_fill_cost_dollars": centsToDollars(o.TakerFillCost),
+		"maker_fill_cost_dollars": centsToDollars(o.MakerFillCost),
+		"taker_fees_dollars":      centsToDollars(o.TakerFees),
+		"maker_fees_dollars":      centsToDollars(o.MakerFees),
+	})
+}
+
+// publish queues a user-channel message, dropping it if the hub is behind.
+func (ex *exchange) publish(typ string, msg map[string]any) {
+	select {
+	case ex.userFeed <- userMsg{typ: typ, msg: msg}:
+	default:
+		fmt.Fprintf(os.Stderr, "[ws] user feed full, dropping %s\n", typ)
+	}
+}
+
+// place validates and books a single order. Caller must hold mu.
+func (ex *exchange) place(req kalshi_http.CreateOrderRequest) (*order, error) {
+	m, ok := ex.markets[req.Ticker]
+	if !ok {
+		return nil, fmt.Errorf("market_not_found")
+	}
+	if req.Action != "buy" {
+		return nil, fmt.Errorf("only buy orders are supported by the mock")
+	}
+	if req.Side != "yes" && req.Side != "no" {
+		return nil, fmt.Errorf("invalid side %q", req.Side)
+	}
+	count := 1
+	if req.CountFP != "" {
+		v, err := strconv.ParseFloat(req.CountFP, 64)
+		if err != nil || v < 1 {
+			return nil, fmt.Errorf("invalid count_fp %q", req.CountFP)
+		}
+		count = int(v)
+	}
+	priceStr := req.YesPriceDollars
+	if req.Side == "no" {
+		priceStr = req.NoPriceDollars
+	}
+	limit := dollarsToCents(priceStr)
+	if limit < 1 || limit > 99 {
+		return nil, fmt.Errorf("invalid price %q", priceStr)
+	}
+
+	if req.OrderGroupID != "" {
+		g, ok := ex.groups[req.OrderGroupID]
+		if !ok {
+			return nil, fmt.Errorf("order_group_not_found")
+		}
+		if g.triggered {
+			return nil, fmt.Errorf("order group %s limit reached", g.id)
+		}
+	}
+
+	ex.seq++
+	now := time.Now()
+	o := &order{
+		OrderDetail: kalshi_http.OrderDetail{
+			OrderID:        fmt.Sprintf("mock-%06d", ex.seq),
+			ClientOrderID:  req.ClientID,
+			Ticker:         req.Ticker,
+			Status:         "resting",
+			Side:           req.Side,
+			RemainingCount: count,
+		},
+		Action:       req.Action,
+		Type:         req.Type,
+		CreatedTime:  now.UTC().Format(time.RFC3339),
+		OrderGroupID: req.OrderGroupID,
+		limit:        limit,
+	}
+	if req.Side == "yes" {
+		o.YesPrice, o.NoPrice = limit, 100-limit
+	} else {
+		o.NoPrice, o.YesPrice = limit, 100-limit
+	}
+	if req.ExpirationTS > 0 {
+		o.expiresAt = time.Unix(req.ExpirationTS, 0)
+		o.ExpirationTime = o.expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	// Booked before matching so a group triggered by this fill cancels
+	// the order's remainder along with the rest of the group.
+	ex.orders[o.OrderID] = o
+	if ask := askFor(m, req.Side); ask <= limit {
+		ex.fill(m, o, ask, count, false)
+	}
+	if o.RemainingCount > 0 && (req.TimeInForce == "immediate_or_cancel" || req.TimeInForce == "fill_or_kill") {
+		o.RemainingCount = 0
+		o.Status = "canceled"
+	}
+
+	ex.publishOrder(o)
+	return o, nil
+}
+
+// ── HTTP handlers ──────────────────────────────────────────────
+
+func (ex *exchange) handleMarkets(w http.ResponseWriter, r *http.Request) {
+	series := r.URL.Query().Get("series_ticker")
+	event := r.URL.Query().Get("event_ticker")
+
+	ex.mu.Lock()
+	out := make([]kalshi_http.Market, 0, len(ex.tickers))
+	for _, tk := range ex.tickers {
+		m := ex.markets[tk]
+		if series != "" && !strings.EqualFold(m.series, series) {
+			continue
+		}
+		if event != "" && !strings.EqualFold(m.EventTicker, event) {
+			continue
+		}
+		out = append(out, m.snapshot())
+	}
+	ex.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, kalshi_http.GetMarketsResponse{Markets: out})
+}
+
+func (ex *exchange) handleBalance(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	defer ex.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"balance":         ex.balance,
+		"portfolio_value": ex.portfolioValue(),
+		"updated_ts":      time.Now().Unix(),
+	})
+}
+
+// portfolioValue marks open positions at the current bid. Caller must hold mu.
+func (ex *exchange) portfolioValue() int {
+	v := 0
+	for tk, p := range ex.positions {
+		m := ex.markets[tk]
+		v += p.yes*m.yesBid + p.no*(100-m.yesAsk)
+	}
+	return v
+}
+
+func (ex *exchange) handlePositions(w http.ResponseWriter, r *http.Request) {
+	type marketPosition struct {
+		Ticker         string `json:"ticker"`
+		Position       int    `json:"position"`
+		MarketExposure int    `json:"market_exposure"`
+		RealizedPnl    int    `json:"realized_pnl"`
+		TotalTraded    int    `json:"total_traded"`
+		FeesPaid       int    `json:"fees_paid"`
+	}
+
+	ex.mu.Lock()
+	out := make([]marketPosition, 0, len(ex.positions))
+	for tk, p := range ex.positions {
+		out = append(out, marketPosition{
+			Ticker:         tk,
+			Position:       p.yes - p.no,
+			MarketExposure: p.exposure,
+			TotalTraded:    p.totalTraded,
+			FeesPaid:       p.feesPaid,
+		})
+	}
+	ex.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Ticker < out[j].Ticker })
+	writeJSON(w, http.StatusOK, map[string]any{
+		"market_positions": out,
+		"event_positions":  []any{},
+	})
+}
+
+func (ex *exchange) handleListOrders(w http.ResponseWriter, r *http.Request) {
+	ticker := r.URL.Query().Get("ticker")
+	status := r.URL.Query().Get("status")
+
+	ex.mu.Lock()
+	out := make([]order, 0, len(ex.orders))
+	for _, o := range ex.orders {
+		if ticker != "" && o.Ticker != ticker {
+			continue
+		}
+		if status != "" && o.Status != status {
+			continue
+		}
+		out = append(out, *o)
+	}
+	ex.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].OrderID < out[j].OrderID })
+	writeJSON(w, http.StatusOK, map[string]any{"orders": out, "cursor": ""})
+}
+
+func (ex *exchange) handleFills(w http.ResponseWriter, r *http.Request) {
+	var minTs int64
+	if v := r.URL.Query().Get("min_ts"); v != "" {
+		minTs, _ = strconv.ParseInt(v, 10, 64)
+	}
+
+	ex.mu.Lock()
+	out := make([]kalshi_http.Fill, 0, len(ex.fills))
+	for _, f := range ex.fills {
+		if t, err := time.Parse(time.RFC3339, f.CreatedTime); err == nil && t.Unix() >= minTs {
+			out = append(out, f)
+		}
+	}
+	ex.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"fills": out, "cursor": ""})
+}
+
+func (ex *exchange) handleCreateOrder(w http.ResponseWriter, r *http.Request) {
+	var req kalshi_http.CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+
+	ex.mu.Lock()
+	o, err := ex.place(req)
+	var out order
+	if o != nil {
+		out = *o
+	}
+	ex.mu.Unlock()
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_order", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]any{"order": out})
+}
+
+func (ex *exchange) handleBatchCreate(w http.ResponseWriter, r *http.Request) {
+	var req kalshi_http.BatchCreateOrdersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+
+	type result struct {
+		Order *order    `json:"order"`
+		Error *apiError `json:"error"`
+	}
+	results := make([]result, 0, len(req.Orders))
+
+	ex.mu.Lock()
+	for _, r := range req.Orders {
+		o, err := ex.place(r)
+		if err != nil {
+			results = append(results, result{Error: &apiError{Code: "invalid_order", Message: err.Error()}})
+			continue
+		}
+		cp := *o
+		results = append(results, result{Order: &cp})
+	}
+	ex.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]any{"orders": results})
+}
+
+func (ex *exchange) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	o, ok := ex.orders[r.PathValue("id")]
+	var out order
+	if ok {
+		out = *o
+	}
+	ex.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"order": out})
+}
+
+func (ex *exchange) handleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	out, reduced, ok := ex.cancel(r.PathValue("id"))
+	ex.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"order": out, "reduced_by": reduced})
+}
+
+func (ex *exchange) handleBatchCancel(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IDs []string `json:"ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+
+	type result struct {
+		OrderID   string    `json:"order_id"`
+		Order     *order    `json:"order,omitempty"`
+		ReducedBy int       `json:"reduced_by"`
+		Error     *apiError `json:"error,omitempty"`
+	}
+	results := make([]result, 0, len(req.IDs))
+
+	ex.mu.Lock()
+	for _, id := range req.IDs {
+		out, reduced, ok := ex.cancel(id)
+		if !ok {
+			results = append(results, result{OrderID: id, Error: &apiError{Code: "not_found", Message: "order not found"}})
+			continue
+		}
+		results = append(results, result{OrderID: id, Order: &out, ReducedBy: reduced})
+	}
+	ex.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"orders": results})
+}
+
+func (ex *exchange) handleAmendOrder(w http.ResponseWriter, r *http.Request) {
+	var req kalshi_http.AmendOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+	priceStr := req.YesPriceDollars
+	if req.Side == "no" {
+		priceStr = req.NoPriceDollars
+	}
+	limit := dollarsToCents(priceStr)
+	if limit < 1 || limit > 99 {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", fmt.Sprintf("invalid price %q", priceStr))
+		return
+	}
+
+	ex.mu.Lock()
+	o, ok := ex.orders[r.PathValue("id")]
+	if !ok {
+		ex.mu.Unlock()
+		writeError(w, http.StatusNotFound, "not_found", "order not found")
+		return
+	}
+	if o.Status != "resting" || o.Side != req.Side || o.Ticker != req.Ticker {
+		ex.mu.Unlock()
+		writeError(w, http.StatusBadRequest, "invalid_order", "order cannot be amended")
+		return
+	}
+	old := *o
+	o.limit = limit
+	if o.Side == "yes" {
+		o.YesPrice, o.NoPrice = limit, 100-limit
+	} else {
+		o.NoPrice, o.YesPrice = limit, 100-limit
+	}
+	if m := ex.markets[o.Ticker]; m != nil {
+		if ask := askFor(m, o.Side); ask <= limit {
+			ex.fill(m, o, ask, o.RemainingCount, false)
+		}
+	}
+	ex.publishOrder(o)
+	out := *o
+	ex.mu.Unlock()
+
+	fmt.Fprintf(os.Stderr, "[amend] %s %s %s %d¢ -> %d¢\n", out.OrderID, out.Ticker, out.Side, old.limit, limit)
+	writeJSON(w, http.StatusOK, map[string]any{"old_order": old, "order": out})
+}
+
+func (ex *exchange) handleDecreaseOrder(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ReduceBy *int `json:"reduce_by"`
+		ReduceTo *int `json:"reduce_to"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+	if (req.ReduceBy == nil) == (req.ReduceTo == nil) {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "exactly one of reduce_by or reduce_to is required")
+		return
+	}
+
+	ex.mu.Lock()
+	o, ok := ex.orders[r.PathValue("id")]
+	if !ok {
+		ex.mu.Unlock()
+		writeError(w, http.StatusNotFound, "not_found", "order not found")
+		return
+	}
+	if o.Status == "resting" {
+		to := o.RemainingCount
+		if req.ReduceTo != nil {
+			to = *req.ReduceTo
+		} else {
+			to -= *req.ReduceBy
+		}
+		if to < o.RemainingCount {
+			o.RemainingCount = max(to, 0)
+			if o.RemainingCount == 0 {
+				o.Status = "canceled"
+			}
+			ex.publishOrder(o)
+		}
+	}
+	out := *o
+	ex.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"order": out})
+}
+
+// cancel zeroes a resting order's remaining count. Caller must hold mu.
+func (ex *exchange) cancel(id string) (order, int, bool) {
+	o, ok := ex.orders[id]
+	if !ok {
+		return order{}, 0, false
+	}
+	reduced := 0
+	if o.Status == "resting" {
+		reduced = o.RemainingCount
+		o.RemainingCount = 0
+		o.Status = "canceled"
+		ex.publishOrder(o)
+	}
+	return *o, reduced, true
+}
+
+// ── Auth ───────────────────────────────────────────────────────
+
+// authed checks that Kalshi auth headers, when present, have the shape
+// kalshi_auth.Signer produces. The signature itself is not verified — the
+// mock has no access to the public key and accepts any RSA-PSS signature.
+func authed(require bool, h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("KALSHI-ACCESS-KEY")
+		sig := r.Header.Get("KALSHI-ACCESS-SIGNATURE")
+		ts := r.Header.Get("KALSHI-ACCESS-TIMESTAMP")
+
+		if key == "" && sig == "" && ts == "" {
+			if require {
+				writeError(w, http.StatusUnauthorized, "missing_auth", "missing KALSHI-ACCESS-* headers")
+				return
+			}
+			h(w, r)
+			return
+		}
+
+		if key == "" || sig == "" || ts == "" {
+			writeError(w, http.StatusUnauthorized, "invalid_auth", "incomplete KALSHI-ACCESS-* headers")
+			return
+		}
+		ms, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil || time.Since(time.UnixMilli(ms)).Abs() > 5*time.Minute {
+			writeError(w, http.StatusUnauthorized, "invalid_auth", "bad KALSHI-ACCESS-TIMESTAMP")
+			return
+		}
+		// RSA-PSS signatures are exactly the modulus size (>= 1024 bits).
+		raw, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil || len(raw) < 128 {
+			writeError(w, http.StatusUnauthorized, "invalid_auth", "bad KALSHI-ACCESS-SIGNATURE")
+			return
+		}
+		h(w, r)
+	})
+}
+
+// ── Helpers ────────────────────────────────────────────────────
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, msg string) {
+	writeJSON(w, status, map[string]apiError{"error": {Code: code, Message: msg}})
+}
+
+func askFor(m *market, side string) int {
+	if side == "no" {
+		return 100 - m.yesBid
+	}
+	return m.yesAsk
+}
+
+func centsToDollars(c int) string {
+	return fmt.Sprintf("%.4f", float64(c)/100.0)
+}
+
+func dollarsToCents(s string) int {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(math.Round(v * 100))
+}
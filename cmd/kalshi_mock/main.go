@@ -0,0 +1,117 @@
+// kalshi_mock is a local stand-in for the Kalshi trade API. It implements
+// the subset of specs/openapi.yaml and specs/asyncapi.yaml the trading
+// processes and the GoalServe mocks actually use:
+//
+//	GET    /trade-api/v2/markets
+//	GET    /trade-api/v2/portfolio/balance
+//	GET    /trade-api/v2/portfolio/positions
+//	GET    /trade-api/v2/portfolio/fills
+//	GET    /trade-api/v2/portfolio/orders
+//	POST   /trade-api/v2/portfolio/orders
+//	POST   /trade-api/v2/portfolio/orders/batched
+//	DELETE /trade-api/v2/portfolio/orders/batched
+//	GET    /trade-api/v2/portfolio/orders/{id}
+//	DELETE /trade-api/v2/portfolio/orders/{id}
+//	POST   /trade-api/v2/portfolio/orders/{id}/amend
+//	POST   /trade-api/v2/portfolio/orders/{id}/decrease
+//	GET    /trade-api/v2/portfolio/order_groups
+//	POST   /trade-api/v2/portfolio/order_groups/create
+//	GET    /trade-api/v2/portfolio/order_groups/{id}
+//	DELETE /trade-api/v2/portfolio/order_groups/{id}
+//	PUT    /trade-api/v2/portfolio/order_groups/{id}/reset
+//	PUT    /trade-api/v2/portfolio/order_groups/{id}/trigger
+//	PUT    /trade-api/v2/portfolio/order_groups/{id}/limit
+//	WS     /trade-api/ws/v2  (ticker, orderbook_delta, fill, user_orders)
+//
+// Markets come from a script file (-script) or a built-in default set of
+// one NHL, one EPL and one NFL game. Prices random-walk every -tick, and
+// any scripted price moves in the file are applied at their offsets.
+//
+// Auth headers are checked for shape only: any RSA-PSS signature produced
+// by kalshi_auth.Signer is accepted. Unsigned requests are accepted unless
+// -require-auth is set, so the stack can run without a key.
+//
+// Usage:
+//
+//	go run ./cmd/kalshi_mock
+//
+// Then point the other binaries at it:
+//
+//	KALSHI_BASE_URL=http://localhost:9300
+//	KALSHI_WS_URL=ws://localhost:9300/trade-api/ws/v2
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+func main() {
+	addr := flag.String("addr", ":9300", "listen address")
+	scriptPath := flag.String("script", "", "JSON market script (default: built-in markets)")
+	tick := flag.Duration("tick", 5*time.Second, "random-walk interval (0 disables)")
+	step := flag.Int("step", 2, "max random-walk step in cents")
+	balance := flag.Int("balance", 100000, "starting balance in cents")
+	requireAuth := flag.Bool("require-auth", false, "reject portfolio requests without Kalshi auth headers")
+	flag.Parse()
+
+	script, err := loadScript(*scriptPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "script: %v\n", err)
+		os.Exit(1)
+	}
+	if script.TickIntervalSec > 0 {
+		*tick = time.Duration(script.TickIntervalSec) * time.Second
+	}
+	if script.MaxStepCents > 0 {
+		*step = script.MaxStepCents
+	}
+
+	ex := newExchange(script, *balance)
+	hub := newHub(ex)
+	ex.onPrice = hub.broadcast
+
+	fmt.Fprintf(os.Stderr, "Scripted markets:\n")
+	for _, e := range script.Events {
+		fmt.Fprintf(os.Stderr, "  [%s] %s\n", e.EventTicker, e.Title)
+		for _, m := range e.Markets {
+			fmt.Fprintf(os.Stderr, "      %-40s %2d/%2d¢\n", m.Ticker, m.YesBid, m.YesAsk)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /trade-api/v2/markets", ex.handleMarkets)
+	mux.Handle("GET /trade-api/v2/portfolio/balance", authed(*requireAuth, ex.handleBalance))
+	mux.Handle("GET /trade-api/v2/portfolio/positions", authed(*requireAuth, ex.handlePositions))
+	mux.Handle("GET /trade-api/v2/portfolio/fills", authed(*requireAuth, ex.handleFills))
+	mux.Handle("GET /trade-api/v2/portfolio/orders", authed(*requireAuth, ex.handleListOrders))
+	mux.Handle("POST /trade-api/v2/portfolio/orders", authed(*requireAuth, ex.handleCreateOrder))
+	mux.Handle("POST /trade-api/v2/portfolio/orders/batched", authed(*requireAuth, ex.handleBatchCreate))
+	mux.Handle("DELETE /trade-api/v2/portfolio/orders/batched", authed(*requireAuth, ex.handleBatchCancel))
+	mux.Handle("GET /trade-api/v2/portfolio/orders/{id}", authed(*requireAuth, ex.handleGetOrder))
+	mux.Handle("DELETE /trade-api/v2/portfolio/orders/{id}", authed(*requireAuth, ex.handleCancelOrder))
+	mux.Handle("POST /trade-api/v2/portfolio/orders/{id}/amend", authed(*requireAuth, ex.handleAmendOrder))
+	mux.Handle("POST /trade-api/v2/portfolio/orders/{id}/decrease", authed(*requireAuth, ex.handleDecreaseOrder))
+	mux.Handle("GET /trade-api/v2/portfolio/order_groups", authed(*requireAuth, ex.handleListOrderGroups))
+	mux.Handle("POST /trade-api/v2/portfolio/order_groups/create", authed(*requireAuth, ex.handleCreateOrderGroup))
+	mux.Handle("GET /trade-api/v2/portfolio/order_groups/{id}", authed(*requireAuth, ex.handleGetOrderGroup))
+	mux.Handle("DELETE /trade-api/v2/portfolio/order_groups/{id}", authed(*requireAuth, ex.handleDeleteOrderGroup))
+	mux.Handle("PUT /trade-api/v2/portfolio/order_groups/{id}/reset", authed(*requireAuth, ex.handleResetOrderGroup))
+	mux.Handle("PUT /trade-api/v2/portfolio/order_groups/{id}/trigger", authed(*requireAuth, ex.handleTriggerOrderGroup))
+	mux.Handle("PUT /trade-api/v2/portfolio/order_groups/{id}/limit", authed(*requireAuth, ex.handleOrderGroupLimit))
+	mux.Handle("GET /trade-api/ws/v2", authed(*requireAuth, hub.handleWS))
+
+	go ex.run(*tick, *step, script.Schedule)
+
+	fmt.Fprintf(os.Stderr, "\nKalshi Mock listening on %s\n", *addr)
+	fmt.Fprintf(os.Stderr, "  REST: http://localhost%s/trade-api/v2\n", *addr)
+	fmt.Fprintf(os.Stderr, "  WS:   ws://localhost%s/trade-api/ws/v2\n", *addr)
+
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Fprintf(os.Stderr, "server: %v\n", err)
+		os.Exit(1)
+	}
+}
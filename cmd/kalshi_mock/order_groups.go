@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+)
+
+// orderGroupWindow is the rolling window Kalshi measures a group's
+// matched contracts over.
+const orderGroupWindow = 15 * time.Second
+
+type orderGroup struct {
+	id        string
+	limit     int
+	triggered bool
+	matched   []groupMatch
+}
+
+type groupMatch struct {
+	at time.Time
+	n  int
+}
+
+// windowCount drops matches older than the window and sums the rest.
+func (g *orderGroup) windowCount(now time.Time) int {
+	keep := g.matched[:0]
+	total := 0
+	for _, m := range g.matched {
+		if now.Sub(m.at) <= orderGroupWindow {
+			keep = append(keep, m)
+			total += m.n
+		}
+	}
+	g.matched = keep
+	return total
+}
+
+// groupMatched counts n matched contracts against g and triggers it once
+// the window total reaches the limit. Caller must hold mu.
+func (ex *exchange) groupMatched(g *orderGroup, n int) {
+	now := time.Now()
+	g.matched = append(g.matched, groupMatch{at: now, n: n})
+	if !g.triggered && g.windowCount(now) >= g.limit {
+		fmt.Fprintf(os.Stderr, "[group] %s hit its %d-contract limit\n", g.id, g.limit)
+		ex.triggerGroup(g)
+	}
+}
+
+// triggerGroup cancels every resting order in g and blocks new ones until
+// it is reset. Caller must hold mu.
+func (ex *exchange) triggerGroup(g *orderGroup) {
+	g.triggered = true
+	for id, o := range ex.orders {
+		if o.OrderGroupID == g.id {
+			ex.cancel(id)
+		}
+	}
+}
+
+func (ex *exchange) groupOrders(id string) []string {
+	out := []string{}
+	for oid, o := range ex.orders {
+		if o.OrderGroupID == id {
+			out = append(out, oid)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+type groupLimitBody struct {
+	ContractsLimit   int    `json:"contracts_limit"`
+	ContractsLimitFP string `json:"contracts_limit_fp"`
+}
+
+func (b groupLimitBody) limit() int {
+	if b.ContractsLimit > 0 {
+		return b.ContractsLimit
+	}
+	var v float64
+	fmt.Sscanf(b.ContractsLimitFP, "%f", &v)
+	return int(v)
+}
+
+func (ex *exchange) handleCreateOrderGroup(w http.ResponseWriter, r *http.Request) {
+	var req groupLimitBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+	limit := req.limit()
+	if limit < 1 {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "contracts_limit must be >= 1")
+		return
+	}
+
+	ex.mu.Lock()
+	ex.seq++
+	id := fmt.Sprintf("mock-group-%06d", ex.seq)
+	ex.groups[id] = &orderGroup{id: id, limit: limit}
+	ex.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]any{"order_group_id": id})
+}
+
+func (ex *exchange) handleListOrderGroups(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	out := make([]map[string]any, 0, len(ex.groups))
+	for _, g := range ex.groups {
+		out = append(out, map[string]any{
+			"id":                     g.id,
+			"contracts_limit":        g.limit,
+			"contracts_limit_fp":     fmt.Sprintf("%d.00", g.limit),
+			"is_auto_cancel_enabled": true,
+		})
+	}
+	ex.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i]["id"].(string) < out[j]["id"].(string) })
+	writeJSON(w, http.StatusOK, map[string]any{"order_groups": out})
+}
+
+func (ex *exchange) handleGetOrderGroup(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	g, ok := ex.groups[r.PathValue("id")]
+	var resp map[string]any
+	if ok {
+		resp = map[string]any{
+			"is_auto_cancel_enabled": true,
+			"contracts_limit":        g.limit,
+			"contracts_limit_fp":     fmt.Sprintf("%d.00", g.limit),
+			"orders":                 ex.groupOrders(g.id),
+		}
+	}
+	ex.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (ex *exchange) handleDeleteOrderGroup(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	g, ok := ex.groups[r.PathValue("id")]
+	if ok {
+		ex.triggerGroup(g)
+		delete(ex.groups, g.id)
+	}
+	ex.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (ex *exchange) handleResetOrderGroup(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	g, ok := ex.groups[r.PathValue("id")]
+	if ok {
+		g.triggered = false
+		g.matched = nil
+	}
+	ex.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (ex *exchange) handleTriggerOrderGroup(w http.ResponseWriter, r *http.Request) {
+	ex.mu.Lock()
+	g, ok := ex.groups[r.PathValue("id")]
+	if ok {
+		ex.triggerGroup(g)
+	}
+	ex.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
+
+func (ex *exchange) handleOrderGroupLimit(w http.ResponseWriter, r *http.Request) {
+	var req groupLimitBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", err.Error())
+		return
+	}
+	limit := req.limit()
+	if limit < 1 {
+		writeError(w, http.StatusBadRequest, "invalid_parameters", "contracts_limit must be >= 1")
+		return
+	}
+
+	ex.mu.Lock()
+	g, ok := ex.groups[r.PathValue("id")]
+	if ok {
+		g.limit = limit
+		if !g.triggered && g.windowCount(time.Now()) >= limit {
+			ex.triggerGroup(g)
+		}
+	}
+	ex.mu.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "not_found", "order group not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{})
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// script describes the markets the mock serves and how their prices move.
+//
+//	{
+//	  "tick_interval_sec": 5,
+//	  "max_step_cents": 2,
+//	  "events": [{
+//	    "series": "KXNHLGAME",
+//	    "event_ticker": "KXNHLGAME-26OCT16PITNYR",
+//	    "title": "Pittsburgh Penguins at New York Rangers Winner?",
+//	    "expires_in_min": 180,
+//	    "markets": [
+//	      {"ticker": "KXNHLGAME-26OCT16PITNYR-NYR", "yes_sub_title": "New York Rangers", "yes_bid": 55, "yes_ask": 57}
+//	    ]
+//	  }],
+//	  "schedule": [
+//	    {"after_sec": 60, "ticker": "KXNHLGAME-26OCT16PITNYR-NYR", "yes_bid": 70, "yes_ask": 72}
+//	  ]
+//	}
+type script struct {
+	TickIntervalSec int            `json:"tick_interval_sec"`
+	MaxStepCents    int            `json:"max_step_cents"`
+	Events          []scriptEvent  `json:"events"`
+	Schedule        []scriptedMove `json:"schedule"`
+}
+
+type scriptEvent struct {
+	Series       string         `json:"series"`
+	EventTicker  string         `json:"event_ticker"`
+	Title        string         `json:"title"`
+	ExpiresInMin int            `json:"expires_in_min"`
+	Markets      []scriptMarket `json:"markets"`
+}
+
+type scriptMarket struct {
+	Ticker      string `json:"ticker"`
+	YesSubTitle string `json:"yes_sub_title"`
+	YesBid      int    `json:"yes_bid"`
+	YesAsk      int    `json:"yes_ask"`
+}
+
+// scriptedMove sets a market's top of book AfterSec seconds after startup.
+type scriptedMove struct {
+	AfterSec int    `json:"after_sec"`
+	Ticker   string `json:"ticker"`
+	YesBid   int    `json:"yes_bid"`
+	YesAsk   int    `json:"yes_ask"`
+}
+
+func loadScript(path string) (*script, error) {
+	if path == "" {
+		return defaultScript(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s script
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(s.Events) == 0 {
+		return nil, fmt.Errorf("%s defines no events", path)
+	}
+	for i := range s.Events {
+		e := &s.Events[i]
+		if e.Series == "" {
+			e.Series = strings.SplitN(e.EventTicker, "-", 2)[0]
+		}
+		if e.ExpiresInMin == 0 {
+			e.ExpiresInMin = 180
+		}
+	}
+	return &s, nil
+}
+
+// defaultScript mirrors the fallback games in goalserve_ws_mock so the two
+// mocks line up without any configuration.
+func defaultScript() *script {
+	date := strings.ToUpper(time.Now().Format("06Jan02"))
+	nhl := "KXNHLGAME-" + date + "PITNYR"
+	epl := "KXEPLGAME-" + date + "ARSCHE"
+	nfl := "KXNFLGAME-" + date + "DALPHI"
+
+	return &script{
+		Events: []scriptEvent{
+			{
+				Series: "KXNHLGAME", EventTicker: nhl, ExpiresInMin: 180,
+				Title: "Pittsburgh Penguins at New York Rangers Winner?",
+				Markets: []scriptMarket{
+					{Ticker: nhl + "-NYR", YesSubTitle: "New York Rangers", YesBid: 55, YesAsk: 57},
+					{Ticker: nhl + "-PIT", YesSubTitle: "Pittsburgh Penguins", YesBid: 43, YesAsk: 45},
+				},
+			},
+			{
+				Series: "KXEPLGAME", EventTicker: epl, ExpiresInMin: 180,
+				Title: "Arsenal vs Chelsea Winner?",
+				Markets: []scriptMarket{
+					{Ticker: epl + "-ARS", YesSubTitle: "Arsenal", YesBid: 48, YesAsk: 50},
+					{Ticker: epl + "-TIE", YesSubTitle: "Tie", YesBid: 26, YesAsk: 28},
+					{Ticker: epl + "-CHE", YesSubTitle: "Chelsea", YesBid: 23, YesAsk: 25},
+				},
+			},
+			{
+				Series: "KXNFLGAME", EventTicker: nfl, ExpiresInMin: 240,
+				Title: "Dallas Cowboys at Philadelphia Eagles Winner?",
+				Markets: []scriptMarket{
+					{Ticker: nfl + "-PHI", YesSubTitle: "Philadelphia Eagles", YesBid: 60, YesAsk: 62},
+					{Ticker: nfl + "-DAL", YesSubTitle: "Dallas Cowboys", YesBid: 38, YesAsk: 40},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(_ *http.Request) bool { return true },
+}
+
+// Kalshi pings every 10s; kalshi_ws times out after 30s of silence.
+const pingInterval = 10 * time.Second
+
+// hub fans market updates out to every connection subscribed to them.
+type hub struct {
+	ex *exchange
+
+	mu    sync.Mutex
+	conns map[*wsConn]struct{}
+}
+
+// wsConn is one client connection. Writes are serialized through mu
+// (gorilla supports a single concurrent writer).
+type wsConn struct {
+	conn *websocket.Conn
+
+	mu      sync.Mutex
+	nextSID int
+	subs    map[int]*subscription
+}
+
+// subscription is one channel subscription. orderbook_delta subscriptions
+// carry a per-sid sequence number, as on the real feed.
+type subscription struct {
+	channel string
+	tickers map[string]bool
+	seq     int64
+}
+
+type wsCommand struct {
+	ID     int    `json:"id"`
+	Cmd    string `json:"cmd"`
+	Params struct {
+		Channels            []string `json:"channels"`
+		MarketTickers       []string `json:"market_tickers"`
+		SendInitialSnapshot bool     `json:"send_initial_snapshot"`
+		SIDs                []int    `json:"sids"`
+	} `json:"params"`
+}
+
+func newHub(ex *exchange) *hub {
+	h := &hub{ex: ex, conns: make(map[*wsConn]struct{})}
+	go h.userLoop()
+	return h
+}
+
+// userChannel maps a user message type to the channel that carries it.
+var userChannel = map[string]string{
+	"fill":       "fill",
+	"user_order": "user_orders",
+}
+
+func (h *hub) handleWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	c := &wsConn{conn: conn, subs: make(map[int]*subscription)}
+
+	h.mu.Lock()
+	h.conns[c] = struct{}{}
+	h.mu.Unlock()
+	fmt.Fprintf(os.Stderr, "[ws] client connected from %s\n", r.RemoteAddr)
+
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		h.mu.Lock()
+		delete(h.conns, c)
+		h.mu.Unlock()
+		conn.Close()
+		fmt.Fprintf(os.Stderr, "[ws] client disconnected from %s\n", r.RemoteAddr)
+	}()
+
+	go c.pingLoop(done)
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var cmd wsCommand
+		if err := json.Unmarshal(data, &cmd); err != nil {
+			c.send(map[string]any{"id": 0, "type": "error", "msg": map[string]any{"code": 1, "msg": "invalid json"}})
+			continue
+		}
+		switch cmd.Cmd {
+		case "subscribe":
+			h.subscribe(c, cmd)
+		case "unsubscribe":
+			c.mu.Lock()
+			for _, sid := range cmd.Params.SIDs {
+				delete(c.subs, sid)
+			}
+			c.mu.Unlock()
+			c.send(map[string]any{"id": cmd.ID, "type": "unsubscribed", "msg": map[string]any{"sids": cmd.Params.SIDs}})
+		default:
+			c.send(map[string]any{"id": cmd.ID, "type": "error", "msg": map[string]any{"code": 5, "msg": "unknown command " + cmd.Cmd}})
+		}
+	}
+}
+
+// subscribe registers one subscription per requested channel and, when
+// asked, sends the current state of each requested market.
+func (h *hub) subscribe(c *wsConn, cmd wsCommand) {
+	for _, ch := range cmd.Params.Channels {
+		switch ch {
+		case "ticker", "orderbook_delta", "fill", "user_orders":
+		default:
+			c.send(map[string]any{"id": cmd.ID, "type": "error", "msg": map[string]any{"code": 8, "msg": "unsupported channel " + ch}})
+			return
+		}
+	}
+
+	h.ex.mu.Lock()
+	var snaps []kalshi_http.Market
+	for _, t := range cmd.Params.MarketTickers {
+		if m, ok := h.ex.markets[t]; ok {
+			snaps = append(snaps, m.snapshot())
+		}
+	}
+	h.ex.mu.Unlock()
+
+	for _, ch := range cmd.Params.Channels {
+		c.mu.Lock()
+		c.nextSID++
+		sid := c.nextSID
+		sub := &subscription{channel: ch, tickers: make(map[string]bool, len(cmd.Params.MarketTickers))}
+		for _, t := range cmd.Params.MarketTickers {
+			sub.tickers[t] = true
+		}
+		c.subs[sid] = sub
+
+		// Orderbook subscriptions always start with a snapshot.
+		var frames []map[string]any
+		if ch == "orderbook_delta" || cmd.Params.SendInitialSnapshot {
+			for _, m := range snaps {
+				frames = append(frames, c.frame(sid, sub, m))
+			}
+		}
+		c.mu.Unlock()
+
+		c.send(map[string]any{"id": cmd.ID, "type": "subscribed", "msg": map[string]any{"channel": ch, "sid": sid}})
+		for _, f := range frames {
+			c.send(f)
+		}
+	}
+}
+
+// broadcast pushes a market change to every subscription covering m.
+// Orderbook subscribers get a fresh snapshot rather than deltas, which
+// the client handles identically to a re-snapshot.
+func (h *hub) broadcast(m kalshi_http.Market) {
+	h.mu.Lock()
+	conns := make([]*wsConn, 0, len(h.conns))
+	for c := range h.conns {
+		conns = append(conns, c)
+	}
+	h.mu.Unlock()
+
+	for _, c := range conns {
+		c.mu.Lock()
+		var frames []map[string]any
+		for sid, sub := range c.subs {
+			if sub.tickers[m.Ticker] {
+				frames = append(frames, c.frame(sid, sub, m))
+			}
+		}
+		c.mu.Unlock()
+		for _, f := range frames {
+			c.send(f)
+		}
+	}
+}
+
+// userLoop delivers fills and order updates to every user-channel
+// subscription. The mock has a single account, so every connection sees
+// every order.
+func (h *hub) userLoop() {
+	for um := range h.ex.userFeed {
+		h.mu.Lock()
+		conns := make([]*wsConn, 0, len(h.conns))
+		for c := range h.conns {
+			conns = append(conns, c)
+		}
+		h.mu.Unlock()
+
+		for _, c := range conns {
+			c.mu.Lock()
+			var frames []map[string]any
+			for sid, sub := range c.subs {
+				if sub.channel == userChannel[um.typ] {
+					frames = append(frames, map[string]any{"type": um.typ, "sid": sid, "msg": um.msg})
+				}
+			}
+			c.mu.Unlock()
+			for _, f := range frames {
+				c.send(f)
+			}
+		}
+	}
+}
+
+// frame builds the outbound message for one subscription. Advances the
+// orderbook sequence. Caller must hold c.mu.
+func (c *wsConn) frame(sid int, sub *subscription, m kalshi_http.Market) map[string]any {
+	if sub.channel == "orderbook_delta" {
+		sub.seq++
+		return orderbookFrame(sid, sub.seq, m)
+	}
+	return tickerFrame(sid, m)
+}
+
+func (c *wsConn) send(v any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	c.conn.WriteJSON(v)
+}
+
+func (c *wsConn) pingLoop(done <-chan struct{}) {
+	t := time.NewTicker(pingInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+			c.mu.Lock()
+			err := c.conn.WriteControl(websocket.PingMessage, []byte("heartbeat"), time.Now().Add(5*time.Second))
+			c.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func tickerFrame(sid int, m kalshi_http.Market) map[string]any {
+	return map[string]any{
+		"type": "ticker",
+		"sid":  sid,
+		"msg": map[string]any{
+			"market_ticker":   m.Ticker,
+			"yes_bid_dollars": m.YesBidDollars,
+			"yes_ask_dollars": m.YesAskDollars,
+			"volume":          m.Volume,
+			"ts":              time.Now().Unix(),
+		},
+	}
+}
+
+// orderbookFrame synthesizes a three-level book around the top of book.
+// YES bids sit at and below the yes bid, NO bids at and below 100 - yes ask.
+func orderbookFrame(sid int, seq int64, m kalshi_http.Market) map[string]any {
+	yesBid := m.EffectiveYesBid()
+	noBid := 100 - m.EffectiveYesAsk()
+
+	levels := func(top int) [][2]int {
+		var out [][2]int
+		for i, n := range []int{100, 250, 500} {
+			if p := top - i; p >= 1 {
+				out = append([][2]int{{p, n}}, out...)
+			}
+		}
+		return out
+	}
+
+	return map[string]any{
+		"type": "orderbook_snapshot",
+		"sid":  sid,
+		"seq":  seq,
+		"msg": map[string]any{
+			"market_ticker": m.Ticker,
+			"yes":           levels(yesBid),
+			"no":            levels(noBid),
+		},
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/charleschow/hft-trading/internal/config"
 	"github.com/charleschow/hft-trading/internal/events"
 	"github.com/charleschow/hft-trading/internal/fanout"
+	"github.com/charleschow/hft-trading/internal/killswitch"
 	"github.com/charleschow/hft-trading/internal/telemetry"
 )
 
@@ -67,6 +68,48 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// ── Kill switch ────────────────────────────────────────────
+	// A halt is relayed to every sport process over fanout, then every
+	// resting order on the account is canceled from here.
+	kill := killswitch.New()
+	kill.OnHalt(func(h events.HaltEvent) {
+		bus.Publish(events.Event{Type: events.EventHalt, Timestamp: h.At, Payload: h})
+		go killswitch.CancelAll(context.Background(), kalshiClient)
+	})
+	go kill.WatchSignal(ctx)
+	go kill.WatchFile(ctx, cfg.KillSwitchFile)
+
+	// Loss breakers live in the sport processes; a manual reset is relayed
+	// to the named sport over fanout.
+	admin := http.NewServeMux()
+	admin.Handle("/admin/", kill.Handler())
+	admin.HandleFunc("POST /admin/breaker/reset", func(w http.ResponseWriter, r *http.Request) {
+		reset := events.BreakerResetEvent{
+			Sport:  events.Sport(r.URL.Query().Get("sport")),
+			League: r.URL.Query().Get("league"),
+		}
+		if reset.Sport == "" {
+			http.Error(w, "sport is required", http.StatusBadRequest)
+			return
+		}
+		bus.Publish(events.Event{
+			Type: events.EventBreakerReset, Sport: reset.Sport, League: reset.League,
+			Timestamp: time.Now(), Payload: reset,
+		})
+		telemetry.Infof("[RISK-LIMIT] breaker reset sent to %s %s", reset.Sport, reset.League)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	if cfg.AdminToken != "" {
+		go func() {
+			if err := http.ListenAndServe(cfg.AdminAddr, killswitch.RequireToken(cfg.AdminToken, admin)); err != nil {
+				telemetry.Errorf("Admin server: %v", err)
+			}
+		}()
+		telemetry.Plainf("Admin listening on %q  (kill file %s)", cfg.AdminAddr, cfg.KillSwitchFile)
+	} else {
+		telemetry.Warnf("ADMIN_TOKEN not set — admin endpoints disabled (kill file %s and SIGUSR1 still work)", cfg.KillSwitchFile)
+	}
+
 	var server *http.Server
 	var ngrokProc *os.Process
 	var webhookStore *goalserve_webhook.Store
@@ -5,6 +5,7 @@ import (
 
 	"github.com/charleschow/hft-trading/internal/adapters/outbound/goalserve_http"
 	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/calibration"
 	"github.com/charleschow/hft-trading/internal/core/odds"
 	"github.com/charleschow/hft-trading/internal/core/state/game"
 	"github.com/charleschow/hft-trading/internal/core/strategy"
@@ -12,6 +13,7 @@ import (
 	"github.com/charleschow/hft-trading/internal/core/training"
 	"github.com/charleschow/hft-trading/internal/events"
 	"github.com/charleschow/hft-trading/internal/process"
+	"github.com/charleschow/hft-trading/internal/telemetry"
 )
 
 func main() {
@@ -19,6 +21,15 @@ func main() {
 		Sport:    events.SportSoccer,
 		SportKey: "soccer",
 		BuildStrategy: func(cfg *config.Config) strategy.Strategy {
+			if mp, err := config.LoadModelParams(cfg.ModelParamsPath); err != nil {
+				telemetry.Warnf("soccer model params: %v (using the uncalibrated model)", err)
+			} else {
+				soccerStrat.SetModelParams(soccerStrat.ModelParams{
+					WinRecal:  calibration.TableFrom(mp.Soccer.WinRecal),
+					DrawRecal: calibration.TableFrom(mp.Soccer.DrawRecal),
+				})
+				telemetry.Infof("soccer model params version %d loaded", mp.Version)
+			}
 			return soccerStrat.NewStrategy()
 		},
 		BuildPregameProvider: func(cfg *config.Config) strategy.PregameProvider {
@@ -108,6 +108,9 @@ func (p *Parser) Parse(payload *WebhookPayload) []events.Event {
 		}
 		gu.MatchStatus = p.inferMatchStatus(&ev, homeScore, awayScore, period)
 
+		if p.sport == events.SportSoccer {
+			gu.ExtraTimeLeft = soccerExtraTimeLeft(strings.ToLower(period), ev.Info.Minute)
+		}
 		if p.sport == events.SportHockey {
 			gu.PowerPlay, gu.HomePenaltyCount, gu.AwayPenaltyCount = p.extractPowerPlay(&ev)
 		}
@@ -168,7 +171,7 @@ func (p *Parser) calcTimeRemaining(period, minute, seconds string) float64 {
 	case events.SportHockey:
 		return hockeyTimeRemaining(low, seconds)
 	case events.SportFootball:
-		return footballTimeRemaining(low)
+		return footballTimeRemaining(low, seconds)
 	}
 	return 0
 }
@@ -209,6 +212,20 @@ func soccerTimeRemaining(period, minute string) float64 {
 	return 90
 }
 
+// soccerExtraTimeLeft returns the minutes of extra time left while it is
+// played, from the elapsed-minutes field ("105", "120+1"), and 0 outside
+// extra time. With no minute it assumes extra time has just begun.
+func soccerExtraTimeLeft(period, minute string) float64 {
+	if !strings.Contains(period, "extra") && period != "et" {
+		return 0
+	}
+	m := strings.TrimSpace(minute)
+	if m == "" {
+		return 30
+	}
+	return max(0, min(30, 120-parseTimer(m)))
+}
+
 // hockeyTimeRemaining parses the period string and the Seconds field
 // (MM:SS countdown clock within the current period) to compute total minutes remaining.
 func hockeyTimeRemaining(period, seconds string) float64 {
@@ -229,7 +246,9 @@ func hockeyTimeRemaining(period, seconds string) float64 {
 	return 60
 }
 
-func footballTimeRemaining(period string) float64 {
+// footballTimeRemaining returns regulation minutes remaining by quarter,
+// and in overtime the OT clock from the Seconds field.
+func footballTimeRemaining(period, seconds string) float64 {
 	switch {
 	case strings.Contains(period, "q1") || strings.Contains(period, "1st quarter"):
 		return 45
@@ -242,7 +261,7 @@ func footballTimeRemaining(period string) float64 {
 	case strings.Contains(period, "q4") || strings.Contains(period, "4th quarter"):
 		return 0
 	case strings.Contains(period, "overtime") || period == "ot":
-		return 0
+		return parsePeriodClock(seconds, 10)
 	default:
 		return 60
 	}
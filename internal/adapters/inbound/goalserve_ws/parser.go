@@ -50,6 +50,7 @@ func ParseUpdt(msg *UpdtMessage) *events.Event {
 
 	if sport == events.SportSoccer {
 		gu.HomeRedCards, gu.AwayRedCards = extractRedCards(msg)
+		gu.ExtraTimeLeft = soccerExtraTimeLeft(msg)
 	}
 	if sport == events.SportHockey {
 		gu.PowerPlay, gu.HomePenaltyCount, gu.AwayPenaltyCount = extractPowerPlay(msg)
@@ -207,11 +208,22 @@ func mapPeriod(sport events.Sport, pc int) string {
 	return fmt.Sprintf("Period %d", pc)
 }
 
+// soccerExtraTimeLeft returns the minutes of extra time left while it is
+// played (pc 4 and 5), from ET's seconds since kickoff, and 0 otherwise.
+func soccerExtraTimeLeft(msg *UpdtMessage) float64 {
+	if msg.PC != 4 && msg.PC != 5 {
+		return 0
+	}
+	remain := 120.0 - float64(msg.ET)/60.0
+	return max(0, min(30, remain))
+}
+
 // calcTimeRemaining computes minutes remaining from period code and time fields.
 // Soccer: ET is total elapsed seconds since kickoff.
 // Hockey: CMS tm is cumulative elapsed seconds from game start — remaining is
 // simply (totalGameSec - tm). Falls back to ET (per-period countdown).
-// Football: ET is total elapsed seconds.
+// Football: ET is total elapsed seconds; in overtime the OT clock is
+// returned.
 func calcTimeRemaining(sport events.Sport, msg *UpdtMessage) float64 {
 	pc, et := msg.PC, msg.ET
 	switch sport {
@@ -234,10 +246,8 @@ func calcTimeRemaining(sport events.Sport, msg *UpdtMessage) float64 {
 				remain = 0
 			}
 			return remain
-		case 4, 5:
-			return 90.0 - elapsed
 		default:
-			return 0
+			return 0 // extra time runs on its own clock: soccerExtraTimeLeft
 		}
 	case events.SportHockey:
 		if pc == 255 {
@@ -255,6 +265,11 @@ func calcTimeRemaining(sport events.Sport, msg *UpdtMessage) float64 {
 				remain = 0
 			}
 			return remain
+		case 3:
+			return 30.0 // halftime
+		case 6:
+			// Overtime: minutes left on the 10-minute OT clock.
+			return max(0, min(10, 10+remain))
 		default:
 			return 0
 		}
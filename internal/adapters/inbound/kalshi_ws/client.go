@@ -2,6 +2,7 @@ package kalshi_ws
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
 	"sync"
 	"time"
@@ -14,7 +15,9 @@ import (
 )
 
 // Client connects to the Kalshi WebSocket feed and publishes
-// MarketEvent updates onto the event bus.
+// MarketEvent updates onto the event bus. When the signer holds a key it
+// also subscribes the authenticated fill and user_orders channels, which
+// carry our own executions as FillEvent / OrderUpdateEvent.
 //
 // Gorilla/websocket supports one concurrent reader and one concurrent
 // writer, so all writes are serialized through mu.
@@ -28,15 +31,26 @@ type Client struct {
 	mu      sync.Mutex
 	tickers map[string]bool
 	subID   int
+
+	// Orderbook sequencing. pending maps a subscribe command id to its
+	// tickers until Kalshi confirms the orderbook_delta sid; bookSubs then
+	// maps that sid to the same tickers so a gap can be re-snapshotted.
+	// seqs is only touched by the read goroutine.
+	pending  map[int][]string
+	bookSubs map[int64][]string
+	seqs     map[int64]int64
 }
 
 func NewClient(wsURL string, signer *kalshi_auth.Signer, bus *events.Bus) *Client {
 	return &Client{
-		url:     wsURL,
-		signer:  signer,
-		bus:     bus,
-		done:    make(chan struct{}),
-		tickers: make(map[string]bool),
+		url:      wsURL,
+		signer:   signer,
+		bus:      bus,
+		done:     make(chan struct{}),
+		tickers:  make(map[string]bool),
+		pending:  make(map[int][]string),
+		bookSubs: make(map[int64][]string),
+		seqs:     make(map[int64]int64),
 	}
 }
 
@@ -63,7 +77,10 @@ func (c *Client) dial(ctx context.Context) error {
 
 	c.mu.Lock()
 	c.conn = conn
+	c.pending = make(map[int][]string)
+	c.bookSubs = make(map[int64][]string)
 	c.mu.Unlock()
+	c.seqs = make(map[int64]int64)
 	return nil
 }
 
@@ -135,12 +152,18 @@ func (c *Client) runLoop(ctx context.Context) {
 	}
 }
 
-// resubscribeAll sends a subscribe for every known ticker.
-// Called after each successful connection/reconnection.
+// userChannels are account-scoped: they take no market tickers and need
+// an authenticated connection.
+var userChannels = []string{"fill", "user_orders"}
+
+// resubscribeAll sends a subscribe for every known ticker and for the
+// user channels. Called after each successful connection/reconnection.
 func (c *Client) resubscribeAll() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.subscribeUserChannels()
+
 	if len(c.tickers) == 0 {
 		return
 	}
@@ -155,22 +178,117 @@ func (c *Client) resubscribeAll() {
 	}
 }
 
-// sendSubscribe writes a subscribe command. Caller must hold mu.
+// subscribeUserChannels subscribes each user channel with its own command,
+// so a server that rejects one still delivers the other. Caller must hold mu.
+func (c *Client) subscribeUserChannels() {
+	if !c.signer.Enabled() {
+		return
+	}
+	for _, ch := range userChannels {
+		c.subID++
+		cmd := subscribeCmd{
+			ID:     c.subID,
+			Cmd:    "subscribe",
+			Params: subscribeParams{Channels: []string{ch}},
+		}
+		if err := c.conn.WriteJSON(cmd); err != nil {
+			telemetry.Warnf("Kalshi WS %s subscribe failed: %v", ch, err)
+			return
+		}
+	}
+	telemetry.Debugf("kalshi_ws: subscribed user channels %v", userChannels)
+}
+
+// sendSubscribe writes a subscribe command for the ticker and orderbook
+// channels. Caller must hold mu.
 func (c *Client) sendSubscribe(tickers []string) error {
+	return c.sendSubscribeChannels([]string{"ticker", "orderbook_delta"}, tickers)
+}
+
+// sendSubscribeChannels writes a subscribe command. Caller must hold mu.
+func (c *Client) sendSubscribeChannels(channels, tickers []string) error {
 	c.subID++
+	c.pending[c.subID] = tickers
 	cmd := subscribeCmd{
 		ID:  c.subID,
 		Cmd: "subscribe",
 		Params: subscribeParams{
-			Channels:            []string{"ticker"},
+			Channels:            channels,
 			MarketTickers:       tickers,
 			SendInitialSnapshot: true,
 		},
 	}
-	telemetry.Debugf("kalshi_ws: subscribing to %d tickers (sid=%d)", len(tickers), c.subID)
+	telemetry.Debugf("kalshi_ws: subscribing %v to %d tickers (id=%d)", channels, len(tickers), c.subID)
 	return c.conn.WriteJSON(cmd)
 }
 
+// trackSequence records orderbook subscription ids and sequence numbers.
+// Returns false when msg is an orderbook_delta that follows a gap — the
+// caller must drop it. The affected books are invalidated and a fresh
+// snapshot is requested.
+func (c *Client) trackSequence(msg wsMessage) bool {
+	switch msg.Type {
+	case "subscribed":
+		var sub struct {
+			Channel string `json:"channel"`
+			SID     int64  `json:"sid"`
+		}
+		if json.Unmarshal(msg.Msg, &sub) != nil || sub.Channel != "orderbook_delta" {
+			return true
+		}
+		c.mu.Lock()
+		c.bookSubs[sub.SID] = c.pending[msg.ID]
+		delete(c.pending, msg.ID)
+		c.mu.Unlock()
+
+	case "orderbook_snapshot":
+		c.seqs[msg.SID] = msg.Seq
+
+	case "orderbook_delta":
+		last, ok := c.seqs[msg.SID]
+		if ok && msg.Seq != last+1 {
+			telemetry.Warnf("kalshi_ws: orderbook seq gap on sid=%d (expected %d, got %d) — re-snapshotting",
+				msg.SID, last+1, msg.Seq)
+			telemetry.Metrics.OrderbookSeqGaps.Inc()
+			c.resnapshot(msg.SID)
+			return false
+		}
+		c.seqs[msg.SID] = msg.Seq
+	}
+	return true
+}
+
+// resnapshot drops an orderbook subscription whose sequence broke and
+// re-subscribes its tickers, which makes Kalshi send a new snapshot.
+// Books stay invalid until that snapshot arrives.
+func (c *Client) resnapshot(sid int64) {
+	delete(c.seqs, sid)
+
+	c.mu.Lock()
+	tickers := c.bookSubs[sid]
+	delete(c.bookSubs, sid)
+	var err error
+	if c.conn != nil {
+		c.subID++
+		err = c.conn.WriteJSON(subscribeCmd{
+			ID:     c.subID,
+			Cmd:    "unsubscribe",
+			Params: subscribeParams{SIDs: []int64{sid}},
+		})
+		if err == nil && len(tickers) > 0 {
+			err = c.sendSubscribeChannels([]string{"orderbook_delta"}, tickers)
+		}
+	}
+	c.mu.Unlock()
+
+	if err != nil {
+		telemetry.Warnf("kalshi_ws: re-snapshot for sid=%d failed: %v", sid, err)
+	}
+	for _, t := range tickers {
+		c.bus.Publish(orderBookEvent(events.OrderBookEvent{Ticker: t, Invalidate: true}))
+	}
+}
+
 type subscribeCmd struct {
 	ID     int             `json:"id"`
 	Cmd    string          `json:"cmd"`
@@ -178,9 +296,10 @@ type subscribeCmd struct {
 }
 
 type subscribeParams struct {
-	Channels            []string `json:"channels"`
+	Channels            []string `json:"channels,omitempty"`
 	MarketTickers       []string `json:"market_tickers,omitempty"`
 	SendInitialSnapshot bool     `json:"send_initial_snapshot,omitempty"`
+	SIDs                []int64  `json:"sids,omitempty"`
 }
 
 func (c *Client) readLoop(ctx context.Context) {
@@ -213,7 +332,17 @@ func (c *Client) readLoop(ctx context.Context) {
 		}
 
 		conn.SetReadDeadline(time.Now().Add(pingWait))
-		for _, evt := range ParseMessage(msg) {
+
+		// Decode the envelope once; sequencing and parsing share it.
+		var frame wsMessage
+		if err := json.Unmarshal(msg, &frame); err != nil {
+			telemetry.Warnf("kalshi_ws: parse error: %v", err)
+			continue
+		}
+		if !c.trackSequence(frame) {
+			continue
+		}
+		for _, evt := range parseMessage(frame) {
 			c.bus.Publish(evt)
 		}
 	}
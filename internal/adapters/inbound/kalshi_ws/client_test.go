@@ -0,0 +1,148 @@
+package kalshi_ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+// commandServer is a websocket server that records the commands a Client
+// writes to it.
+func commandServer(t *testing.T) (*websocket.Conn, <-chan subscribeCmd) {
+	t.Helper()
+	cmds := make(chan subscribeCmd, 16)
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var cmd subscribeCmd
+			if conn.ReadJSON(&cmd) != nil {
+				return
+			}
+			cmds <- cmd
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, cmds
+}
+
+func TestTrackSequence(t *testing.T) {
+	subscribed := func(channel string) wsMessage {
+		return wsMessage{ID: 1, Type: "subscribed", Msg: []byte(`{"channel":"` + channel + `","sid":7}`)}
+	}
+	snapshot := func(seq int64) wsMessage { return wsMessage{Type: "orderbook_snapshot", SID: 7, Seq: seq} }
+	delta := func(seq int64) wsMessage { return wsMessage{Type: "orderbook_delta", SID: 7, Seq: seq} }
+
+	tests := []struct {
+		name        string
+		frames      []wsMessage
+		want        []bool
+		invalidated []string
+		resubscribe []string // tickers of the orderbook re-subscribe, nil for none
+	}{
+		{
+			name:   "in order",
+			frames: []wsMessage{subscribed("orderbook_delta"), snapshot(1), delta(2), delta(3)},
+			want:   []bool{true, true, true, true},
+		},
+		{
+			name:        "gap drops the delta and re-snapshots",
+			frames:      []wsMessage{subscribed("orderbook_delta"), snapshot(1), delta(2), delta(4)},
+			want:        []bool{true, true, true, false},
+			invalidated: []string{"A", "B"},
+			resubscribe: []string{"A", "B"},
+		},
+		{
+			name:        "repeated delta is a gap",
+			frames:      []wsMessage{subscribed("orderbook_delta"), snapshot(1), delta(2), delta(2)},
+			want:        []bool{true, true, true, false},
+			invalidated: []string{"A", "B"},
+			resubscribe: []string{"A", "B"},
+		},
+		{
+			name:   "snapshot restarts the sequence",
+			frames: []wsMessage{subscribed("orderbook_delta"), snapshot(1), delta(2), snapshot(10), delta(11)},
+			want:   []bool{true, true, true, true, true},
+		},
+		{
+			name:   "delta before any snapshot passes",
+			frames: []wsMessage{subscribed("orderbook_delta"), delta(5), delta(6)},
+			want:   []bool{true, true, true},
+		},
+		{
+			name:   "ticker channel subscription is not a book",
+			frames: []wsMessage{subscribed("ticker"), snapshot(1), delta(3)},
+			want:   []bool{true, true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, cmds := commandServer(t)
+			bus := events.NewBus()
+			var invalidated []string
+			bus.Subscribe(events.EventOrderBook, func(e events.Event) error {
+				if ob := e.Payload.(events.OrderBookEvent); ob.Invalidate {
+					invalidated = append(invalidated, ob.Ticker)
+				}
+				return nil
+			})
+			c := NewClient("", nil, bus)
+			c.conn = conn
+			c.subID = 1
+			c.pending[1] = []string{"A", "B"}
+
+			var got []bool
+			for _, f := range tt.frames {
+				got = append(got, c.trackSequence(f))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("trackSequence = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(invalidated, tt.invalidated) {
+				t.Errorf("invalidated %v, want %v", invalidated, tt.invalidated)
+			}
+
+			gap := slices.Contains(tt.want, false)
+			if !gap {
+				select {
+				case cmd := <-cmds:
+					t.Errorf("unexpected command %+v", cmd)
+				case <-time.After(50 * time.Millisecond):
+				}
+				return
+			}
+			unsub := <-cmds
+			if unsub.Cmd != "unsubscribe" || !slices.Equal(unsub.Params.SIDs, []int64{7}) {
+				t.Errorf("first command %+v, want unsubscribe of sid 7", unsub)
+			}
+			if tt.resubscribe == nil {
+				return
+			}
+			sub := <-cmds
+			if sub.Cmd != "subscribe" || !slices.Equal(sub.Params.Channels, []string{"orderbook_delta"}) ||
+				!slices.Equal(sub.Params.MarketTickers, tt.resubscribe) || !sub.Params.SendInitialSnapshot {
+				t.Errorf("second command %+v, want an orderbook subscribe with a snapshot for %v", sub, tt.resubscribe)
+			}
+			if _, ok := c.pending[sub.ID]; !ok {
+				t.Errorf("re-subscribe id %d not pending", sub.ID)
+			}
+		})
+	}
+}
@@ -11,9 +11,11 @@ import (
 
 // wsMessage represents a raw message from the Kalshi WebSocket.
 type wsMessage struct {
+	ID   int             `json:"id"`
 	Type string          `json:"type"`
 	Msg  json.RawMessage `json:"msg"`
 	SID  int64           `json:"sid"`
+	Seq  int64           `json:"seq"`
 }
 
 type tickerMsg struct {
@@ -23,17 +25,72 @@ type tickerMsg struct {
 	Volume        int64  `json:"volume"`
 }
 
-// ParseMessage converts a raw WebSocket frame into domain events.
-func ParseMessage(data []byte) []events.Event {
-	var msg wsMessage
-	if err := json.Unmarshal(data, &msg); err != nil {
-		telemetry.Warnf("kalshi_ws: parse error: %v", err)
-		return nil
-	}
+// orderbookSnapshotMsg carries both the legacy integer levels and the
+// fixed-point dollar levels; the fixed-point form wins when present.
+type orderbookSnapshotMsg struct {
+	MarketTicker string     `json:"market_ticker"`
+	Yes          [][2]int   `json:"yes"`
+	No           [][2]int   `json:"no"`
+	YesDollarsFP [][]string `json:"yes_dollars_fp"`
+	NoDollarsFP  [][]string `json:"no_dollars_fp"`
+}
+
+type orderbookDeltaMsg struct {
+	MarketTicker string `json:"market_ticker"`
+	Price        int    `json:"price"`
+	PriceDollars string `json:"price_dollars"`
+	Delta        int    `json:"delta"`
+	DeltaFP      string `json:"delta_fp"`
+	Side         string `json:"side"`
+}
+
+// fillMsg is one trade on one of our orders (authenticated fill channel).
+type fillMsg struct {
+	TradeID         string `json:"trade_id"`
+	OrderID         string `json:"order_id"`
+	ClientOrderID   string `json:"client_order_id"`
+	MarketTicker    string `json:"market_ticker"`
+	IsTaker         bool   `json:"is_taker"`
+	Side            string `json:"side"`
+	YesPrice        int    `json:"yes_price"`
+	YesPriceDollars string `json:"yes_price_dollars"`
+	Count           int    `json:"count"`
+	CountFP         string `json:"count_fp"`
+	Action          string `json:"action"`
+	Ts              int64  `json:"ts"`
+}
+
+// userOrderMsg is an order state change (authenticated user_orders channel).
+// Counts and costs are cumulative; the fixed-point forms win when present.
+type userOrderMsg struct {
+	OrderID              string `json:"order_id"`
+	ClientOrderID        string `json:"client_order_id"`
+	Ticker               string `json:"ticker"`
+	Side                 string `json:"side"`
+	Status               string `json:"status"`
+	FillCount            int    `json:"fill_count"`
+	FillCountFP          string `json:"fill_count_fp"`
+	RemainingCount       int    `json:"remaining_count"`
+	RemainingCountFP     string `json:"remaining_count_fp"`
+	TakerFillCostDollars string `json:"taker_fill_cost_dollars"`
+	MakerFillCostDollars string `json:"maker_fill_cost_dollars"`
+	TakerFeesDollars     string `json:"taker_fees_dollars"`
+	MakerFeesDollars     string `json:"maker_fees_dollars"`
+}
 
+// parseMessage converts a decoded WebSocket frame into domain events.
+func parseMessage(msg wsMessage) []events.Event {
 	switch msg.Type {
 	case "ticker":
 		return parseTickerUpdate(msg.Msg)
+	case "orderbook_snapshot":
+		return parseOrderbookSnapshot(msg.Msg)
+	case "orderbook_delta":
+		return parseOrderbookDelta(msg.Msg)
+	case "fill":
+		return parseFill(msg.Msg)
+	case "user_order":
+		return parseUserOrder(msg.Msg)
 	case "subscribed", "unsubscribed", "ok", "error":
 		if msg.Type == "error" {
 			telemetry.Warnf("kalshi_ws: server error: %s", string(msg.Msg))
@@ -68,6 +125,170 @@ func parseTickerUpdate(raw json.RawMessage) []events.Event {
 	}}
 }
 
+func parseOrderbookSnapshot(raw json.RawMessage) []events.Event {
+	var m orderbookSnapshotMsg
+	if err := json.Unmarshal(raw, &m); err != nil || m.MarketTicker == "" {
+		return nil
+	}
+
+	ob := events.OrderBookEvent{
+		Ticker:   m.MarketTicker,
+		Snapshot: true,
+		YesBids:  bookLevels(m.Yes, m.YesDollarsFP),
+		NoBids:   bookLevels(m.No, m.NoDollarsFP),
+	}
+	return []events.Event{orderBookEvent(ob)}
+}
+
+func parseOrderbookDelta(raw json.RawMessage) []events.Event {
+	var m orderbookDeltaMsg
+	if err := json.Unmarshal(raw, &m); err != nil || m.MarketTicker == "" {
+		return nil
+	}
+
+	price := m.Price
+	if m.PriceDollars != "" {
+		if c := dollarsToCents(m.PriceDollars); c >= 0 {
+			price = int(c + 0.5)
+		}
+	}
+	delta := m.Delta
+	if m.DeltaFP != "" {
+		if v, err := strconv.ParseFloat(m.DeltaFP, 64); err == nil {
+			delta = int(v)
+		}
+	}
+
+	ob := events.OrderBookEvent{
+		Ticker: m.MarketTicker,
+		Side:   m.Side,
+		Price:  price,
+		Delta:  delta,
+	}
+	return []events.Event{orderBookEvent(ob)}
+}
+
+func parseFill(raw json.RawMessage) []events.Event {
+	var m fillMsg
+	if err := json.Unmarshal(raw, &m); err != nil || m.OrderID == "" {
+		return nil
+	}
+
+	yesPrice := m.YesPrice
+	if c := dollarsToCents(m.YesPriceDollars); c >= 0 {
+		yesPrice = int(c + 0.5)
+	}
+	price := yesPrice
+	if m.Side == "no" {
+		price = 100 - yesPrice
+	}
+
+	ts := time.Now()
+	if m.Ts > 0 {
+		ts = time.Unix(m.Ts, 0)
+	}
+
+	fe := events.FillEvent{
+		TradeID:       m.TradeID,
+		OrderID:       m.OrderID,
+		ClientOrderID: m.ClientOrderID,
+		Ticker:        m.MarketTicker,
+		Side:          m.Side,
+		Action:        m.Action,
+		PriceCents:    price,
+		Count:         fpCount(m.CountFP, m.Count),
+		IsTaker:       m.IsTaker,
+		Ts:            ts,
+	}
+	return []events.Event{{
+		ID:        m.MarketTicker,
+		Type:      events.EventFill,
+		Timestamp: time.Now(),
+		Payload:   fe,
+	}}
+}
+
+func parseUserOrder(raw json.RawMessage) []events.Event {
+	var m userOrderMsg
+	if err := json.Unmarshal(raw, &m); err != nil || m.OrderID == "" {
+		return nil
+	}
+
+	ou := events.OrderUpdateEvent{
+		OrderID:        m.OrderID,
+		ClientOrderID:  m.ClientOrderID,
+		Ticker:         m.Ticker,
+		Side:           m.Side,
+		Status:         m.Status,
+		FillCount:      fpCount(m.FillCountFP, m.FillCount),
+		RemainingCount: fpCount(m.RemainingCountFP, m.RemainingCount),
+		FillCostCents:  dollarsToWholeCents(m.TakerFillCostDollars) + dollarsToWholeCents(m.MakerFillCostDollars),
+		FeesCents:      dollarsToWholeCents(m.TakerFeesDollars) + dollarsToWholeCents(m.MakerFeesDollars),
+	}
+	return []events.Event{{
+		ID:        m.Ticker,
+		Type:      events.EventOrderUpdate,
+		Timestamp: time.Now(),
+		Payload:   ou,
+	}}
+}
+
+// fpCount prefers a fixed-point contract count over the legacy integer.
+func fpCount(fp string, legacy int) int {
+	if fp == "" {
+		return legacy
+	}
+	v, err := strconv.ParseFloat(fp, 64)
+	if err != nil {
+		return legacy
+	}
+	return int(v)
+}
+
+// dollarsToWholeCents rounds a dollar amount to cents, treating an absent
+// field as zero.
+func dollarsToWholeCents(s string) int {
+	c := dollarsToCents(s)
+	if c < 0 {
+		return 0
+	}
+	return int(c + 0.5)
+}
+
+func orderBookEvent(ob events.OrderBookEvent) events.Event {
+	return events.Event{
+		ID:        ob.Ticker,
+		Type:      events.EventOrderBook,
+		Timestamp: time.Now(),
+		Payload:   ob,
+	}
+}
+
+// bookLevels converts snapshot price levels to cents/contracts, preferring
+// the fixed-point dollar form.
+func bookLevels(cents [][2]int, fp [][]string) []events.BookLevel {
+	if len(fp) > 0 {
+		out := make([]events.BookLevel, 0, len(fp))
+		for _, l := range fp {
+			if len(l) != 2 {
+				continue
+			}
+			price := dollarsToCents(l[0])
+			count, err := strconv.ParseFloat(l[1], 64)
+			if price < 0 || err != nil {
+				continue
+			}
+			out = append(out, events.BookLevel{Price: int(price + 0.5), Count: int(count)})
+		}
+		return out
+	}
+	out := make([]events.BookLevel, 0, len(cents))
+	for _, l := range cents {
+		out = append(out, events.BookLevel{Price: l[0], Count: l[1]})
+	}
+	return out
+}
+
 // dollarsToCents converts a dollar-string from the Kalshi WS to cents.
 // Returns -1 when the field is absent or unparseable (partial WS update),
 // so callers can distinguish "not sent" from "genuinely $0.00".
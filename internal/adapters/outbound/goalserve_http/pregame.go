@@ -17,11 +17,12 @@ import (
 )
 
 const (
-	goalserveBase    = "http://www.goalserve.com/getfeed"
-	soccerPregameCat = "soccer_10"
-	hockeyPregameCat = "hockey_10"
-	requestTimeout   = 45 * time.Second
-	rateLimitSec     = 10
+	goalserveBase      = "http://www.goalserve.com/getfeed"
+	soccerPregameCat   = "soccer_10"
+	hockeyPregameCat   = "hockey_10"
+	footballPregameCat = "football_10"
+	requestTimeout     = 45 * time.Second
+	rateLimitSec       = 10
 )
 
 var preferredBookmakers = []string{
@@ -101,7 +102,7 @@ func (c *PregameClient) FetchHockeyPregame() ([]odds.PregameOdds, error) {
 		return nil, nil
 	}
 
-	matches, err := parseHockeyPregameXML(body)
+	matches, err := parseMoneylinePregameXML(body)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +111,41 @@ func (c *PregameClient) FetchHockeyPregame() ([]odds.PregameOdds, error) {
 	return matches, nil
 }
 
+// FetchFootballPregame fetches American football (NFL, NCAAF) pregame odds
+// from GoalServe. The amfootball odds feed is XML in the same shape as
+// hockey's, so the 2-way moneyline is extracted the same way.
+func (c *PregameClient) FetchFootballPregame() ([]odds.PregameOdds, error) {
+	c.rateLimit()
+
+	url := fmt.Sprintf("%s/%s/getodds/football?cat=%s", goalserveBase, c.apiKey, footballPregameCat)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("goalserve football pregame fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("goalserve football pregame: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("goalserve football pregame read: %w", err)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	matches, err := parseMoneylinePregameXML(body)
+	if err != nil {
+		return nil, err
+	}
+
+	telemetry.Infof("[GoalServe]: fetched %d football pregame matches", len(matches))
+	return matches, nil
+}
+
 func (c *PregameClient) rateLimit() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -187,7 +223,7 @@ func parseSoccerPregameJSON(data []byte) ([]odds.PregameOdds, error) {
 	return out, nil
 }
 
-// --- Hockey XML parsing (GoalServe hockey endpoint returns XML) ---
+// --- Moneyline XML parsing (GoalServe hockey and football endpoints return XML) ---
 
 type xmlScores struct {
 	XMLName    xml.Name      `xml:"scores"`
@@ -232,12 +268,12 @@ type xmlOdd struct {
 	Value string `xml:"value,attr"`
 }
 
-func parseHockeyPregameXML(data []byte) ([]odds.PregameOdds, error) {
+func parseMoneylinePregameXML(data []byte) ([]odds.PregameOdds, error) {
 	data = stripBOM(data)
 
 	var scores xmlScores
 	if err := xml.Unmarshal(data, &scores); err != nil {
-		return nil, fmt.Errorf("goalserve hockey pregame XML parse: %w", err)
+		return nil, fmt.Errorf("goalserve pregame XML parse: %w", err)
 	}
 
 	var out []odds.PregameOdds
@@ -0,0 +1,122 @@
+package kalshi_http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Order groups cap how many contracts the orders in a group can match over
+// a rolling 15-second window. When the cap is hit Kalshi cancels every
+// order in the group and rejects new ones until the group is reset.
+
+// OrderGroup is one entry of GET /portfolio/order_groups.
+type OrderGroup struct {
+	ID                  string `json:"id"`
+	ContractsLimit      int    `json:"contracts_limit"`
+	IsAutoCancelEnabled bool   `json:"is_auto_cancel_enabled"`
+}
+
+// OrderGroupDetail is the response of GET /portfolio/order_groups/{id}.
+type OrderGroupDetail struct {
+	ContractsLimit      int      `json:"contracts_limit"`
+	IsAutoCancelEnabled bool     `json:"is_auto_cancel_enabled"`
+	Orders              []string `json:"orders"`
+}
+
+type orderGroupLimit struct {
+	ContractsLimit int `json:"contracts_limit"`
+}
+
+// CreateOrderGroup creates a group capped at contractsLimit and returns
+// its ID, to be set as CreateOrderRequest.OrderGroupID.
+func (c *Client) CreateOrderGroup(ctx context.Context, contractsLimit int) (string, error) {
+	body, status, err := c.Post(ctx, "/trade-api/v2/portfolio/order_groups/create", orderGroupLimit{ContractsLimit: contractsLimit})
+	if err != nil {
+		return "", err
+	}
+	if status < 200 || status >= 300 {
+		return "", fmt.Errorf("create order group: status=%d body=%s", status, string(body))
+	}
+	var resp struct {
+		OrderGroupID string `json:"order_group_id"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("unmarshal order group: %w", err)
+	}
+	return resp.OrderGroupID, nil
+}
+
+func (c *Client) GetOrderGroups(ctx context.Context) ([]OrderGroup, error) {
+	body, status, err := c.Get(ctx, "/trade-api/v2/portfolio/order_groups")
+	if err != nil {
+		return nil, err
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("get order groups: status=%d body=%s", status, string(body))
+	}
+	var resp struct {
+		OrderGroups []OrderGroup `json:"order_groups"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal order groups: %w", err)
+	}
+	return resp.OrderGroups, nil
+}
+
+func (c *Client) GetOrderGroup(ctx context.Context, groupID string) (*OrderGroupDetail, error) {
+	body, status, err := c.Get(ctx, "/trade-api/v2/portfolio/order_groups/"+groupID)
+	if err != nil {
+		return nil, err
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("get order group: status=%d body=%s", status, string(body))
+	}
+	var resp OrderGroupDetail
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal order group: %w", err)
+	}
+	return &resp, nil
+}
+
+// DeleteOrderGroup removes the group and cancels every order in it.
+func (c *Client) DeleteOrderGroup(ctx context.Context, groupID string) error {
+	_, status, err := c.Delete(ctx, "/trade-api/v2/portfolio/order_groups/"+groupID)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("delete order group: status=%d", status)
+	}
+	return nil
+}
+
+// ResetOrderGroup zeroes the group's matched-contracts counter so it
+// accepts orders again after hitting its limit.
+func (c *Client) ResetOrderGroup(ctx context.Context, groupID string) error {
+	return c.putOrderGroup(ctx, groupID, "reset", struct{}{})
+}
+
+// TriggerOrderGroup cancels every order in the group and blocks new ones
+// until it is reset.
+func (c *Client) TriggerOrderGroup(ctx context.Context, groupID string) error {
+	return c.putOrderGroup(ctx, groupID, "trigger", struct{}{})
+}
+
+// UpdateOrderGroupLimit changes the group's contracts limit. A limit the
+// group has already exceeded triggers it immediately.
+func (c *Client) UpdateOrderGroupLimit(ctx context.Context, groupID string, contractsLimit int) error {
+	return c.putOrderGroup(ctx, groupID, "limit", orderGroupLimit{ContractsLimit: contractsLimit})
+}
+
+func (c *Client) putOrderGroup(ctx context.Context, groupID, op string, body any) error {
+	path := fmt.Sprintf("/trade-api/v2/portfolio/order_groups/%s/%s", groupID, op)
+	resp, status, err := c.Put(ctx, path, body)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("%s order group: status=%d body=%s", op, status, string(resp))
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
 	"strconv"
 
 	"github.com/charleschow/hft-trading/internal/telemetry"
@@ -22,6 +23,7 @@ type CreateOrderRequest struct {
 	ClientID        string `json:"client_order_id,omitempty"`
 	TimeInForce     string `json:"time_in_force,omitempty"`     // "good_till_canceled", "immediate_or_cancel", "fill_or_kill"
 	ExpirationTS    int64  `json:"expiration_ts,omitempty"`
+	OrderGroupID    string `json:"order_group_id,omitempty"`
 }
 
 type CreateOrderResponse struct {
@@ -73,6 +75,8 @@ type BatchCreateOrdersIndividualResponse struct {
 
 type OrderDetail struct {
 	OrderID       string `json:"order_id"`
+	ClientOrderID string `json:"client_order_id,omitempty"`
+	Ticker        string `json:"ticker,omitempty"`
 	Status        string `json:"status"`
 	Side          string `json:"side"`
 	YesPrice      int    `json:"yes_price"`
@@ -148,23 +152,124 @@ func (c *Client) CancelOrder(ctx context.Context, orderID string) error {
 	return nil
 }
 
+// batchCancelMax is the most order IDs Kalshi accepts per batch cancel.
+const batchCancelMax = 20
+
+// BatchCancelOrders cancels orderIDs via DELETE /portfolio/orders/batched,
+// in chunks of batchCancelMax. Returns how many orders had contracts
+// canceled; per-order errors are logged and skipped.
+func (c *Client) BatchCancelOrders(ctx context.Context, orderIDs []string) (int, error) {
+	canceled := 0
+	for start := 0; start < len(orderIDs); start += batchCancelMax {
+		ids := orderIDs[start:min(start+batchCancelMax, len(orderIDs))]
+		body, status, err := c.do(ctx, http.MethodDelete, "/trade-api/v2/portfolio/orders/batched", struct {
+			IDs []string `json:"ids"`
+		}{ids})
+		if err != nil {
+			return canceled, err
+		}
+		if status < 200 || status >= 300 {
+			return canceled, fmt.Errorf("batch cancel failed: status=%d body=%s", status, string(body))
+		}
+		var resp struct {
+			Orders []struct {
+				OrderID   string `json:"order_id"`
+				ReducedBy int    `json:"reduced_by"`
+				Error     *struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			} `json:"orders"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return canceled, fmt.Errorf("unmarshal batch cancel response: %w", err)
+		}
+		for _, r := range resp.Orders {
+			if r.Error != nil {
+				telemetry.Warnf("kalshi: batch cancel %s: %s", r.OrderID, r.Error.Message)
+				continue
+			}
+			if r.ReducedBy > 0 {
+				canceled++
+			}
+		}
+	}
+	return canceled, nil
+}
+
+// AmendOrderRequest is the payload for POST /trade-api/v2/portfolio/orders/{id}/amend.
+// Exactly one of YesPriceDollars / NoPriceDollars is set. CountFP is the
+// order's max fillable contracts (fill_count + remaining_count).
+type AmendOrderRequest struct {
+	Ticker               string `json:"ticker"`
+	Side                 string `json:"side"`
+	Action               string `json:"action"`
+	ClientOrderID        string `json:"client_order_id,omitempty"`
+	UpdatedClientOrderID string `json:"updated_client_order_id,omitempty"`
+	YesPriceDollars      string `json:"yes_price_dollars,omitempty"`
+	NoPriceDollars       string `json:"no_price_dollars,omitempty"`
+	CountFP              string `json:"count_fp,omitempty"`
+}
+
+// AmendOrder reprices (and/or resizes) a resting order and returns the
+// amended order.
+func (c *Client) AmendOrder(ctx context.Context, orderID string, req AmendOrderRequest) (*OrderDetail, error) {
+	path := fmt.Sprintf("/trade-api/v2/portfolio/orders/%s/amend", orderID)
+	body, status, err := c.Post(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("amend rejected: status=%d body=%s", status, string(body))
+	}
+	var resp struct {
+		Order OrderDetail `json:"order"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal amend response: %w", err)
+	}
+	return &resp.Order, nil
+}
+
+// DecreaseOrder shrinks a resting order to reduceTo remaining contracts
+// and returns the updated order. reduceTo 0 is equivalent to a cancel.
+func (c *Client) DecreaseOrder(ctx context.Context, orderID string, reduceTo int) (*OrderDetail, error) {
+	path := fmt.Sprintf("/trade-api/v2/portfolio/orders/%s/decrease", orderID)
+	body, status, err := c.Post(ctx, path, struct {
+		ReduceTo int `json:"reduce_to"`
+	}{reduceTo})
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("decrease rejected: status=%d body=%s", status, string(body))
+	}
+	var resp struct {
+		Order OrderDetail `json:"order"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal decrease response: %w", err)
+	}
+	return &resp.Order, nil
+}
+
 // Market represents a single Kalshi market from the API.
 type Market struct {
-	Ticker                 string `json:"ticker"`
-	EventTicker            string `json:"event_ticker"`
-	Title                  string `json:"title"`
-	Subtitle               string `json:"subtitle"`
-	YesSubTitle            string `json:"yes_sub_title"`
-	NoSubTitle             string `json:"no_sub_title"`
-	Status                 string `json:"status"`
-	ExpectedExpirationTime string `json:"expected_expiration_time"`
-	CloseTime              string `json:"close_time"`
-	Volume                 int64  `json:"volume"`
-	YesAskDollars          string `json:"yes_ask_dollars"`
-	YesBidDollars          string `json:"yes_bid_dollars"`
-	NoAskDollars           string `json:"no_ask_dollars"`
-	NoBidDollars           string `json:"no_bid_dollars"`
-	MutuallyExclusive      bool   `json:"mutually_exclusive"`
+	Ticker                 string  `json:"ticker"`
+	EventTicker            string  `json:"event_ticker"`
+	Title                  string  `json:"title"`
+	Subtitle               string  `json:"subtitle"`
+	YesSubTitle            string  `json:"yes_sub_title"`
+	NoSubTitle             string  `json:"no_sub_title"`
+	Status                 string  `json:"status"`
+	ExpectedExpirationTime string  `json:"expected_expiration_time"`
+	CloseTime              string  `json:"close_time"`
+	Volume                 int64   `json:"volume"`
+	YesAskDollars          string  `json:"yes_ask_dollars"`
+	YesBidDollars          string  `json:"yes_bid_dollars"`
+	NoAskDollars           string  `json:"no_ask_dollars"`
+	NoBidDollars           string  `json:"no_bid_dollars"`
+	MutuallyExclusive      bool    `json:"mutually_exclusive"`
+	FloorStrike            float64 `json:"floor_strike"`
 }
 
 func (m Market) EffectiveYesAsk() int { return dollarsToCentsInt(m.YesAskDollars) }
@@ -236,3 +341,90 @@ func (c *Client) GetPositions(ctx context.Context) (*PositionResponse, error) {
 	}
 	return &resp, nil
 }
+
+// Fill is one trade on one of our orders, from GET /portfolio/fills.
+// Prices are cents; the price paid is YesPrice for YES and NoPrice for NO.
+type Fill struct {
+	TradeID     string `json:"trade_id"`
+	OrderID     string `json:"order_id"`
+	Ticker      string `json:"ticker"`
+	Side        string `json:"side"`
+	Action      string `json:"action"`
+	Count       int    `json:"count"`
+	YesPrice    int    `json:"yes_price"`
+	NoPrice     int    `json:"no_price"`
+	IsTaker     bool   `json:"is_taker"`
+	CreatedTime string `json:"created_time"`
+}
+
+// GetFills returns every fill since minTs (Unix seconds; 0 for all),
+// following the cursor across pages.
+func (c *Client) GetFills(ctx context.Context, minTs int64) ([]Fill, error) {
+	var all []Fill
+	cursor := ""
+	for {
+		path := "/trade-api/v2/portfolio/fills?limit=1000"
+		if minTs > 0 {
+			path += fmt.Sprintf("&min_ts=%d", minTs)
+		}
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		body, status, err := c.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if status != 200 {
+			return nil, fmt.Errorf("get fills: status=%d body=%s", status, string(body))
+		}
+		var resp struct {
+			Fills  []Fill `json:"fills"`
+			Cursor string `json:"cursor"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("unmarshal fills: %w", err)
+		}
+		all = append(all, resp.Fills...)
+		if resp.Cursor == "" || len(resp.Fills) == 0 {
+			break
+		}
+		cursor = resp.Cursor
+	}
+	return all, nil
+}
+
+// GetOrders returns our orders with the given status ("resting",
+// "canceled", "executed"; empty for all), following the cursor.
+func (c *Client) GetOrders(ctx context.Context, status string) ([]OrderDetail, error) {
+	var all []OrderDetail
+	cursor := ""
+	for {
+		path := "/trade-api/v2/portfolio/orders?limit=1000"
+		if status != "" {
+			path += "&status=" + status
+		}
+		if cursor != "" {
+			path += "&cursor=" + cursor
+		}
+		body, code, err := c.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		if code != 200 {
+			return nil, fmt.Errorf("get orders: status=%d body=%s", code, string(body))
+		}
+		var resp struct {
+			Orders []OrderDetail `json:"orders"`
+			Cursor string        `json:"cursor"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("unmarshal orders: %w", err)
+		}
+		all = append(all, resp.Orders...)
+		if resp.Cursor == "" || len(resp.Orders) == 0 {
+			break
+		}
+		cursor = resp.Cursor
+	}
+	return all, nil
+}
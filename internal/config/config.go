@@ -35,6 +35,19 @@ type Config struct {
 	// Risk
 	RiskLimitsPath string
 
+	// Models
+	HockeyScoringRatesPath string // league goals-per-60 table for the hockey models
+	ModelParamsPath        string // fitted parameters written by cmd/calibrate
+	StrategyParamsPath     string // per-league strategy knobs, reloaded on change
+
+	// Shadow strategies
+	ShadowStrategies string // comma-separated shadow strategy IDs, e.g. "v4"
+	ShadowDBPath     string // decision log of live and shadow intents
+
+	// Trading mode
+	TradingMode       string // "live" or "paper"
+	PaperBalanceCents int    // starting cash for the paper exchange
+
 	// ngrok
 	NgrokEnabled   bool
 	NgrokAuthToken string
@@ -44,6 +57,11 @@ type Config struct {
 	FanoutPort int    // port the central fanout server listens on
 	FanoutAddr string // address sport processes connect to
 
+	// Kill switch
+	AdminAddr      string // central admin HTTP endpoint (POST /admin/halt)
+	AdminToken     string // shared secret for the admin endpoints; empty disables them
+	KillSwitchFile string // trading halts while this file exists
+
 	// Rate limiting
 	RateDivisor int // divide Kalshi rate limits by this (set to N when running N sport processes)
 
@@ -57,9 +75,11 @@ type Config struct {
 
 	// Order tracking
 	OrderTrackingDBPath string
+	IdempotencyDBPath   string // dedup keys and client_order_id session
 
 	// Overturns
-	OverturnDBPath string
+	OverturnDBPath          string
+	OverturnReplaceOnReject bool // re-place orders canceled for a pending overturn once it is rejected
 
 	// Telemetry
 	LogLevel string
@@ -106,6 +126,16 @@ func Load() *Config {
 
 		RiskLimitsPath: envStr("RISK_LIMITS_PATH", "internal/config/risk_limits.yaml"),
 
+		HockeyScoringRatesPath: envStr("HOCKEY_SCORING_RATES_PATH", "internal/config/hockey_scoring_rates.yaml"),
+		ModelParamsPath:        envStr("MODEL_PARAMS_PATH", "internal/config/model_params.yaml"),
+		StrategyParamsPath:     envStr("STRATEGY_PARAMS_PATH", "internal/config/strategy_params.yaml"),
+
+		ShadowStrategies: envStr("SHADOW_STRATEGIES", ""),
+		ShadowDBPath:     envStr("SHADOW_DB_PATH", "data/shadow_decisions.db"),
+
+		TradingMode:       envStr("TRADING_MODE", "live"),
+		PaperBalanceCents: envInt("PAPER_BALANCE_CENTS", 100000),
+
 		NgrokEnabled:   envStr("NGROK_ENABLED", "true") == "true",
 		NgrokAuthToken: envStr("NGROK_AUTH_TOKEN", ""),
 		NgrokDomain:    envStr("NGROK_DOMAIN", ""),
@@ -114,6 +144,10 @@ func Load() *Config {
 		FanoutAddr:  envStr("FANOUT_ADDR", "localhost:9100"),
 		RateDivisor: envInt("RATE_DIVISOR", 1),
 
+		AdminAddr:      envStr("ADMIN_ADDR", "127.0.0.1:9400"),
+		AdminToken:     envStr("ADMIN_TOKEN", ""),
+		KillSwitchFile: envStr("KILL_SWITCH_FILE", "data/HALT"),
+
 		TickersConfigDir: envStr("TICKERS_CONFIG_DIR", "configs"),
 
 		SoccerTrainingDBPath:     envStr("SOCCER_TRAINING_DB_PATH", "data/soccer_training.db"),
@@ -121,8 +155,10 @@ func Load() *Config {
 		TrainingBackfillDelaySec: envInt("TRAINING_BACKFILL_DELAY_SEC", 10),
 
 		OrderTrackingDBPath: envStr("ORDER_TRACKING_DB_PATH", "data/order_tracking.db"),
+		IdempotencyDBPath:   envStr("IDEMPOTENCY_DB_PATH", "data/idempotency.db"),
 
-		OverturnDBPath: envStr("OVERTURN_DB_PATH", "data/overturns.db"),
+		OverturnDBPath:          envStr("OVERTURN_DB_PATH", "data/overturns.db"),
+		OverturnReplaceOnReject: envStr("OVERTURN_REPLACE_ON_REJECT", "false") == "true",
 
 		LogLevel: envStr("LOG_LEVEL", "info"),
 	}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LogisticParams recalibrate a model probability as σ(a·logit(p) + b).
+type LogisticParams struct {
+	A       float64 `yaml:"a"`
+	B       float64 `yaml:"b"`
+	Samples int     `yaml:"samples,omitempty"`
+}
+
+// RecalParams hold a recalibration per league (lowercase keys) and a
+// default for the rest.
+type RecalParams struct {
+	Default LogisticParams            `yaml:"default"`
+	Leagues map[string]LogisticParams `yaml:"leagues,omitempty"`
+}
+
+// DecayParams are the hockey V2 logistic model constants.
+type DecayParams struct {
+	K      float64 `yaml:"k"`      // lead coefficient
+	A      float64 `yaml:"a"`      // time amplification
+	Theta  float64 `yaml:"theta"`  // time denominator offset
+	Eta    float64 `yaml:"eta"`    // time exponent base
+	Lambda float64 `yaml:"lambda"` // lead decay on the time exponent
+}
+
+type HockeyModelParams struct {
+	Decay      DecayParams `yaml:"decay"`
+	ShareScale float64     `yaml:"share_scale"` // tilt of the Poisson scoring share from 50/50
+	Recal      RecalParams `yaml:"recalibration"`
+}
+
+type SoccerModelParams struct {
+	WinRecal  RecalParams `yaml:"win_recalibration"`  // home and away prices
+	DrawRecal RecalParams `yaml:"draw_recalibration"` // draw price
+}
+
+// ModelParams are the fitted model parameters written by cmd/calibrate.
+// Version increases by one on every fit; zero values mean "use the
+// compiled-in default".
+type ModelParams struct {
+	Version  int               `yaml:"version"`
+	FittedAt string            `yaml:"fitted_at,omitempty"`
+	Hockey   HockeyModelParams `yaml:"hockey"`
+	Soccer   SoccerModelParams `yaml:"soccer"`
+}
+
+func LoadModelParams(path string) (ModelParams, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ModelParams{}, fmt.Errorf("read model params: %w", err)
+	}
+
+	var params ModelParams
+	if err := yaml.Unmarshal(data, &params); err != nil {
+		return ModelParams{}, fmt.Errorf("parse model params: %w", err)
+	}
+
+	return params, nil
+}
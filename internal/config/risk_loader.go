@@ -7,18 +7,58 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// SizingLimits are the fractional-Kelly parameters. Fields a league leaves
+// unset inherit the sport's value; KellyFraction is a pointer so that a
+// league can set it to 0 and trade one contract per order.
+type SizingLimits struct {
+	KellyFraction     *float64 `yaml:"kelly_fraction"`
+	MaxOrderContracts int      `yaml:"max_order_contracts"`
+}
+
+// Kelly returns the Kelly fraction, 0 when unset.
+func (s SizingLimits) Kelly() float64 {
+	if s.KellyFraction == nil {
+		return 0
+	}
+	return *s.KellyFraction
+}
+
+// LossLimits are circuit-breaker thresholds on the trading day's P&L
+// (realized + mark-to-market). Zero disables a threshold.
+type LossLimits struct {
+	MaxDailyLossCents int `yaml:"max_daily_loss_cents"`
+	MaxDrawdownCents  int `yaml:"max_drawdown_cents"` // from the day's peak
+}
+
+// PreTradeLimits configure the lanes' market sanity checks. Zero disables
+// a check; the WS-connected and crossed/reset-book checks always run.
+type PreTradeLimits struct {
+	MaxQuoteAgeSeconds int `yaml:"max_quote_age_seconds"`
+	MaxLimitCents      int `yaml:"max_limit_cents"`
+	MaxSpreadCents     int `yaml:"max_spread_cents"`
+}
+
 type LeagueLimits struct {
-	MaxGameCents int `yaml:"max_game_cents"`
+	MaxGameCents     int          `yaml:"max_game_cents"`
+	MaxGameContracts int          `yaml:"max_game_contracts"`
+	Sizing           SizingLimits `yaml:"sizing"`
+	LossLimits       LossLimits   `yaml:"loss_limits"`
 }
 
 type SportLimits struct {
-	MaxSportCents   int                     `yaml:"max_sport_cents"`
-	OrderTTLSeconds int                     `yaml:"order_ttl_seconds"`
-	Leagues         map[string]LeagueLimits `yaml:"leagues"`
+	MaxSportCents       int                     `yaml:"max_sport_cents"`
+	OrderTTLSeconds     int                     `yaml:"order_ttl_seconds"`
+	RequoteMinMoveCents int                     `yaml:"requote_min_move_cents"`
+	Sizing              SizingLimits            `yaml:"sizing"`
+	LossLimits          LossLimits              `yaml:"loss_limits"`
+	PreTrade            PreTradeLimits          `yaml:"pre_trade"`
+	Leagues             map[string]LeagueLimits `yaml:"leagues"`
 }
 
 type GlobalLimits struct {
-	DefaultBankrollCents int `yaml:"default_bankroll_cents"`
+	DefaultBankrollCents int    `yaml:"default_bankroll_cents"`
+	LiveBankroll         bool   `yaml:"live_bankroll"`
+	TradingDayTimezone   string `yaml:"trading_day_timezone"`
 }
 
 type RiskLimits struct {
@@ -53,3 +93,20 @@ func (rl RiskLimits) LeagueLimit(sport, league string) (LeagueLimits, bool) {
 	ll, ok := sl.Leagues[league]
 	return ll, ok
 }
+
+// LeagueSizing returns a league's sizing parameters with unset fields
+// filled from the sport.
+func (sl SportLimits) LeagueSizing(league string) SizingLimits {
+	sz := sl.Sizing
+	ll, ok := sl.Leagues[league]
+	if !ok {
+		return sz
+	}
+	if ll.Sizing.KellyFraction != nil {
+		sz.KellyFraction = ll.Sizing.KellyFraction
+	}
+	if ll.Sizing.MaxOrderContracts > 0 {
+		sz.MaxOrderContracts = ll.Sizing.MaxOrderContracts
+	}
+	return sz
+}
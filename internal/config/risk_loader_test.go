@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLeagueSizing(t *testing.T) {
+	const doc = `
+sizing:
+  kelly_fraction: 0.25
+  max_order_contracts: 50
+leagues:
+  NHL:
+    sizing:
+      max_order_contracts: 20
+  AHL:
+    sizing:
+      kelly_fraction: 0.1
+  KHL:
+    sizing:
+      kelly_fraction: 0
+`
+	var sl SportLimits
+	if err := yaml.Unmarshal([]byte(doc), &sl); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		league    string
+		kelly     float64
+		contracts int
+	}{
+		{"NHL", 0.25, 20},
+		{"AHL", 0.1, 50},
+		{"KHL", 0, 50},
+		{"SHL", 0.25, 50},
+	}
+	for _, tt := range tests {
+		sz := sl.LeagueSizing(tt.league)
+		if sz.Kelly() != tt.kelly || sz.MaxOrderContracts != tt.contracts {
+			t.Errorf("%s: kelly %v, max %d; want %v, %d",
+				tt.league, sz.Kelly(), sz.MaxOrderContracts, tt.kelly, tt.contracts)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LeagueRate is a league's average regulation scoring rate and the number
+// of games it was fitted from.
+type LeagueRate struct {
+	GoalsPer60 float64 `yaml:"goals_per_60"`
+	Games      int     `yaml:"games,omitempty"`
+}
+
+// ScoringRates are the hockey models' total scoring rates by league, in
+// regulation goals per 60 minutes. Leagues not listed use the default.
+type ScoringRates struct {
+	DefaultGoalsPer60 float64               `yaml:"default_goals_per_60"`
+	Leagues           map[string]LeagueRate `yaml:"leagues"`
+}
+
+func LoadScoringRates(path string) (ScoringRates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ScoringRates{}, fmt.Errorf("read scoring rates: %w", err)
+	}
+
+	var rates ScoringRates
+	if err := yaml.Unmarshal(data, &rates); err != nil {
+		return ScoringRates{}, fmt.Errorf("parse scoring rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+// GoalsPer60 returns each league's rate keyed by lowercase league name,
+// skipping non-positive entries.
+func (sr ScoringRates) GoalsPer60() map[string]float64 {
+	out := make(map[string]float64, len(sr.Leagues))
+	for league, lr := range sr.Leagues {
+		if lr.GoalsPer60 > 0 {
+			out[strings.ToLower(strings.TrimSpace(league))] = lr.GoalsPer60
+		}
+	}
+	return out
+}
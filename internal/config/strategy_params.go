@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StrategyParams are the trading knobs a strategy applies to one league,
+// resolved from the file by StrategyParamsFile.For.
+type StrategyParams struct {
+	EdgeThresholdPct float64
+	ConfirmSec       int      // score-drop confirmation window
+	OrderTTLSeconds  int      // 0 = the sport's risk-limits TTL
+	MinTimeRemaining float64  // minutes; no new orders below
+	MaxPriceCents    int      // cap on limit prices; 0 = none
+	Sides            []string // "yes" and/or "no"; empty = both
+
+	// Market-leads-feed detection: a ticker moving MarketLeadCents within
+	// MarketLeadWindowSec with no score change from the feed suspends new
+	// orders on the game for MarketLeadHoldSec. Zero or negative cents
+	// turns it off.
+	MarketLeadCents     float64
+	MarketLeadWindowSec int
+	MarketLeadHoldSec   int
+}
+
+// StrategyOverrides is one level of the strategy params file. Unset fields
+// inherit from the level above: league → sport → defaults. Fields are
+// pointers, and Sides is nil when unset, so that a level can set a value
+// back to 0 or to an empty list.
+type StrategyOverrides struct {
+	EdgeThresholdPct *float64 `yaml:"edge_threshold_pct"`
+	ConfirmSec       *int     `yaml:"confirm_sec"`
+	OrderTTLSeconds  *int     `yaml:"order_ttl_seconds"`
+	MinTimeRemaining *float64 `yaml:"min_time_remaining"`
+	MaxPriceCents    *int     `yaml:"max_price_cents"`
+	Sides            []string `yaml:"sides"`
+
+	MarketLeadCents     *float64 `yaml:"market_lead_cents"`
+	MarketLeadWindowSec *int     `yaml:"market_lead_window_sec"`
+	MarketLeadHoldSec   *int     `yaml:"market_lead_hold_sec"`
+}
+
+type SportStrategyParams struct {
+	StrategyOverrides `yaml:",inline"`
+	Leagues           map[string]StrategyOverrides `yaml:"leagues"`
+}
+
+type StrategyParamsFile struct {
+	Defaults StrategyOverrides              `yaml:"defaults"`
+	Sports   map[string]SportStrategyParams `yaml:"sports"`
+}
+
+// DefaultStrategyParams apply where the file leaves a field unset.
+var DefaultStrategyParams = StrategyParams{
+	EdgeThresholdPct: 3.0,
+	ConfirmSec:       15,
+	Sides:            []string{"yes", "no"},
+
+	MarketLeadCents:     8,
+	MarketLeadWindowSec: 5,
+	MarketLeadHoldSec:   30,
+}
+
+func LoadStrategyParams(path string) (StrategyParamsFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return StrategyParamsFile{}, fmt.Errorf("read strategy params: %w", err)
+	}
+
+	var f StrategyParamsFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return StrategyParamsFile{}, fmt.Errorf("parse strategy params: %w", err)
+	}
+
+	for _, p := range f.all() {
+		for _, side := range p.Sides {
+			if side != "yes" && side != "no" {
+				return StrategyParamsFile{}, fmt.Errorf("strategy params: unknown side %q", side)
+			}
+		}
+	}
+	return f, nil
+}
+
+// For resolves the parameters of a league. League keys match
+// case-insensitively.
+func (f StrategyParamsFile) For(sport, league string) StrategyParams {
+	p := f.Defaults.apply(DefaultStrategyParams)
+	sp, ok := f.Sports[sport]
+	if !ok {
+		return p
+	}
+	p = sp.StrategyOverrides.apply(p)
+	for name, lp := range sp.Leagues {
+		if strings.EqualFold(name, strings.TrimSpace(league)) {
+			return lp.apply(p)
+		}
+	}
+	return p
+}
+
+// MarketLeadEnabled reports whether market-leads-feed detection is on.
+func (p StrategyParams) MarketLeadEnabled() bool {
+	return p.MarketLeadCents > 0 && p.MarketLeadWindowSec > 0
+}
+
+// SideEnabled reports whether orders may be placed on side.
+func (p StrategyParams) SideEnabled(side string) bool {
+	return len(p.Sides) == 0 || slices.Contains(p.Sides, side)
+}
+
+// apply returns p with the fields o sets replaced.
+func (o StrategyOverrides) apply(p StrategyParams) StrategyParams {
+	set(&p.EdgeThresholdPct, o.EdgeThresholdPct)
+	set(&p.ConfirmSec, o.ConfirmSec)
+	set(&p.OrderTTLSeconds, o.OrderTTLSeconds)
+	set(&p.MinTimeRemaining, o.MinTimeRemaining)
+	set(&p.MaxPriceCents, o.MaxPriceCents)
+	if o.Sides != nil {
+		p.Sides = o.Sides
+	}
+	set(&p.MarketLeadCents, o.MarketLeadCents)
+	set(&p.MarketLeadWindowSec, o.MarketLeadWindowSec)
+	set(&p.MarketLeadHoldSec, o.MarketLeadHoldSec)
+	return p
+}
+
+func set[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func (f StrategyParamsFile) all() []StrategyOverrides {
+	out := []StrategyOverrides{f.Defaults}
+	for _, sp := range f.Sports {
+		out = append(out, sp.StrategyOverrides)
+		for _, lp := range sp.Leagues {
+			out = append(out, lp)
+		}
+	}
+	return out
+}
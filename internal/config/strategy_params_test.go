@@ -0,0 +1,119 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeParams(t *testing.T, doc string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "strategy_params.yaml")
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStrategyParamsFor(t *testing.T) {
+	const doc = `
+defaults:
+  edge_threshold_pct: 4
+  max_price_cents: 90
+sports:
+  hockey:
+    confirm_sec: 20
+    min_time_remaining: 3
+    sides: [yes]
+    leagues:
+      NHL:
+        edge_threshold_pct: 2.5
+      AHL:
+        min_time_remaining: 0
+        max_price_cents: 0
+        sides: []
+      KHL:
+        market_lead_cents: 0
+`
+	f, err := LoadStrategyParams(writeParams(t, doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		sport, league string
+		edge          float64
+		confirm       int
+		minTime       float64
+		maxPrice      int
+		sides         []string
+		marketLead    bool
+	}{
+		{"unknown sport takes the defaults", "soccer", "EPL", 4, 15, 0, 90, []string{"yes", "no"}, true},
+		{"unknown league takes the sport", "hockey", "SHL", 4, 20, 3, 90, []string{"yes"}, true},
+		{"league overrides", "hockey", "NHL", 2.5, 20, 3, 90, []string{"yes"}, true},
+		{"league key is case-insensitive", "hockey", " nhl ", 2.5, 20, 3, 90, []string{"yes"}, true},
+		{"explicit zeros override", "hockey", "AHL", 4, 20, 0, 0, []string{}, true},
+		{"zero cents turns market lead off", "hockey", "KHL", 4, 20, 3, 90, []string{"yes"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := f.For(tt.sport, tt.league)
+			if p.EdgeThresholdPct != tt.edge || p.ConfirmSec != tt.confirm ||
+				p.MinTimeRemaining != tt.minTime || p.MaxPriceCents != tt.maxPrice {
+				t.Errorf("edge %v confirm %d minTime %v maxPrice %d; want %v %d %v %d",
+					p.EdgeThresholdPct, p.ConfirmSec, p.MinTimeRemaining, p.MaxPriceCents,
+					tt.edge, tt.confirm, tt.minTime, tt.maxPrice)
+			}
+			if !slices.Equal(p.Sides, tt.sides) {
+				t.Errorf("sides %v, want %v", p.Sides, tt.sides)
+			}
+			if p.MarketLeadEnabled() != tt.marketLead {
+				t.Errorf("market lead enabled %v, want %v", p.MarketLeadEnabled(), tt.marketLead)
+			}
+		})
+	}
+}
+
+func TestLoadStrategyParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{"valid sides", "defaults:\n  sides: [yes, no]\n", ""},
+		{"unknown side in defaults", "defaults:\n  sides: [yes, maybe]\n", `unknown side "maybe"`},
+		{"unknown side in a league", "sports:\n  soccer:\n    leagues:\n      EPL:\n        sides: [YES]\n", `unknown side "YES"`},
+		{"bad yaml", "defaults: [\n", "parse strategy params"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadStrategyParams(writeParams(t, tt.doc))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadStrategyParams(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("missing file loaded")
+	}
+}
+
+func TestRepoStrategyParams(t *testing.T) {
+	f, err := LoadStrategyParams("strategy_params.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sport := range []string{"hockey", "soccer", "football"} {
+		if p := f.For(sport, ""); p.EdgeThresholdPct != 3 || !p.MarketLeadEnabled() || !p.SideEnabled("no") {
+			t.Errorf("%s: %+v", sport, p)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package calibration
+
+import (
+	"math"
+	"strings"
+
+	"github.com/charleschow/hft-trading/internal/config"
+)
+
+// probFloor keeps logits and log-loss finite for predictions of exactly 0 or 1.
+const probFloor = 1e-6
+
+// Logistic is a Platt-style recalibration of a model probability:
+// p' = σ(A·logit(p) + B). The zero value leaves probabilities unchanged.
+type Logistic struct {
+	A, B float64
+}
+
+// Apply returns the recalibrated probability. Certain outcomes (0 or 1)
+// pass through unchanged.
+func (l Logistic) Apply(p float64) float64 {
+	if l.A == 0 || p <= 0 || p >= 1 {
+		return p
+	}
+	return sigmoid(l.A*logit(p) + l.B)
+}
+
+// Table holds a recalibration per league (lowercase keys) with a default
+// for leagues without enough data of their own.
+type Table struct {
+	Default Logistic
+	Leagues map[string]Logistic
+}
+
+// For returns the league's recalibration, or the default.
+func (t Table) For(league string) Logistic {
+	if l, ok := t.Leagues[strings.ToLower(strings.TrimSpace(league))]; ok {
+		return l
+	}
+	return t.Default
+}
+
+// TableFrom converts the recalibration section of the model parameter file.
+func TableFrom(rp config.RecalParams) Table {
+	t := Table{
+		Default: Logistic{A: rp.Default.A, B: rp.Default.B},
+		Leagues: make(map[string]Logistic, len(rp.Leagues)),
+	}
+	for league, lp := range rp.Leagues {
+		t.Leagues[strings.ToLower(strings.TrimSpace(league))] = Logistic{A: lp.A, B: lp.B}
+	}
+	return t
+}
+
+// FitLogistic fits A and B by Newton's method on the log-loss of
+// σ(A·logit(p) + B) against 0/1 outcomes.
+func FitLogistic(preds, outcomes []float64) Logistic {
+	a, b := 1.0, 0.0
+	for range 50 {
+		var ga, gb, haa, hab, hbb float64
+		for i, p := range preds {
+			x := logit(clamp(p))
+			q := sigmoid(a*x + b)
+			r := q - outcomes[i]
+			w := q * (1 - q)
+			ga += r * x
+			gb += r
+			haa += w * x * x
+			hab += w * x
+			hbb += w
+		}
+		// Small ridge keeps the Hessian invertible on degenerate data.
+		haa += 1e-6
+		hbb += 1e-6
+		det := haa*hbb - hab*hab
+		if det == 0 {
+			break
+		}
+		da := (hbb*ga - hab*gb) / det
+		db := (haa*gb - hab*ga) / det
+		a -= da
+		b -= db
+		if math.Abs(da) < 1e-9 && math.Abs(db) < 1e-9 {
+			break
+		}
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || a <= 0 {
+		return Logistic{A: 1}
+	}
+	return Logistic{A: a, B: b}
+}
+
+// Brier returns the mean squared error of preds against 0/1 outcomes.
+func Brier(preds, outcomes []float64) float64 {
+	if len(preds) == 0 {
+		return 0
+	}
+	var sum float64
+	for i, p := range preds {
+		d := p - outcomes[i]
+		sum += d * d
+	}
+	return sum / float64(len(preds))
+}
+
+// LogLoss returns the mean negative log-likelihood of 0/1 outcomes.
+func LogLoss(preds, outcomes []float64) float64 {
+	if len(preds) == 0 {
+		return 0
+	}
+	var sum float64
+	for i, p := range preds {
+		p = clamp(p)
+		if outcomes[i] > 0.5 {
+			sum -= math.Log(p)
+		} else {
+			sum -= math.Log(1 - p)
+		}
+	}
+	return sum / float64(len(preds))
+}
+
+// Bin is one bucket of a reliability diagram.
+type Bin struct {
+	Lo, Hi    float64
+	Count     int
+	Predicted float64 // mean prediction in the bin
+	Observed  float64 // share of outcomes that happened
+}
+
+// Reliability buckets predictions into n equal-width bins over [0, 1].
+func Reliability(preds, outcomes []float64, n int) []Bin {
+	bins := make([]Bin, n)
+	for i := range bins {
+		bins[i].Lo = float64(i) / float64(n)
+		bins[i].Hi = float64(i+1) / float64(n)
+	}
+	for i, p := range preds {
+		k := min(n-1, max(0, int(p*float64(n))))
+		bins[k].Count++
+		bins[k].Predicted += p
+		bins[k].Observed += outcomes[i]
+	}
+	for i := range bins {
+		if c := float64(bins[i].Count); c > 0 {
+			bins[i].Predicted /= c
+			bins[i].Observed /= c
+		}
+	}
+	return bins
+}
+
+func logit(p float64) float64 { return math.Log(p / (1 - p)) }
+
+func sigmoid(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
+
+func clamp(p float64) float64 { return math.Max(probFloor, math.Min(1-probFloor, p)) }
@@ -134,6 +134,7 @@ func PrintHockey(gc *game.GameContext, eventType string) {
 			fmt.Fprintf(&b, "    >>> %s\n", strings.Join(edges, " | "))
 		}
 	}
+	writeLines(&b, gc, hs.Lines, homeShort, awayShort)
 	fmt.Fprintf(&b, "%s\n", divider)
 
 	fmt.Fprint(os.Stderr, b.String())
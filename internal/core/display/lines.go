@@ -0,0 +1,45 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+)
+
+// writeLines prints a row per totals or spread market: Kalshi prices,
+// the model's YES price and any edge of 3% or more.
+func writeLines(b *strings.Builder, gc *game.GameContext, lines []game.LineMarket, homeShort, awayShort string) {
+	for _, l := range lines {
+		label := fmt.Sprintf("Over %g:", l.Line)
+		if l.Kind == game.LineSpread {
+			team := homeShort
+			if l.Team == "away" {
+				team = awayShort
+			}
+			label = fmt.Sprintf("%s -%g:", team, l.Line)
+		}
+
+		prices := "Yes  —   |  No  —"
+		if td, ok := gc.Tickers[l.Ticker]; ok && (td.YesAsk > 0 || td.NoAsk > 0) {
+			prices = fmt.Sprintf("Yes %2.0fc  |  No %2.0fc", td.YesAsk, td.NoAsk)
+		}
+		if !l.Priced {
+			fmt.Fprintf(b, "    Line    %-30s%s\n", label, prices)
+			continue
+		}
+
+		var edges []string
+		if l.EdgeYes >= 3.0 {
+			edges = append(edges, fmt.Sprintf("YES %+.1f%%", l.EdgeYes))
+		}
+		if l.EdgeNo >= 3.0 {
+			edges = append(edges, fmt.Sprintf("NO %+.1f%%", l.EdgeNo))
+		}
+		edge := ""
+		if len(edges) > 0 {
+			edge = "  >>> " + strings.Join(edges, " | ")
+		}
+		fmt.Fprintf(b, "    Line    %-30s%s  |  Model %.1f%%%s\n", label, prices, l.ModelYes, edge)
+	}
+}
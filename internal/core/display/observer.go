@@ -56,7 +56,7 @@ func (d *DisplayObserver) OnGameEvent(gc *game.GameContext, eventType string) {
 			d.mu.Unlock()
 			return
 		}
-		if gc.Game.HasSignificantEdge() {
+		if gc.Game.HasSignificantEdge(gc.Params().EdgeThresholdPct) {
 			d.lastEdge[gc.EID] = time.Now()
 			d.mu.Unlock()
 			disp.DisplayGame(gc, "EDGE")
@@ -105,6 +105,10 @@ func PrintSoccer(gc *game.GameContext, eventType string) {
 	} else {
 		fmt.Fprintf(&b, "    %-40s%8s%14s%14s\n", "Kalshi NO:", "—", "—", "—")
 	}
+	if len(ss.Lines) > 0 {
+		fmt.Fprintf(&b, "\n")
+		writeLines(&b, gc, ss.Lines, homeShort, awayShort)
+	}
 
 	fmt.Fprintf(&b, "%s\n", divider)
 
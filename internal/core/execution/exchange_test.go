@@ -0,0 +1,121 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/store"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// TestMain sets the logger up front, as main does: the lazy default
+// would otherwise be created by whichever goroutine logs first.
+func TestMain(m *testing.M) {
+	telemetry.Init(slog.LevelWarn)
+	os.Exit(m.Run())
+}
+
+// fakeExchange is an OrderPlacer that rests every order it is sent, unless
+// respond answers instead, and reports each batch on placed.
+type fakeExchange struct {
+	mu       sync.Mutex
+	batches  [][]kalshi_http.CreateOrderRequest
+	canceled []string
+	amended  map[string]kalshi_http.AmendOrderRequest
+	reduced  map[string]int
+	seq      int
+
+	cancelErr  error
+	cancelGate chan struct{} // when set, each cancel waits for a receive
+	respond    func([]kalshi_http.CreateOrderRequest) (*kalshi_http.BatchCreateOrdersResponse, error)
+	placed     chan []kalshi_http.CreateOrderRequest
+}
+
+func newFakeExchange() *fakeExchange {
+	return &fakeExchange{
+		amended: make(map[string]kalshi_http.AmendOrderRequest),
+		reduced: make(map[string]int),
+		placed:  make(chan []kalshi_http.CreateOrderRequest, 16),
+	}
+}
+
+func (x *fakeExchange) PlaceOrder(context.Context, kalshi_http.CreateOrderRequest) (*kalshi_http.CreateOrderResponse, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (x *fakeExchange) PlaceBatchOrders(_ context.Context, req kalshi_http.BatchCreateOrdersRequest) (*kalshi_http.BatchCreateOrdersResponse, error) {
+	x.mu.Lock()
+	x.batches = append(x.batches, req.Orders)
+	if x.respond != nil {
+		x.mu.Unlock()
+		x.placed <- req.Orders
+		return x.respond(req.Orders)
+	}
+	resp := &kalshi_http.BatchCreateOrdersResponse{}
+	for _, o := range req.Orders {
+		x.seq++
+		n, _ := strconv.Atoi(strings.TrimSuffix(o.CountFP, ".00"))
+		resp.Orders = append(resp.Orders, kalshi_http.BatchCreateOrdersIndividualResponse{
+			Order: &kalshi_http.OrderDetail{
+				OrderID:        "o" + strconv.Itoa(x.seq),
+				ClientOrderID:  o.ClientID,
+				Ticker:         o.Ticker,
+				Side:           o.Side,
+				Status:         "resting",
+				RemainingCount: n,
+			},
+		})
+	}
+	x.mu.Unlock()
+	x.placed <- req.Orders
+	return resp, nil
+}
+
+func (x *fakeExchange) CancelOrder(_ context.Context, orderID string) error {
+	if x.cancelGate != nil {
+		<-x.cancelGate
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if x.cancelErr != nil {
+		return x.cancelErr
+	}
+	x.canceled = append(x.canceled, orderID)
+	return nil
+}
+
+func (x *fakeExchange) AmendOrder(_ context.Context, orderID string, req kalshi_http.AmendOrderRequest) (*kalshi_http.OrderDetail, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.amended[orderID] = req
+	n, _ := strconv.Atoi(strings.TrimSuffix(req.CountFP, ".00"))
+	return &kalshi_http.OrderDetail{OrderID: orderID, Status: "resting", RemainingCount: n}, nil
+}
+
+func (x *fakeExchange) DecreaseOrder(_ context.Context, orderID string, reduceTo int) (*kalshi_http.OrderDetail, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.reduced[orderID] = reduceTo
+	status := "resting"
+	if reduceTo == 0 {
+		status = "canceled"
+	}
+	return &kalshi_http.OrderDetail{OrderID: orderID, Status: status, RemainingCount: reduceTo}, nil
+}
+
+// newTestService returns a Service on x with one hockey NHL lane and no
+// tracker.
+func newTestService(x OrderPlacer, lane *lanes.Lane) *Service {
+	router := NewLaneRouter()
+	router.Register(events.SportHockey, "NHL", lane)
+	return NewService(events.NewBus(), router, x, store.New(), nil)
+}
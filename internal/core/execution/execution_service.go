@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
@@ -25,17 +27,25 @@ var _ OrderPlacer = (*kalshi_http.Client)(nil)
 //
 // Order placement is async — the HTTP call runs on a short-LIVEd goroutine
 // so it never blocks the game's event loop. The fill result is fed back
-// to the game's goroutine via gc.Send().
+// to the game's goroutine via gc.Send(), and later fills pushed over the
+// Kalshi WS user channels are folded in by the fill ledger.
 type Service struct {
 	bus       *events.Bus
 	router    *LaneRouter
 	client    OrderPlacer
 	gameStore *store.GameStateStore
 	tracker   *tracking.Tracker
+	fills     *fillLedger
+	groups    *orderGroups
+	sizer     sizer
+	dedup     *IdempotencyStore
+	halted    atomic.Bool
 	sessionID string
-	orderSeq  int64
+	orderSeq  atomic.Int64
 }
 
+// NewService returns a Service placing orders through client. tracker may
+// be nil, in which case nothing is recorded.
 func NewService(bus *events.Bus, router *LaneRouter, client OrderPlacer, gameStore *store.GameStateStore, tracker *tracking.Tracker) *Service {
 	s := &Service{
 		bus:       bus,
@@ -43,10 +53,20 @@ func NewService(bus *events.Bus, router *LaneRouter, client OrderPlacer, gameSto
 		client:    client,
 		gameStore: gameStore,
 		tracker:   tracker,
+		fills:     newFillLedger(tracker),
+		groups:    newOrderGroups(client),
 		sessionID: strconv.FormatInt(time.Now().UnixNano(), 36),
 	}
 
+	// Orders the WS never reported final are settled from the tracker's
+	// REST backfill, so their reservation is still released.
+	tracker.OnBackfill(func(d kalshi_http.OrderDetail) {
+		s.fills.onOrderUpdate(detailUpdate(d.OrderID, &d))
+	})
+
 	bus.Subscribe(events.EventOrderIntent, s.onOrderIntent)
+	bus.Subscribe(events.EventFill, s.onFill)
+	bus.Subscribe(events.EventOrderUpdate, s.onOrderUpdate)
 
 	return s
 }
@@ -57,7 +77,15 @@ func NewService(bus *events.Bus, router *LaneRouter, client OrderPlacer, gameSto
 // batch HTTP call.
 func (s *Service) onOrderIntent(evt events.Event) error {
 	intents, ok := evt.Payload.([]events.OrderIntent)
-	if !ok {
+	if !ok || s.halted.Load() {
+		return nil
+	}
+
+	// Requotes reprice the game's resting orders; they never place new ones.
+	if intents[0].Requote {
+		if gc, ok := s.gameStore.Get(intents[0].Sport, intents[0].GameID); ok {
+			s.requote(gc, intents)
+		}
 		return nil
 	}
 
@@ -81,11 +109,17 @@ func (s *Service) onOrderIntent(evt events.Event) error {
 			if lane := s.router.Route(intent.Sport, intent.League); lane != nil {
 				lane.ClearIdempotencyForTicker(intent.Ticker)
 				cleared[intent.Ticker] = true
+				if err := s.dedup.ClearTicker(intent.Ticker); err != nil {
+					telemetry.Warnf("idempotency store: clear %s failed: %v", intent.Ticker, err)
+				}
 			}
 		}
 	}
 
 	var approved []events.OrderIntent
+	var depth []int
+	// Approved earlier in this batch, not yet in the game's exposure.
+	batchCents, batchContracts := 0, 0
 	for _, intent := range intents {
 		lane := s.router.Route(intent.Sport, intent.League)
 		if lane == nil {
@@ -94,39 +128,154 @@ func (s *Service) onOrderIntent(evt events.Event) error {
 			continue
 		}
 
-		orderCents := int(intent.LimitPct)
-
 		gc, gcOK := s.gameStore.Get(intent.Sport, intent.GameID)
-		if gcOK && lane.MaxGameCents() > 0 {
-			spent := gc.TotalExposureCents()
-			if spent+orderCents > lane.MaxGameCents() {
-				telemetry.Infof("[RISK-LIMIT] %s — per-game cap (%d/%d¢ spent)",
-					matchLabel, spent, lane.MaxGameCents())
-				continue
-			}
+		spent, contracts := batchCents, batchContracts
+		if gcOK {
+			spent += gc.TotalExposureCents()
+			contracts += gc.TotalContracts()
 		}
 
-		reason := lane.Check(intent.Ticker, intent.Side, intent.HomeScore, intent.AwayScore, orderCents)
-		if reason != "" && !(intent.Slam && reason == lanes.RejectDuplicate) {
-			telemetry.Infof("[RISK-LIMIT] %s — %s (score %d-%d)",
-				matchLabel, reason, intent.HomeScore, intent.AwayScore)
+		intent.Count = s.sizer.size(lane, intent, spent, fillPrice(gc, gcOK, intent))
+		if limit := lane.MaxGameContracts(); gcOK && limit > 0 {
+			intent.Count = min(intent.Count, max(limit-contracts, 0))
+		}
+		if intent.Count == 0 {
+			telemetry.Debugf("[SIZING] %s — no stake for %s %s @ %.0f¢ (model %.1f%%, %d contracts held)",
+				matchLabel, intent.Ticker, intent.Side, intent.LimitPct, intent.ModelPct, contracts)
 			continue
 		}
-
-		lane.RecordOrder(intent.Ticker, intent.Side, intent.HomeScore, intent.AwayScore, orderCents)
+		orderCents, ok := approve(lane, gc, gcOK, intent, spent, contracts, false, matchLabel)
+		if !ok {
+			continue
+		}
+		batchCents += orderCents
+		batchContracts += intent.Count
 		approved = append(approved, intent)
+		depth = append(depth, bookDepth(gc, gcOK, intent))
 	}
 
 	if len(approved) == 0 {
 		return nil
 	}
+	s.persistKeys(approved)
 
 	ttlSec := s.router.OrderTTL(approved[0].Sport)
-	go s.placeBatchOrder(approved, evt.Timestamp, ttlSec)
+	go s.placeBatchOrder(approved, depth, evt.Timestamp, ttlSec)
+	return nil
+}
+
+// approve runs one sized intent through the per-game caps, the market-lead
+// hold and the lane's checks, and records it against the lane when it
+// passes. spent and contracts are the game's exposure and contracts held,
+// including orders approved earlier in the batch. Slams skip the dedup
+// check, and so does an order re-placed after a rejected overturn, which
+// still holds its key from the first time. Slams also skip the market-lead
+// hold and the market checks (see Lane.PreTrade), since they are priced
+// off the settled result; the caps and breakers apply to them as to any
+// other order.
+// Intents priced under 1¢ cannot be placed and are never approved.
+func approve(lane *lanes.Lane, gc *game.GameContext, gcOK bool, intent events.OrderIntent, spent, contracts int, replaced bool, matchLabel string) (int, bool) {
+	if math.Floor(intent.LimitPct) < 1 {
+		telemetry.Debugf("[EXEC] skipping %s %s — limitPct %.1f → price <1¢", intent.Ticker, intent.Side, intent.LimitPct)
+		return 0, false
+	}
+	orderCents := intentCents(intent)
+
+	if gcOK && lane.MaxGameCents() > 0 {
+		if spent+orderCents > lane.MaxGameCents() {
+			telemetry.Infof("[RISK-LIMIT] %s — per-game cap (%d/%d¢ spent)",
+				matchLabel, spent, lane.MaxGameCents())
+			return 0, false
+		}
+	}
+	if limit := lane.MaxGameContracts(); gcOK && limit > 0 && contracts+intent.Count > limit {
+		telemetry.Infof("[RISK-LIMIT] %s — per-game contract cap (%d+%d/%d contracts)",
+			matchLabel, contracts, intent.Count, limit)
+		return 0, false
+	}
+
+	if gcOK && !intent.Slam && time.Now().Before(gc.HeldUntil) {
+		telemetry.Infof("[RISK-LIMIT] %s — held, market leading the feed (score %d-%d)",
+			matchLabel, intent.HomeScore, intent.AwayScore)
+		return 0, false
+	}
+
+	q := quoteFor(gc, gcOK, intent)
+	reason := lane.Check(intent.Ticker, intent.Side, intent.HomeScore, intent.AwayScore, orderCents, q)
+	switch {
+	case reason != lanes.RejectDuplicate:
+	case intent.Slam, replaced:
+		reason = lane.CheckHeld(orderCents, q)
+	}
+	if reason != "" {
+		telemetry.Infof("[RISK-LIMIT] %s — %s (score %d-%d)",
+			matchLabel, reason, intent.HomeScore, intent.AwayScore)
+		return 0, false
+	}
+
+	lane.RecordOrder(intent.Ticker, intent.Side, intent.HomeScore, intent.AwayScore, orderCents)
+	return orderCents, true
+}
+
+// onFill and onOrderUpdate run on the Kalshi WS read goroutine. Orders
+// this process did not place are ignored by the ledger.
+func (s *Service) onFill(evt events.Event) error {
+	if fe, ok := evt.Payload.(events.FillEvent); ok {
+		s.fills.onFill(fe)
+	}
 	return nil
 }
 
-func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedAt time.Time, ttlSec int) {
+func (s *Service) onOrderUpdate(evt events.Event) error {
+	if ou, ok := evt.Payload.(events.OrderUpdateEvent); ok {
+		s.fills.onOrderUpdate(ou)
+	}
+	return nil
+}
+
+// bookDepth returns the contracts available at or below the intent's limit
+// from the game's depth book, or -1 when no valid book is held. Runs on
+// the game's goroutine (onOrderIntent is invoked synchronously from it).
+func bookDepth(gc *game.GameContext, gcOK bool, intent events.OrderIntent) int {
+	if !gcOK {
+		return -1
+	}
+	book := gc.Books[intent.Ticker]
+	if book == nil || !book.Valid {
+		return -1
+	}
+	return book.AvailableAtOrBelow(intent.Side, int(math.Floor(intent.LimitPct)))
+}
+
+// quoteFor snapshots the market state the lane's pre-trade checks need.
+// Runs on the game's goroutine.
+func quoteFor(gc *game.GameContext, gcOK bool, intent events.OrderIntent) lanes.Quote {
+	q := lanes.Quote{
+		Ticker:     intent.Ticker,
+		Side:       intent.Side,
+		LimitCents: int(math.Floor(intent.LimitPct)),
+		Slam:       intent.Slam,
+	}
+	if !gcOK {
+		return q
+	}
+	q.Connected = gc.KalshiConnected
+	if td := gc.Tickers[intent.Ticker]; td != nil && !td.UpdatedAt.IsZero() {
+		q.HasPrice = true
+		q.Age = time.Since(td.UpdatedAt)
+		q.YesBid = td.YesBid
+		q.YesAsk = td.YesAsk
+	}
+	return q
+}
+
+func (s *Service) placeBatchOrder(intents []events.OrderIntent, depth []int, webhookReceivedAt time.Time, ttlSec int) {
+	if s.halted.Load() {
+		telemetry.Warnf("[KILL] dropping %d-order batch — trading halted", len(intents))
+		s.refund(intents)
+		return
+	}
+
 	homeTeam, awayTeam := "?", "?"
 	gc, gcOK := s.gameStore.Get(intents[0].Sport, intents[0].GameID)
 	if gcOK {
@@ -150,34 +299,32 @@ func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedA
 		nameWidth = len(awayTeam)
 	}
 
-	var reqs []kalshi_http.CreateOrderRequest
-	var kept []events.OrderIntent
+	groupID := ""
+	if gcOK && slices.ContainsFunc(intents, func(i events.OrderIntent) bool { return !i.Slam }) {
+		groupID = s.groups.idFor(context.Background(), gc, s.router.Route(gc.Sport, gc.League))
+	}
+
+	reqs := make([]kalshi_http.CreateOrderRequest, 0, len(intents))
 	for _, intent := range intents {
-		priceCents := math.Floor(intent.LimitPct)
-		if strings.HasPrefix(intent.EID, "MOCK-") {
-			priceCents = 1
-		}
-		if priceCents < 1 {
-			telemetry.Debugf("[EXEC] skipping %s %s — limitPct %.1f → price <1¢", intent.Ticker, intent.Side, intent.LimitPct)
-			continue
-		}
-		if priceCents > 99 {
-			priceCents = 99
-		}
+		priceCents := min(math.Floor(intent.LimitPct), 99)
 
-		s.orderSeq++
-		clientID := s.sessionID + ":" + strconv.FormatInt(s.orderSeq, 36)
+		clientID := s.sessionID + ":" + strconv.FormatInt(s.orderSeq.Add(1), 36)
 		req := kalshi_http.CreateOrderRequest{
 			Ticker:      intent.Ticker,
 			Action:      "buy",
 			Side:        intent.Side,
 			Type:        "limit",
-			CountFP:     "1.00",
+			CountFP:     fmt.Sprintf("%d.00", max(intent.Count, 1)),
 			ClientID:    clientID,
 			TimeInForce: "good_till_canceled",
 		}
 		if !intent.Slam {
-			req.ExpirationTS = time.Now().Add(time.Duration(ttlSec) * time.Second).Unix()
+			req.OrderGroupID = groupID
+			ttl := ttlSec
+			if intent.TTLSeconds > 0 {
+				ttl = intent.TTLSeconds
+			}
+			req.ExpirationTS = time.Now().Add(time.Duration(ttl) * time.Second).Unix()
 		}
 		priceDollars := fmt.Sprintf("%.2f", priceCents/100.0)
 		if intent.Side == "yes" {
@@ -186,14 +333,8 @@ func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedA
 			req.NoPriceDollars = priceDollars
 		}
 		reqs = append(reqs, req)
-		kept = append(kept, intent)
-	}
-	intents = kept
-
-	if len(reqs) == 0 {
-		telemetry.Debugf("[EXEC] all orders skipped — no viable prices")
-		return
 	}
+	s.persistSession(intents[0].Sport)
 
 	if !webhookReceivedAt.IsZero() {
 		telemetry.Metrics.OrderE2ELatency.Record(time.Since(webhookReceivedAt))
@@ -219,9 +360,13 @@ func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedA
 		if intent.Slam {
 			label = "[SLAM]"
 		}
-		fmt.Fprintf(&ob, "%s%s %-*s  %-3s  1 contracts @ %d¢\n",
+		depthLabel := ""
+		if depth[i] >= 0 {
+			depthLabel = fmt.Sprintf("  (depth %d)", depth[i])
+		}
+		fmt.Fprintf(&ob, "%s%s %-*s  %-3s  %d contracts @ %d¢%s\n",
 			prefix, label, nameWidth, teamFor(intent.Outcome),
-			strings.ToUpper(intent.Side), cents)
+			strings.ToUpper(intent.Side), max(intent.Count, 1), cents, depthLabel)
 	}
 	fmt.Fprint(os.Stderr, ob.String())
 
@@ -230,6 +375,7 @@ func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedA
 	})
 	if err != nil {
 		telemetry.Errorf("[RESPONSE] batch FAILED: %v", err)
+		s.refund(intents)
 		return
 	}
 
@@ -240,8 +386,12 @@ func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedA
 
 	if len(resp.Orders) == 0 {
 		fmt.Fprintf(os.Stderr, "%s[RESPONSE] empty — Kalshi returned 0 order results\n", tsPrefix)
+		s.refund(intents)
 		return
 	}
+	if len(resp.Orders) < len(intents) {
+		s.refund(intents[len(resp.Orders):])
+	}
 
 	var rb strings.Builder
 	for i, r := range resp.Orders {
@@ -260,11 +410,16 @@ func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedA
 		if r.Error != nil {
 			fmt.Fprintf(&rb, "%s[RESPONSE] %-*s  %-3s  REJECTED: %s\n",
 				prefix, nameWidth, name, side, r.Error.Message)
+			s.refund(intents[i : i+1])
+			if gcOK && reqs[i].OrderGroupID != "" && groupLimitHit(r.Error.Code, r.Error.Message) {
+				s.groups.triggered(gc, reqs[i].OrderGroupID)
+			}
 			continue
 		}
 		if r.Order == nil {
 			fmt.Fprintf(&rb, "%s[RESPONSE] %-*s  %-3s  (nil order, nil error)\n",
 				prefix, nameWidth, name, side)
+			s.refund(intents[i : i+1])
 			continue
 		}
 
@@ -276,25 +431,46 @@ func (s *Service) placeBatchOrder(intents []events.OrderIntent, webhookReceivedA
 		avg := float64(fillCost+fees) / max(float64(o.FillCount), 1)
 		fmt.Fprintf(&rb, "%s[RESPONSE] %-*s  %-3s  [%d/%d] @ %.2f¢ avg\n",
 			prefix, nameWidth, name, side, o.FillCount, total, avg)
+	}
+	fmt.Fprint(os.Stderr, rb.String())
 
-		if !gcOK {
+	if !gcOK {
+		return
+	}
+	s.tracker.RecordBatch(gc, intents, resp.Orders, ttlSec)
+
+	// Hand each accepted order to the fill ledger after the tracker row
+	// exists, so pushed fills always have a row to update.
+	for i, r := range resp.Orders {
+		if i >= len(intents) || r.Error != nil || r.Order == nil {
 			continue
 		}
-		orderID := o.OrderID
-		costCents := fillCost + fees
-		gc.Send(func() {
-			gc.RecordFill(game.Fill{
-				OrderID:   orderID,
-				Ticker:    intent.Ticker,
-				Side:      intent.Side,
-				CostCents: costCents,
-			})
-		})
+		intent := intents[i]
+		o := r.Order
+		s.fills.track(o.OrderID, &trackedOrder{
+			gc:            gc,
+			lane:          s.router.Route(intent.Sport, intent.League),
+			ticker:        intent.Ticker,
+			side:          intent.Side,
+			clientID:      reqs[i].ClientID,
+			intent:        intent,
+			limitCents:    int(math.Floor(intent.LimitPct)),
+			reservedCents: intentCents(intent),
+			total:         o.FillCount + o.RemainingCount,
+			filled:        o.FillCount,
+			costCents:     o.TakerFillCost + o.MakerFillCost + o.TakerFees + o.MakerFees,
+		}, o.Status == "executed" || o.Status == "canceled")
 	}
-	fmt.Fprint(os.Stderr, rb.String())
+}
 
-	if s.tracker != nil && gcOK {
-		s.tracker.RecordBatch(gc, intents, resp.Orders, ttlSec)
+// refund returns the spend reserved at approval for intents that never
+// became resting orders (rejected, or a failed batch). Orders
+// that were accepted have their reservation settled by the fill ledger.
+func (s *Service) refund(intents []events.OrderIntent) {
+	for _, intent := range intents {
+		if lane := s.router.Route(intent.Sport, intent.League); lane != nil {
+			lane.AdjustSpend(-intentCents(intent))
+		}
 	}
 }
 
@@ -0,0 +1,71 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestApproveSlam(t *testing.T) {
+	tests := []struct {
+		name  string
+		slam  bool
+		seen  bool // the dedup key is already recorded
+		held  bool // the market is leading the feed
+		trip  bool
+		stale bool
+		want  bool
+	}{
+		{name: "order on a live book", want: true},
+		{name: "order on a quiet book", stale: true, want: false},
+		{name: "slam on a quiet book", slam: true, stale: true, want: true},
+		{name: "order while held", held: true, want: false},
+		{name: "slam while held", slam: true, held: true, want: true},
+		{name: "repeated order", seen: true, want: false},
+		{name: "repeated slam", slam: true, seen: true, want: true},
+		{name: "slam with a tripped breaker", slam: true, trip: true, want: false},
+		{name: "repeated slam with a tripped breaker", slam: true, seen: true, trip: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lane := lanes.NewLane(0, 1_000_000)
+			lane.AddCheck(lanes.RequireConnected())
+			lane.AddCheck(lanes.MaxQuoteAge(5 * time.Second))
+			b := lanes.NewBreaker()
+			lane.AddBreaker(b)
+			if tt.trip {
+				b.Trip("test")
+			}
+
+			intent := events.OrderIntent{
+				Sport: events.SportHockey, League: "NHL", EID: "1",
+				Ticker: "BOS", Side: "yes", LimitPct: 95, Count: 1,
+				HomeScore: 2, AwayScore: 1, Slam: tt.slam,
+			}
+			if tt.seen {
+				lane.RecordOrder(intent.Ticker, intent.Side, intent.HomeScore, intent.AwayScore, 95)
+			}
+
+			gc := newTestGame(t)
+			var ok bool
+			onGame(gc, func() {
+				updated := time.Now()
+				if tt.stale {
+					updated = updated.Add(-time.Minute)
+				}
+				gc.KalshiConnected = true
+				gc.Tickers["BOS"] = &game.TickerData{Ticker: "BOS", YesBid: 90, YesAsk: 94, UpdatedAt: updated}
+				if tt.held {
+					gc.HeldUntil = time.Now().Add(time.Minute)
+				}
+				_, ok = approve(lane, gc, true, intent, 0, 0, false, "BOS vs TOR")
+			})
+			if ok != tt.want {
+				t.Errorf("approved = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package execution
+
+import "math"
+
+// Kalshi fee schedule: fee = ceil(rate * count * P * (1 - P)) dollars,
+// rounded up to the next cent. Makers pay a quarter of the taker rate.
+const (
+	TakerFeeRate = 0.07
+	MakerFeeRate = 0.0175
+)
+
+// FeeCents returns the Kalshi trading fee in cents for count contracts
+// filled at priceCents.
+func FeeCents(isTaker bool, count, priceCents int) int {
+	rate := MakerFeeRate
+	if isTaker {
+		rate = TakerFeeRate
+	}
+	p := float64(priceCents) / 100.0
+	return int(math.Ceil(rate * float64(count) * p * (1 - p) * 100))
+}
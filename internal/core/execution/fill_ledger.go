@@ -0,0 +1,399 @@
+package execution
+
+import (
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/core/state/trading"
+	"github.com/charleschow/hft-trading/internal/core/tracking"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// earlyFillTTL bounds how long a fill for an unknown order is held. A fill
+// can beat the batch HTTP response; anything older than this belongs to
+// another process sharing the account.
+const earlyFillTTL = 30 * time.Second
+
+// fillLedger follows every order this process placed from the batch
+// response until it is executed or canceled, folding in pushed fills and
+// order updates from the Kalshi WS user channels.
+//
+// Each change is folded into the order's running state under mu, which
+// yields a ledgerUpdate. The update is applied to the game, the lane and
+// the tracking store after mu is released: most changes arrive on the WS
+// read goroutine, which must not wait on a busy game or the disk.
+type fillLedger struct {
+	writes *fillWriter
+
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+	early  map[string][]earlyFill
+}
+
+type earlyFill struct {
+	fill events.FillEvent
+	at   time.Time
+}
+
+// trackedOrder is the running state of one placed order. filled and
+// costCents (fill cost + fees) are cumulative; chargedCents is what the
+// lane currently holds against this order.
+type trackedOrder struct {
+	gc       *game.GameContext
+	lane     *lanes.Lane
+	ticker   string
+	side     string
+	clientID string
+
+	// intent is the strategy intent the order was placed for; zero for
+	// orders restored by Reconcile.
+	intent     events.OrderIntent
+	limitCents int
+
+	reservedCents int
+	chargedCents  int
+
+	total     int
+	filled    int
+	costCents int
+
+	// wsFilled counts contracts reported by the fill channel. Fills that
+	// were already in the HTTP response are not re-counted: only the part
+	// of wsFilled beyond filled adds new contracts.
+	wsFilled int
+	trades   map[string]bool
+
+	// version numbers this order's updates under mu; gameVersion is the
+	// newest one applied to the game, and is only touched on the game's
+	// goroutine, so a retried update never overwrites a newer one.
+	version     int64
+	gameVersion int64
+}
+
+func newFillLedger(tracker *tracking.Tracker) *fillLedger {
+	l := &fillLedger{
+		writes: newFillWriter(tracker),
+		orders: make(map[string]*trackedOrder),
+		early:  make(map[string][]earlyFill),
+	}
+	go l.writes.run()
+	return l
+}
+
+// track starts following an order from its batch response. Fills that
+// arrived before the response are replayed.
+func (l *fillLedger) track(orderID string, o *trackedOrder, terminal bool) {
+	l.mu.Lock()
+	o.trades = make(map[string]bool)
+	o.chargedCents = o.reservedCents
+	l.orders[orderID] = o
+
+	for _, ef := range l.early[orderID] {
+		l.addFill(orderID, o, ef.fill)
+	}
+	delete(l.early, orderID)
+
+	u := l.publish(orderID, o, terminal || o.filled >= o.total)
+	l.mu.Unlock()
+
+	l.apply(u)
+}
+
+// onFill folds a fill-channel trade into its order.
+func (l *fillLedger) onFill(fe events.FillEvent) {
+	l.mu.Lock()
+	o, ok := l.orders[fe.OrderID]
+	if !ok {
+		l.holdEarly(fe)
+		l.mu.Unlock()
+		return
+	}
+	var u *ledgerUpdate
+	if l.addFill(fe.OrderID, o, fe) {
+		u = l.publish(fe.OrderID, o, o.filled >= o.total)
+	}
+	l.mu.Unlock()
+
+	l.apply(u)
+}
+
+// onOrderUpdate replaces an order's running totals with the exchange's
+// cumulative figures. A stale update can trail fills already counted from
+// the fill channel: it is dropped, or if terminal it only finalizes the
+// order, keeping the larger counts.
+func (l *fillLedger) onOrderUpdate(ou events.OrderUpdateEvent) {
+	l.mu.Lock()
+	o, ok := l.orders[ou.OrderID]
+	if !ok || (ou.FillCount < o.filled && !ou.Terminal()) {
+		l.mu.Unlock()
+		return
+	}
+	if ou.FillCount >= o.filled {
+		o.filled = ou.FillCount
+		o.costCents = ou.FillCostCents + ou.FeesCents
+	}
+	if t := ou.FillCount + ou.RemainingCount; t > 0 {
+		o.total = max(t, o.filled)
+	}
+	u := l.publish(ou.OrderID, o, ou.Terminal())
+	l.mu.Unlock()
+
+	l.apply(u)
+}
+
+// reprice records an order's new limit after an amend and re-reserves
+// its full size at that price.
+func (l *fillLedger) reprice(orderID string, limitCents int) {
+	l.mu.Lock()
+	o, ok := l.orders[orderID]
+	if !ok {
+		l.mu.Unlock()
+		return
+	}
+	o.limitCents = limitCents
+	o.reservedCents = limitCents * o.total
+	u := l.publish(orderID, o, false)
+	l.mu.Unlock()
+
+	l.apply(u)
+}
+
+// openOrder is a snapshot of an order that can still fill.
+type openOrder struct {
+	orderID    string
+	ticker     string
+	side       string
+	intent     events.OrderIntent
+	limitCents int
+	filled     int
+	remaining  int
+}
+
+// open returns every order on gc that can still fill, or every such
+// order on any game when gc is nil.
+func (l *fillLedger) open(gc *game.GameContext) []openOrder {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []openOrder
+	for id, o := range l.orders {
+		if (gc != nil && o.gc != gc) || o.filled >= o.total {
+			continue
+		}
+		out = append(out, openOrder{
+			orderID:    id,
+			ticker:     o.ticker,
+			side:       o.side,
+			intent:     o.intent,
+			limitCents: o.limitCents,
+			filled:     o.filled,
+			remaining:  o.total - o.filled,
+		})
+	}
+	return out
+}
+
+// addFill applies one trade. Returns false when it added nothing new.
+// Caller must hold mu.
+func (l *fillLedger) addFill(orderID string, o *trackedOrder, fe events.FillEvent) bool {
+	if fe.TradeID != "" {
+		if o.trades[fe.TradeID] {
+			return false
+		}
+		o.trades[fe.TradeID] = true
+	}
+	o.wsFilled += fe.Count
+	n := o.wsFilled - o.filled
+	if n <= 0 {
+		return false
+	}
+	if n > fe.Count {
+		n = fe.Count
+	}
+	o.filled += n
+	o.costCents += n*fe.PriceCents + FeeCents(fe.IsTaker, n, fe.PriceCents)
+	return true
+}
+
+// holdEarly parks a fill for an order whose batch response has not been
+// processed yet, and drops anything older than earlyFillTTL.
+// Caller must hold mu.
+func (l *fillLedger) holdEarly(fe events.FillEvent) {
+	now := time.Now()
+	for id, fills := range l.early {
+		if now.Sub(fills[0].at) > earlyFillTTL {
+			delete(l.early, id)
+		}
+	}
+	l.early[fe.OrderID] = append(l.early[fe.OrderID], earlyFill{fill: fe, at: now})
+}
+
+// ledgerUpdate is one order's new state, built under mu by publish and
+// applied by apply once mu is released.
+type ledgerUpdate struct {
+	order      *trackedOrder
+	version    int64
+	fill       game.Fill
+	open       trading.OpenOrder
+	final      bool
+	spendDelta int
+	write      fillWrite
+}
+
+// publish snapshots an order's current state for the game, the tracking
+// store and the lane. The lane holds the larger of the reservation and the
+// real cost while the order is open, and exactly the real cost once it is
+// final. Caller must hold mu.
+func (l *fillLedger) publish(orderID string, o *trackedOrder, final bool) *ledgerUpdate {
+	o.version++
+	u := &ledgerUpdate{
+		order:   o,
+		version: o.version,
+		fill: game.Fill{
+			OrderID:   orderID,
+			Ticker:    o.ticker,
+			Side:      o.side,
+			Count:     o.filled,
+			CostCents: o.costCents,
+		},
+		open: trading.OpenOrder{
+			OrderID:       orderID,
+			ClientOrderID: o.clientID,
+			Ticker:        o.ticker,
+			Side:          o.side,
+			Count:         o.total,
+			Filled:        o.filled,
+			Price:         o.limitCents,
+			Status:        trading.StatusOpen,
+		},
+		final: final,
+		write: fillWrite{
+			version:    o.version,
+			costCents:  o.costCents,
+			fillCount:  o.filled,
+			totalCount: o.total,
+			final:      final,
+		},
+	}
+
+	charge := max(o.reservedCents, o.costCents)
+	if final {
+		charge = o.costCents
+		delete(l.orders, orderID)
+		telemetry.Debugf("[EXEC] order %s final: %d/%d filled, cost %d¢ (reserved %d¢)",
+			orderID, o.filled, o.total, o.costCents, o.reservedCents)
+	}
+	if o.lane != nil && charge != o.chargedCents {
+		u.spendDelta = charge - o.chargedCents
+		o.chargedCents = charge
+	}
+	return u
+}
+
+// apply pushes an update built by publish to the lane, the game and the
+// tracking store. Must not be called with mu held.
+func (l *fillLedger) apply(u *ledgerUpdate) {
+	if u == nil {
+		return
+	}
+	o := u.order
+	if u.spendDelta != 0 {
+		o.lane.AdjustSpend(u.spendDelta)
+	}
+	l.writes.enqueue(u.fill.OrderID, u.write)
+
+	gc := o.gc
+	fn := func() {
+		if u.version <= o.gameVersion {
+			return
+		}
+		o.gameVersion = u.version
+		gc.RecordFill(u.fill)
+		if u.final {
+			gc.Orders.RemoveOrder(u.fill.OrderID)
+			return
+		}
+		// Keep the status of an order with an amend in flight.
+		oo := u.open
+		if cur, ok := gc.Orders.GetOrder(oo.OrderID); ok {
+			oo.Status = cur.Status
+		}
+		gc.Orders.TrackOrder(&oo)
+	}
+	// Fills feed the game's exposure, so a full inbox is waited out on
+	// another goroutine rather than dropped. The version check lets the
+	// update land late.
+	if !gc.TrySend(fn) {
+		go gc.SendWait(fn)
+	}
+}
+
+// fillWrite is an order's cumulative fill state for the tracking store.
+type fillWrite struct {
+	version    int64
+	costCents  int
+	fillCount  int
+	totalCount int
+	final      bool
+}
+
+// fillWriter writes fill state to the tracking store on its own goroutine.
+// Writes are coalesced per order, newest version wins, so a burst of fills
+// costs one row update and the queue never blocks the caller.
+type fillWriter struct {
+	tracker *tracking.Tracker
+
+	mu      sync.Mutex
+	pending map[string]fillWrite
+	wake    chan struct{}
+
+	// written is the newest version stored per open order; only run
+	// touches it.
+	written map[string]int64
+}
+
+func newFillWriter(tracker *tracking.Tracker) *fillWriter {
+	return &fillWriter{
+		tracker: tracker,
+		pending: make(map[string]fillWrite),
+		wake:    make(chan struct{}, 1),
+		written: make(map[string]int64),
+	}
+}
+
+func (w *fillWriter) enqueue(orderID string, fw fillWrite) {
+	w.mu.Lock()
+	if cur, ok := w.pending[orderID]; !ok || fw.version > cur.version {
+		w.pending[orderID] = fw
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *fillWriter) run() {
+	for range w.wake {
+		w.mu.Lock()
+		batch := w.pending
+		w.pending = make(map[string]fillWrite)
+		w.mu.Unlock()
+
+		for orderID, fw := range batch {
+			if fw.version <= w.written[orderID] {
+				continue
+			}
+			w.tracker.UpdateFill(orderID, fw.costCents, fw.fillCount, fw.totalCount, fw.final)
+			if fw.final {
+				delete(w.written, orderID)
+			} else {
+				w.written[orderID] = fw.version
+			}
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package execution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+// newTestGame returns a running hockey game with EID "1". It is left open:
+// order goroutines may still send to it after the test.
+func newTestGame(t *testing.T) *game.GameContext {
+	t.Helper()
+	return game.NewGameContext(events.SportHockey, "NHL", "1", hockeyState.New("1", "NHL", "Boston", "Toronto"))
+}
+
+// onGame runs fn on gc's goroutine and waits for it, after everything
+// already queued.
+func onGame(gc *game.GameContext, fn func()) {
+	done := make(chan struct{})
+	gc.SendWait(func() {
+		fn()
+		close(done)
+	})
+	<-done
+}
+
+func TestFillLedger(t *testing.T) {
+	fill := func(trade string, n, price int) events.FillEvent {
+		return events.FillEvent{TradeID: trade, OrderID: "o1", Count: n, PriceCents: price, IsTaker: true}
+	}
+	update := func(status string, filled, remaining, cost, fees int) events.OrderUpdateEvent {
+		return events.OrderUpdateEvent{OrderID: "o1", Status: status, FillCount: filled, RemainingCount: remaining, FillCostCents: cost, FeesCents: fees}
+	}
+	cost := func(n, price int) int { return n*price + FeeCents(true, n, price) }
+
+	tests := []struct {
+		name        string
+		early       []events.FillEvent // before the batch response
+		filled      int                // in the batch response
+		costCents   int
+		steps       []any
+		wantFilled  int
+		wantCost    int
+		wantOpen    bool
+		wantCharged int
+	}{
+		{
+			name:        "fill adds contracts",
+			steps:       []any{fill("t1", 3, 40)},
+			wantFilled:  3,
+			wantCost:    cost(3, 40),
+			wantOpen:    true,
+			wantCharged: 400,
+		},
+		{
+			name:        "duplicate trade ignored",
+			steps:       []any{fill("t1", 3, 40), fill("t1", 3, 40)},
+			wantFilled:  3,
+			wantCost:    cost(3, 40),
+			wantOpen:    true,
+			wantCharged: 400,
+		},
+		{
+			name:        "fill already in the response not recounted",
+			filled:      3,
+			costCents:   cost(3, 40),
+			steps:       []any{fill("t1", 3, 40), fill("t2", 2, 40)},
+			wantFilled:  5,
+			wantCost:    cost(3, 40) + cost(2, 40),
+			wantOpen:    true,
+			wantCharged: 400,
+		},
+		{
+			name:        "early fill replayed",
+			early:       []events.FillEvent{fill("t1", 4, 40)},
+			wantFilled:  4,
+			wantCost:    cost(4, 40),
+			wantOpen:    true,
+			wantCharged: 400,
+		},
+		{
+			name:        "update replaces the totals",
+			steps:       []any{fill("t1", 3, 40), update("resting", 4, 6, 160, 3)},
+			wantFilled:  4,
+			wantCost:    163,
+			wantOpen:    true,
+			wantCharged: 400,
+		},
+		{
+			name:        "stale update ignored",
+			steps:       []any{fill("t1", 5, 40), update("resting", 3, 7, 120, 2)},
+			wantFilled:  5,
+			wantCost:    cost(5, 40),
+			wantOpen:    true,
+			wantCharged: 400,
+		},
+		{
+			name:        "stale terminal update keeps the larger fill",
+			steps:       []any{fill("t1", 5, 40), update("canceled", 3, 0, 120, 2)},
+			wantFilled:  5,
+			wantCost:    cost(5, 40),
+			wantCharged: cost(5, 40),
+		},
+		{
+			name:        "cancel settles at the real cost",
+			steps:       []any{fill("t1", 2, 40), update("canceled", 2, 0, 80, 2)},
+			wantFilled:  2,
+			wantCost:    82,
+			wantCharged: 82,
+		},
+		{
+			name:        "filled by the fill channel is final",
+			steps:       []any{fill("t1", 6, 40), fill("t2", 4, 40)},
+			wantFilled:  10,
+			wantCost:    cost(6, 40) + cost(4, 40),
+			wantCharged: cost(6, 40) + cost(4, 40),
+		},
+		{
+			name:        "cost above the reservation is charged",
+			steps:       []any{fill("t1", 9, 45)},
+			wantFilled:  9,
+			wantCost:    cost(9, 45),
+			wantOpen:    true,
+			wantCharged: cost(9, 45),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gc := newTestGame(t)
+			lane := lanes.NewLane(0, 1_000_000)
+			lane.AdjustSpend(400)
+			l := newFillLedger(nil)
+
+			for _, fe := range tt.early {
+				l.onFill(fe)
+			}
+			l.track("o1", &trackedOrder{
+				gc: gc, lane: lane, ticker: "BOS", side: "yes",
+				limitCents: 40, reservedCents: 400,
+				total: 10, filled: tt.filled, costCents: tt.costCents,
+			}, false)
+			for _, step := range tt.steps {
+				switch s := step.(type) {
+				case events.FillEvent:
+					l.onFill(s)
+				case events.OrderUpdateEvent:
+					l.onOrderUpdate(s)
+				}
+			}
+
+			var f game.Fill
+			var open bool
+			onGame(gc, func() {
+				f = gc.Fills[0]
+				_, open = gc.Orders.GetOrder("o1")
+			})
+			if f.Count != tt.wantFilled || f.CostCents != tt.wantCost {
+				t.Errorf("game fill %d for %d¢, want %d for %d¢", f.Count, f.CostCents, tt.wantFilled, tt.wantCost)
+			}
+			if open != tt.wantOpen || len(l.open(gc)) == 1 != tt.wantOpen {
+				t.Errorf("open = %v, want %v", open, tt.wantOpen)
+			}
+			if got := lane.SportSpent(); got != tt.wantCharged {
+				t.Errorf("lane spent %d¢, want %d¢", got, tt.wantCharged)
+			}
+		})
+	}
+}
+
+func TestFillLedgerAppliesNewestVersion(t *testing.T) {
+	gc := newTestGame(t)
+	l := newFillLedger(nil)
+	o := &trackedOrder{gc: gc, ticker: "BOS", side: "yes", total: 10}
+	l.track("o1", o, false)
+
+	l.mu.Lock()
+	o.filled, o.costCents = 2, 80
+	older := l.publish("o1", o, false)
+	o.filled, o.costCents = 5, 200
+	newer := l.publish("o1", o, false)
+	l.mu.Unlock()
+
+	l.apply(newer)
+	l.apply(older)
+
+	var f game.Fill
+	onGame(gc, func() { f = gc.Fills[0] })
+	if f.Count != 5 || f.CostCents != 200 {
+		t.Errorf("game fill %d for %d¢, want the newer 5 for 200¢", f.Count, f.CostCents)
+	}
+}
+
+func TestFillLedgerWaitsOutAFullInbox(t *testing.T) {
+	gc := newTestGame(t)
+	l := newFillLedger(nil)
+	o := &trackedOrder{gc: gc, ticker: "BOS", side: "yes", total: 10}
+
+	block := make(chan struct{})
+	gc.SendWait(func() { <-block })
+	for gc.TrySend(func() {}) {
+	}
+	l.track("o1", o, false)
+	l.onFill(events.FillEvent{TradeID: "t1", OrderID: "o1", Count: 3, PriceCents: 40})
+	close(block)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		var n int
+		onGame(gc, func() {
+			if len(gc.Fills) > 0 {
+				n = gc.Fills[0].Count
+			}
+		})
+		if n == 3 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("game fill %d, want 3 once the inbox drained", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
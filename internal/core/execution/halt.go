@@ -0,0 +1,59 @@
+package execution
+
+import (
+	"context"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// haltReadTimeout bounds the wait for each game's exposure read.
+const haltReadTimeout = 2 * time.Second
+
+// Halt stops the service approving or placing any further order, cancels
+// every order it is following and logs the exposure left on its games.
+// Only the first call acts.
+func (s *Service) Halt(h events.HaltEvent) {
+	if !s.halted.CompareAndSwap(false, true) {
+		return
+	}
+	go s.flatten()
+}
+
+// Halted reports whether Halt has been called.
+func (s *Service) Halted() bool {
+	return s.halted.Load()
+}
+
+func (s *Service) flatten() {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+	defer cancel()
+
+	orders := s.fills.open(nil)
+	n := 0
+	for _, o := range orders {
+		if err := s.client.CancelOrder(ctx, o.orderID); err != nil {
+			telemetry.Warnf("[KILL] cancel %s %s failed: %v", o.ticker, o.orderID, err)
+			continue
+		}
+		n++
+	}
+	telemetry.Infof("[KILL] %d/%d open orders canceled", n, len(orders))
+
+	total, games := 0, 0
+	for _, gc := range s.gameStore.All() {
+		ch := make(chan int, 1)
+		gc.Send(func() { ch <- gc.TotalExposureCents() })
+		select {
+		case cents := <-ch:
+			if cents > 0 {
+				total += cents
+				games++
+			}
+		case <-time.After(haltReadTimeout):
+			telemetry.Warnf("[KILL] timeout reading exposure for %s", gc.EID)
+		}
+	}
+	telemetry.Infof("[KILL] exposure at halt $%.2f across %d games", float64(total)/100.0, games)
+}
@@ -0,0 +1,73 @@
+package execution
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestHalt(t *testing.T) {
+	tests := []struct {
+		name      string
+		open      []string // tracked orders that can still fill
+		filled    []string // tracked orders already filled
+		cancelErr error
+		want      []string
+	}{
+		{name: "cancels every open order", open: []string{"o1", "o2"}, filled: []string{"o3"}, want: []string{"o1", "o2"}},
+		{name: "nothing open"},
+		{name: "failed cancels still halt", open: []string{"o1"}, cancelErr: errors.New("503")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lane := lanes.NewLane(0, 1_000_000)
+			x := newFakeExchange()
+			x.cancelErr = tt.cancelErr
+			s := newTestService(x, lane)
+			gc := newTestGame(t)
+			s.gameStore.Put(gc)
+			for _, id := range tt.open {
+				s.fills.track(id, &trackedOrder{gc: gc, lane: lane, ticker: "BOS", side: "yes", limitCents: 50, total: 2}, false)
+			}
+			for _, id := range tt.filled {
+				s.fills.track(id, &trackedOrder{gc: gc, lane: lane, ticker: "BOS", side: "yes", limitCents: 50, total: 2, filled: 2}, true)
+			}
+
+			s.Halt(events.HaltEvent{Source: "test"})
+			s.Halt(events.HaltEvent{Source: "again"})
+			if !s.Halted() {
+				t.Fatal("not halted")
+			}
+			if len(tt.want) > 0 {
+				waitFor(t, "the cancels", func() bool {
+					x.mu.Lock()
+					defer x.mu.Unlock()
+					return len(x.canceled) >= len(tt.want)
+				})
+			}
+
+			// Halted services drop new intents outright.
+			intent := events.OrderIntent{
+				Sport: events.SportHockey, League: "NHL", GameID: "1", EID: "1",
+				Ticker: "BOS", Side: "yes", LimitPct: 55, Count: 1,
+			}
+			onGame(gc, func() { s.onOrderIntent(events.Event{Payload: []events.OrderIntent{intent}}) })
+			select {
+			case batch := <-x.placed:
+				t.Errorf("placed %+v after the halt", batch)
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			x.mu.Lock()
+			got := slices.Sorted(slices.Values(x.canceled))
+			x.mu.Unlock()
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("canceled %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package execution
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+
+	_ "modernc.org/sqlite"
+)
+
+// idempotencyMaxAge bounds how long a key survives when its game's finish
+// was never seen (e.g. the process was down when it ended).
+const idempotencyMaxAge = 48 * time.Hour
+
+// IdempotencyStore persists the lanes' dedup keys and each sport's
+// client_order_id session, so a restart mid-game neither re-fires orders
+// for scores already traded nor reuses a client order ID. Keys are
+// dropped when their game finishes.
+//
+// All methods are no-ops on a nil store.
+type IdempotencyStore struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+// dedupKey is one persisted lane idempotency entry.
+type dedupKey struct {
+	Sport   events.Sport
+	League  string
+	GameEID string
+	Ticker  string
+	Key     string
+}
+
+func OpenIdempotencyStore(path string) (*IdempotencyStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create idempotency store dir: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(wal)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(idempotencySchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init idempotency schema: %w", err)
+	}
+
+	cutoff := time.Now().Add(-idempotencyMaxAge).UTC().Format(time.RFC3339)
+	res, _ := db.Exec(`DELETE FROM idempotency_keys WHERE created_at < ?`, cutoff)
+	var pruned int64
+	if res != nil {
+		pruned, _ = res.RowsAffected()
+	}
+
+	telemetry.Plainf("idempotency store: opened %s  pruned=%d", path, pruned)
+	return &IdempotencyStore{db: db}, nil
+}
+
+const idempotencySchema = `
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	sport      TEXT NOT NULL,
+	league     TEXT NOT NULL,
+	game_eid   TEXT NOT NULL,
+	ticker     TEXT NOT NULL,
+	key        TEXT NOT NULL,
+	created_at TEXT NOT NULL,
+	PRIMARY KEY (sport, league, key)
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_game ON idempotency_keys(game_eid);
+CREATE INDEX IF NOT EXISTS idx_idempotency_ticker ON idempotency_keys(ticker);
+
+CREATE TABLE IF NOT EXISTS order_sessions (
+	sport      TEXT PRIMARY KEY,
+	session_id TEXT    NOT NULL,
+	order_seq  INTEGER NOT NULL
+);`
+
+// Session returns the client_order_id session saved for sport, if any.
+func (s *IdempotencyStore) Session(sport events.Sport) (id string, seq int64, ok bool, err error) {
+	if s == nil {
+		return "", 0, false, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = s.db.QueryRow(`SELECT session_id, order_seq FROM order_sessions WHERE sport = ?`,
+		string(sport)).Scan(&id, &seq)
+	if err == sql.ErrNoRows {
+		return "", 0, false, nil
+	}
+	if err != nil {
+		return "", 0, false, err
+	}
+	return id, seq, true, nil
+}
+
+// Keys returns every persisted dedup key for sport.
+func (s *IdempotencyStore) Keys(sport events.Sport) ([]dedupKey, error) {
+	if s == nil {
+		return nil, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.db.Query(`SELECT league, game_eid, ticker, key FROM idempotency_keys WHERE sport = ?`,
+		string(sport))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []dedupKey
+	for rows.Next() {
+		k := dedupKey{Sport: sport}
+		if err := rows.Scan(&k.League, &k.GameEID, &k.Ticker, &k.Key); err != nil {
+			return nil, err
+		}
+		out = append(out, k)
+	}
+	return out, rows.Err()
+}
+
+// Record saves keys and advances the sport's session to seq in one
+// transaction. The saved seq never moves backwards, since batches are
+// placed from concurrent goroutines.
+func (s *IdempotencyStore) Record(sport events.Sport, sessionID string, seq int64, keys []dedupKey) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, k := range keys {
+		if _, err := tx.Exec(
+			`INSERT OR IGNORE INTO idempotency_keys (sport, league, game_eid, ticker, key, created_at)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			string(k.Sport), k.League, k.GameEID, k.Ticker, k.Key, now,
+		); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(
+		`INSERT INTO order_sessions (sport, session_id, order_seq) VALUES (?, ?, ?)
+		 ON CONFLICT(sport) DO UPDATE SET
+			session_id = excluded.session_id,
+			order_seq  = CASE WHEN session_id = excluded.session_id
+			                  THEN MAX(order_seq, excluded.order_seq)
+			                  ELSE excluded.order_seq END`,
+		string(sport), sessionID, seq,
+	); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ClearTicker deletes every key for ticker, mirroring
+// Lane.ClearIdempotencyForTicker after a confirmed overturn.
+func (s *IdempotencyStore) ClearTicker(ticker string) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(`DELETE FROM idempotency_keys WHERE ticker = ?`, ticker)
+	return err
+}
+
+// ExpireGame deletes every key placed for a game.
+func (s *IdempotencyStore) ExpireGame(eid string) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(`DELETE FROM idempotency_keys WHERE game_eid = ?`, eid)
+	return err
+}
+
+// OnGameEvent implements game.GameObserver: keys expire with their game.
+func (s *IdempotencyStore) OnGameEvent(gc *game.GameContext, eventType string) {
+	if s == nil || events.MatchStatus(eventType) != events.StatusGameFinish {
+		return
+	}
+	eid := gc.EID
+	go func() {
+		if err := s.ExpireGame(eid); err != nil {
+			telemetry.Warnf("idempotency store: expire %s failed: %v", eid, err)
+		}
+	}()
+}
+
+func (s *IdempotencyStore) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// UseIdempotencyStore restores sport's dedup keys into the lanes and
+// resumes its client_order_id session from st, then persists every key
+// and session advance from here on. Must run before any intent is
+// approved.
+func (s *Service) UseIdempotencyStore(st *IdempotencyStore, sport events.Sport) error {
+	if id, seq, ok, err := st.Session(sport); err != nil {
+		return fmt.Errorf("load session: %w", err)
+	} else if ok {
+		s.sessionID = id
+		s.orderSeq.Store(seq)
+	}
+
+	keys, err := st.Keys(sport)
+	if err != nil {
+		return fmt.Errorf("load keys: %w", err)
+	}
+	n := 0
+	for _, k := range keys {
+		if lane := s.router.Route(k.Sport, k.League); lane != nil {
+			lane.SeedIdempotency(k.Key)
+			n++
+		}
+	}
+	s.dedup = st
+	telemetry.Infof("[EXEC] restored %d idempotency keys, session %s at seq %d",
+		n, s.sessionID, s.orderSeq.Load())
+	return nil
+}
+
+// persistKeys saves the dedup keys recorded for approved intents. It runs
+// on the game's goroutine right after approval, in order with ClearTicker,
+// so a batch still in flight cannot bring back keys an overturn cleared.
+func (s *Service) persistKeys(intents []events.OrderIntent) {
+	if s.dedup == nil || len(intents) == 0 {
+		return
+	}
+	keys := make([]dedupKey, 0, len(intents))
+	for _, intent := range intents {
+		lane := s.router.Route(intent.Sport, intent.League)
+		if lane == nil {
+			continue
+		}
+		keys = append(keys, dedupKey{
+			Sport:   intent.Sport,
+			League:  intent.League,
+			GameEID: intent.EID,
+			Ticker:  intent.Ticker,
+			Key:     lane.IdempotencyKey(intent.Ticker, intent.Side, intent.HomeScore, intent.AwayScore),
+		})
+	}
+	if err := s.dedup.Record(intents[0].Sport, s.sessionID, s.orderSeq.Load(), keys); err != nil {
+		telemetry.Warnf("idempotency store: record %d keys failed: %v", len(keys), err)
+	}
+}
+
+// persistSession saves the session's current order seq. It runs before a
+// batch is sent, so a crash mid-request cannot reuse its client order IDs.
+func (s *Service) persistSession(sport events.Sport) {
+	if s.dedup == nil {
+		return
+	}
+	if err := s.dedup.Record(sport, s.sessionID, s.orderSeq.Load(), nil); err != nil {
+		telemetry.Warnf("idempotency store: record session failed: %v", err)
+	}
+}
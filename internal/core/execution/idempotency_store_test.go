@@ -0,0 +1,127 @@
+package execution
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func openTestIdempotencyStore(t *testing.T) *IdempotencyStore {
+	t.Helper()
+	st, err := OpenIdempotencyStore(filepath.Join(t.TempDir(), "idempotency.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { st.Close() })
+	return st
+}
+
+func TestIdempotencyStore(t *testing.T) {
+	st := openTestIdempotencyStore(t)
+	key := func(eid, ticker, k string) dedupKey {
+		return dedupKey{Sport: events.SportHockey, League: "NHL", GameEID: eid, Ticker: ticker, Key: k}
+	}
+	if err := st.Record(events.SportHockey, "s1", 7, []dedupKey{
+		key("1", "BOS", "BOS:yes:1-0"),
+		key("1", "TOR", "TOR:no:1-0"),
+		key("2", "MTL", "MTL:yes:0-1"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		op      func() error
+		keys    int
+		session string
+		seq     int64
+	}{
+		{"recorded", func() error { return nil }, 3, "s1", 7},
+		{"seq never moves back", func() error { return st.Record(events.SportHockey, "s1", 5, nil) }, 3, "s1", 7},
+		{"seq advances", func() error { return st.Record(events.SportHockey, "s1", 9, nil) }, 3, "s1", 9},
+		{"a new session restarts the seq", func() error { return st.Record(events.SportHockey, "s2", 1, nil) }, 3, "s2", 1},
+		{"duplicate key ignored", func() error {
+			return st.Record(events.SportHockey, "s2", 2, []dedupKey{key("1", "BOS", "BOS:yes:1-0")})
+		}, 3, "s2", 2},
+		{"ticker cleared", func() error { return st.ClearTicker("BOS") }, 2, "s2", 2},
+		{"game expired", func() error { return st.ExpireGame("1") }, 1, "s2", 2},
+	}
+	for _, tt := range tests {
+		if err := tt.op(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		keys, err := st.Keys(events.SportHockey)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		id, seq, ok, err := st.Session(events.SportHockey)
+		if err != nil || !ok {
+			t.Fatalf("%s: session %v %v", tt.name, ok, err)
+		}
+		if len(keys) != tt.keys || id != tt.session || seq != tt.seq {
+			t.Errorf("%s: %d keys, session %s at %d; want %d, %s at %d",
+				tt.name, len(keys), id, seq, tt.keys, tt.session, tt.seq)
+		}
+	}
+}
+
+func TestNilIdempotencyStore(t *testing.T) {
+	var st *IdempotencyStore
+	if err := st.Record(events.SportHockey, "s", 1, []dedupKey{{Key: "k"}}); err != nil {
+		t.Error(err)
+	}
+	if keys, err := st.Keys(events.SportHockey); keys != nil || err != nil {
+		t.Errorf("Keys = %v, %v", keys, err)
+	}
+}
+
+func TestKeysPersistedAtApproval(t *testing.T) {
+	x := newFakeExchange()
+	s := newTestService(x, lanes.NewLane(0, 1_000_000))
+	st := openTestIdempotencyStore(t)
+	if err := s.UseIdempotencyStore(st, events.SportHockey); err != nil {
+		t.Fatal(err)
+	}
+
+	intent := func(ticker string, limit float64) events.OrderIntent {
+		return events.OrderIntent{
+			Sport: events.SportHockey, League: "NHL", GameID: "g", EID: "1",
+			Ticker: ticker, Side: "yes", Outcome: "home", LimitPct: limit, HomeScore: 1,
+		}
+	}
+	s.onOrderIntent(events.Event{
+		Type:    events.EventOrderIntent,
+		Payload: []events.OrderIntent{intent("BOS", 60), intent("TOR", 0.5)},
+	})
+
+	// The keys are written before onOrderIntent returns, so an overturn
+	// clearing the ticker right after cannot be undone by the batch.
+	keys, err := st.Keys(events.SportHockey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || keys[0].Ticker != "BOS" {
+		t.Fatalf("keys after approval = %+v, want BOS only", keys)
+	}
+	if err := st.ClearTicker("BOS"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case reqs := <-x.placed:
+		if len(reqs) != 1 || reqs[0].Ticker != "BOS" {
+			t.Fatalf("placed %+v, want BOS only", reqs)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("batch never placed")
+	}
+	if keys, _ := st.Keys(events.SportHockey); len(keys) != 0 {
+		t.Errorf("keys after overturn clear = %+v, want none", keys)
+	}
+	if _, seq, _, _ := st.Session(events.SportHockey); seq != s.orderSeq.Load() {
+		t.Errorf("session seq %d, want %d", seq, s.orderSeq.Load())
+	}
+}
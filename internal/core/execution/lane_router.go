@@ -16,12 +16,14 @@ type LaneRouter struct {
 	mu       sync.RWMutex
 	lanes    map[string]*lanes.Lane  // "hockey:ahl" -> Lane
 	sportTTL map[events.Sport]int    // sport -> order TTL in seconds
+	requote  map[events.Sport]int    // sport -> min reprice move in cents
 }
 
 func NewLaneRouter() *LaneRouter {
 	return &LaneRouter{
 		lanes:    make(map[string]*lanes.Lane),
 		sportTTL: make(map[events.Sport]int),
+		requote:  make(map[events.Sport]int),
 	}
 }
 
@@ -42,6 +44,22 @@ func (lr *LaneRouter) OrderTTL(sport events.Sport) int {
 	return defaultOrderTTL
 }
 
+// SetRequoteMinMove sets how far (cents) a resting order's target price
+// must move before it is amended. Zero disables requoting for the sport.
+func (lr *LaneRouter) SetRequoteMinMove(sport events.Sport, cents int) {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	lr.requote[sport] = cents
+}
+
+// RequoteMinMove returns the sport's minimum reprice move in cents, or 0
+// when requoting is disabled.
+func (lr *LaneRouter) RequoteMinMove(sport events.Sport) int {
+	lr.mu.RLock()
+	defer lr.mu.RUnlock()
+	return lr.requote[sport]
+}
+
 func laneKey(sport events.Sport, league string) string {
 	return fmt.Sprintf("%s:%s", sport, league)
 }
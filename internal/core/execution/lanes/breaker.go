@@ -0,0 +1,50 @@
+package lanes
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Breaker is a loss circuit breaker. Once tripped, every lane it is
+// attached to rejects new orders until it is reset. One breaker may be
+// shared by several lanes (e.g. all leagues of a sport).
+type Breaker struct {
+	tripped atomic.Bool
+	mu      sync.Mutex
+	reason  string
+}
+
+func NewBreaker() *Breaker {
+	return &Breaker{}
+}
+
+// Trip opens the breaker. Returns false if it was already open.
+func (b *Breaker) Trip(reason string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tripped.Load() {
+		return false
+	}
+	b.reason = reason
+	b.tripped.Store(true)
+	return true
+}
+
+// Reset closes the breaker.
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reason = ""
+	b.tripped.Store(false)
+}
+
+func (b *Breaker) Tripped() bool {
+	return b.tripped.Load()
+}
+
+// Reason returns why the breaker tripped, or "" when closed.
+func (b *Breaker) Reason() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reason
+}
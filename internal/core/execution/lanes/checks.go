@@ -0,0 +1,94 @@
+package lanes
+
+import "time"
+
+// Quote is the market state a pre-trade check sees for one order. Prices
+// are yes-side cents; the no side is their complement.
+type Quote struct {
+	Ticker     string
+	Side       string
+	LimitCents int
+	Slam       bool // game-finish order, priced off the result rather than the book; PreTrade passes it
+
+	Connected bool          // Kalshi WS feed is live
+	HasPrice  bool          // a MarketEvent has been seen for Ticker
+	Age       time.Duration // since the last MarketEvent for Ticker
+	YesBid    float64
+	YesAsk    float64
+}
+
+// PreTradeCheck inspects the market before an order is approved and
+// returns the reason to block it, or RejectNone.
+type PreTradeCheck interface {
+	Check(q Quote) Reject
+}
+
+// CheckFunc adapts a function to PreTradeCheck.
+type CheckFunc func(q Quote) Reject
+
+func (f CheckFunc) Check(q Quote) Reject { return f(q) }
+
+const (
+	RejectDisconnected Reject = "Kalshi WS disconnected"
+	RejectStalePrice   Reject = "market data stale"
+	RejectBadBook      Reject = "crossed or reset book"
+	RejectPriceCeiling Reject = "limit above price ceiling"
+	RejectWideSpread   Reject = "spread too wide"
+)
+
+// RequireConnected rejects while the Kalshi WS is down.
+func RequireConnected() PreTradeCheck {
+	return CheckFunc(func(q Quote) Reject {
+		if !q.Connected {
+			return RejectDisconnected
+		}
+		return RejectNone
+	})
+}
+
+// MaxQuoteAge rejects when the ticker has no price, or none newer than maxAge.
+func MaxQuoteAge(maxAge time.Duration) PreTradeCheck {
+	return CheckFunc(func(q Quote) Reject {
+		if !q.HasPrice || q.Age > maxAge {
+			return RejectStalePrice
+		}
+		return RejectNone
+	})
+}
+
+// SaneBook rejects a crossed book and the 100/100 sentinel written on a
+// WS disconnect.
+func SaneBook() PreTradeCheck {
+	return CheckFunc(func(q Quote) Reject {
+		if q.YesBid >= 100 && q.YesAsk >= 100 {
+			return RejectBadBook
+		}
+		if q.YesBid > 0 && q.YesAsk > 0 && q.YesBid > q.YesAsk {
+			return RejectBadBook
+		}
+		return RejectNone
+	})
+}
+
+// PriceCeiling rejects limits above maxCents.
+func PriceCeiling(maxCents int) PreTradeCheck {
+	return CheckFunc(func(q Quote) Reject {
+		if q.LimitCents > maxCents {
+			return RejectPriceCeiling
+		}
+		return RejectNone
+	})
+}
+
+// MaxSpread rejects when the yes bid/ask spread is wider than maxCents.
+func MaxSpread(maxCents int) PreTradeCheck {
+	return CheckFunc(func(q Quote) Reject {
+		if q.YesBid <= 0 || q.YesAsk <= 0 {
+			return RejectNone
+		}
+		if q.YesAsk-q.YesBid > float64(maxCents) {
+			return RejectWideSpread
+		}
+		return RejectNone
+	})
+}
@@ -0,0 +1,93 @@
+package lanes
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChecks(t *testing.T) {
+	live := Quote{Ticker: "T", Side: "yes", LimitCents: 60, Connected: true, HasPrice: true, Age: time.Second, YesBid: 55, YesAsk: 58}
+	with := func(f func(q *Quote)) Quote {
+		q := live
+		f(&q)
+		return q
+	}
+
+	tests := []struct {
+		name  string
+		check PreTradeCheck
+		q     Quote
+		want  Reject
+	}{
+		{"connected", RequireConnected(), live, RejectNone},
+		{"disconnected", RequireConnected(), with(func(q *Quote) { q.Connected = false }), RejectDisconnected},
+		{"fresh quote", MaxQuoteAge(5 * time.Second), live, RejectNone},
+		{"stale quote", MaxQuoteAge(5 * time.Second), with(func(q *Quote) { q.Age = 6 * time.Second }), RejectStalePrice},
+		{"no quote", MaxQuoteAge(5 * time.Second), with(func(q *Quote) { q.HasPrice = false }), RejectStalePrice},
+		{"sane book", SaneBook(), live, RejectNone},
+		{"reset book", SaneBook(), with(func(q *Quote) { q.YesBid, q.YesAsk = 100, 100 }), RejectBadBook},
+		{"crossed book", SaneBook(), with(func(q *Quote) { q.YesBid, q.YesAsk = 60, 58 }), RejectBadBook},
+		{"one-sided book", SaneBook(), with(func(q *Quote) { q.YesBid = 0 }), RejectNone},
+		{"under ceiling", PriceCeiling(60), live, RejectNone},
+		{"over ceiling", PriceCeiling(59), live, RejectPriceCeiling},
+		{"tight spread", MaxSpread(3), live, RejectNone},
+		{"wide spread", MaxSpread(2), live, RejectWideSpread},
+		{"spread on one-sided book", MaxSpread(2), with(func(q *Quote) { q.YesAsk = 0 }), RejectNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.check.Check(tt.q); got != tt.want {
+				t.Errorf("Check = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreTradeSlam(t *testing.T) {
+	l := NewLane(0, 0)
+	l.AddCheck(RequireConnected())
+	l.AddCheck(MaxQuoteAge(5 * time.Second))
+	l.AddCheck(SaneBook())
+	l.AddCheck(PriceCeiling(90))
+	l.AddCheck(MaxSpread(5))
+
+	// Every check would reject this book.
+	dead := Quote{Ticker: "T", Side: "yes", LimitCents: 99, YesBid: 100, YesAsk: 100}
+
+	tests := []struct {
+		name string
+		slam bool
+		want Reject
+	}{
+		{"order", false, RejectDisconnected},
+		{"slam", true, RejectNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := dead
+			q.Slam = tt.slam
+			if got := l.PreTrade(q); got != tt.want {
+				t.Errorf("PreTrade = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSlamStillBreaks(t *testing.T) {
+	l := NewLane(0, 100)
+	b := NewBreaker()
+	l.AddBreaker(b)
+	l.AddCheck(RequireConnected())
+	slam := Quote{Ticker: "T", Side: "yes", LimitCents: 99, Slam: true}
+
+	if got := l.Check("T", "yes", 1, 0, 99, slam); got != RejectNone {
+		t.Fatalf("Check = %q, want none", got)
+	}
+	if got := l.CheckHeld(101, slam); got != RejectSportCap {
+		t.Errorf("CheckHeld over the sport cap = %q, want %q", got, RejectSportCap)
+	}
+	b.Trip("test")
+	if got := l.CheckHeld(99, slam); got != RejectLossLimit {
+		t.Errorf("CheckHeld with a tripped breaker = %q, want %q", got, RejectLossLimit)
+	}
+}
@@ -3,9 +3,13 @@ package lanes
 // Lane encapsulates spending limits and idempotency for a
 // single (sport, league) execution path.
 type Lane struct {
-	maxGameCents int
-	spend        *SpendGuard
-	idempotent   *IdempotencyGuard
+	maxGameCents     int
+	maxGameContracts int
+	sizing           Sizing
+	spend            *SpendGuard
+	idempotent       *IdempotencyGuard
+	breakers         []*Breaker
+	checks           []PreTradeCheck
 }
 
 func NewLane(maxGameCents int, maxSportCents int) *Lane {
@@ -31,6 +35,42 @@ func (l *Lane) MaxGameCents() int {
 	return l.maxGameCents
 }
 
+// SetMaxGameContracts sets the per-game contracts limit. It caps the
+// contracts a game holds at approval and is the game's order-group limit.
+func (l *Lane) SetMaxGameContracts(n int) {
+	l.maxGameContracts = n
+}
+
+// MaxGameContracts returns the per-game contracts limit. When none is
+// configured it falls back to MaxGameCents at an average 50¢ a contract,
+// and is 0 (no limit) when the lane has no per-game cap at all.
+func (l *Lane) MaxGameContracts() int {
+	if l.maxGameContracts > 0 {
+		return l.maxGameContracts
+	}
+	if l.maxGameCents > 0 {
+		return max(1, l.maxGameCents/50)
+	}
+	return 0
+}
+
+// Sizing holds a lane's fractional-Kelly parameters. A zero KellyFraction
+// disables sizing and every order is a single contract.
+type Sizing struct {
+	KellyFraction     float64 // share of the full Kelly stake to take
+	MaxOrderContracts int     // per-order contract cap (0 = none)
+}
+
+// SetSizing sets the lane's position-sizing parameters.
+func (l *Lane) SetSizing(sz Sizing) {
+	l.sizing = sz
+}
+
+// Sizing returns the lane's position-sizing parameters.
+func (l *Lane) Sizing() Sizing {
+	return l.sizing
+}
+
 // Reject describes why an order was blocked; empty string means allowed.
 type Reject string
 
@@ -38,10 +78,43 @@ const (
 	RejectNone       Reject = ""
 	RejectDuplicate  Reject = "duplicate order at this score"
 	RejectSportCap   Reject = "sport spending cap reached"
+	RejectLossLimit  Reject = "loss limit breaker tripped"
 )
 
+// AddBreaker attaches a loss breaker; the lane rejects orders while any
+// attached breaker is tripped.
+func (l *Lane) AddBreaker(b *Breaker) {
+	l.breakers = append(l.breakers, b)
+}
+
+// AddCheck appends a pre-trade market check; checks run in the order added.
+func (l *Lane) AddCheck(c PreTradeCheck) {
+	l.checks = append(l.checks, c)
+}
+
+// PreTrade runs the lane's market checks against q. A slam passes them
+// all: it buys a result the feed has already settled, so a quiet, wide,
+// reset or disconnected book says nothing about its price, and its limit
+// caps what it pays.
+func (l *Lane) PreTrade(q Quote) Reject {
+	if q.Slam {
+		return RejectNone
+	}
+	for _, c := range l.checks {
+		if r := c.Check(q); r != RejectNone {
+			return r
+		}
+	}
+	return RejectNone
+}
+
 // Check returns the reason an order would be blocked, or RejectNone if allowed.
-func (l *Lane) Check(ticker, side string, homeScore, awayScore int, orderCents int) Reject {
+func (l *Lane) Check(ticker, side string, homeScore, awayScore int, orderCents int, q Quote) Reject {
+	for _, b := range l.breakers {
+		if b.Tripped() {
+			return RejectLossLimit
+		}
+	}
 	key := l.idempotent.Key(ticker, side, homeScore, awayScore)
 	if l.idempotent.HasSeen(key) {
 		return RejectDuplicate
@@ -49,7 +122,21 @@ func (l *Lane) Check(ticker, side string, homeScore, awayScore int, orderCents i
 	if !l.spend.CanSpend(orderCents) {
 		return RejectSportCap
 	}
-	return RejectNone
+	return l.PreTrade(q)
+}
+
+// CheckHeld is Check for an order whose dedup key is already recorded,
+// such as one re-placed after it was canceled.
+func (l *Lane) CheckHeld(orderCents int, q Quote) Reject {
+	for _, b := range l.breakers {
+		if b.Tripped() {
+			return RejectLossLimit
+		}
+	}
+	if !l.spend.CanSpend(orderCents) {
+		return RejectSportCap
+	}
+	return l.PreTrade(q)
 }
 
 // SportSpent returns the current sport-level spend in cents.
@@ -70,11 +157,23 @@ func (l *Lane) RecordOrder(ticker, side string, homeScore, awayScore int, orderC
 	l.spend.Record(orderCents)
 }
 
+// AdjustSpend corrects the sport-level spend once an order's real cost is
+// known. Negative deltas release cents reserved by RecordOrder.
+func (l *Lane) AdjustSpend(deltaCents int) {
+	l.spend.Record(deltaCents)
+}
+
 // IdempotencyKey returns the dedup key for external use.
 func (l *Lane) IdempotencyKey(ticker, side string, homeScore, awayScore int) string {
 	return l.idempotent.Key(ticker, side, homeScore, awayScore)
 }
 
+// SeedIdempotency marks a key from IdempotencyKey as already placed without
+// touching spend, for keys restored after a restart.
+func (l *Lane) SeedIdempotency(key string) {
+	l.idempotent.Record(key)
+}
+
 // ClearIdempotency resets all dedup state.
 func (l *Lane) ClearIdempotency() {
 	l.idempotent.Clear()
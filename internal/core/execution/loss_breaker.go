@@ -0,0 +1,375 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/core/state/store"
+	"github.com/charleschow/hft-trading/internal/core/tracking"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// sportScope keys the sport-wide breaker in LossBreaker's maps.
+const sportScope = "*"
+
+// markReadTimeout bounds the wait for the games' mark-to-market reads,
+// which all run at once.
+const markReadTimeout = 2 * time.Second
+
+// BreakerStateStore persists the loss breakers' trading-day state.
+// Satisfied by *tracking.Tracker.
+type BreakerStateStore interface {
+	SaveBreakerStates(sport string, states []tracking.BreakerState) error
+	BreakerStates(sport, day string) ([]tracking.BreakerState, error)
+}
+
+// LossBreaker tracks the trading day's P&L for one sport and each of its
+// leagues and trips the lanes' loss breakers when a threshold from
+// risk_limits.yaml is breached. Day P&L is the realized P&L of games
+// settled today plus the mark-to-market of fills on games still live.
+//
+// Breakers stay tripped until Reset or the day rolls over at midnight in
+// the configured timezone. A reset re-arms a breaker with losses measured
+// from the P&L at the time of the reset. With a state store, reset bases,
+// peaks and trips are saved as they change and survive a restart.
+type LossBreaker struct {
+	gameStore *store.GameStateStore
+	sport     events.Sport
+	sportKey  string
+	loc       *time.Location
+	states    BreakerStateStore
+
+	limits   map[string]config.LossLimits // league (or sportScope) → thresholds
+	breakers map[string]*lanes.Breaker
+
+	mu       sync.Mutex
+	day      string
+	realized map[string]int // settled today, by league
+	base     map[string]int // P&L at the last manual reset
+	peak     map[string]int // day's high-water P&L since rollover/reset
+	saved    map[string]tracking.BreakerState
+
+	markMu sync.Mutex
+	marks  map[*game.GameContext]int // last mark-to-market read per game
+}
+
+// NewLossBreaker builds the sport's breakers from risk limits and attaches
+// them to the router's lanes: the sport breaker to every lane of the
+// sport, each league breaker to that league's lane.
+func NewLossBreaker(router *LaneRouter, gameStore *store.GameStateStore, rl config.RiskLimits, sport events.Sport, sportKey string) (*LossBreaker, error) {
+	loc := time.UTC
+	if tz := rl.Global.TradingDayTimezone; tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("trading day timezone: %w", err)
+		}
+		loc = l
+	}
+
+	b := &LossBreaker{
+		gameStore: gameStore,
+		sport:     sport,
+		sportKey:  sportKey,
+		loc:       loc,
+		limits:    make(map[string]config.LossLimits),
+		breakers:  make(map[string]*lanes.Breaker),
+		realized:  make(map[string]int),
+		base:      make(map[string]int),
+		peak:      make(map[string]int),
+		saved:     make(map[string]tracking.BreakerState),
+		marks:     make(map[*game.GameContext]int),
+	}
+	b.day = b.today()
+
+	sl, _ := rl.SportLimit(sportKey)
+	sportBreaker := lanes.NewBreaker()
+	b.limits[sportScope] = sl.LossLimits
+	b.breakers[sportScope] = sportBreaker
+	if lane := router.Route(sport, "*"); lane != nil {
+		lane.AddBreaker(sportBreaker)
+	}
+	for league, ll := range sl.Leagues {
+		lane := router.Route(sport, league)
+		if lane == nil {
+			continue
+		}
+		lane.AddBreaker(sportBreaker)
+		if ll.LossLimits == (config.LossLimits{}) {
+			continue
+		}
+		lb := lanes.NewBreaker()
+		b.limits[league] = ll.LossLimits
+		b.breakers[league] = lb
+		lane.AddBreaker(lb)
+	}
+	return b, nil
+}
+
+func (b *LossBreaker) today() string {
+	return time.Now().In(b.loc).Format("2006-01-02")
+}
+
+// DayStart returns midnight of the current trading day.
+func (b *LossBreaker) DayStart() time.Time {
+	now := time.Now().In(b.loc)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, b.loc)
+}
+
+// Restore seeds today's realized P&L (by league) after a restart.
+func (b *LossBreaker) Restore(realized map[string]int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for league, pnl := range realized {
+		b.realized[league] += pnl
+	}
+}
+
+// UseStateStore restores today's reset bases, peaks and trips from st and
+// saves every change to them from here on.
+func (b *LossBreaker) UseStateStore(st BreakerStateStore) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	states, err := st.BreakerStates(b.sportKey, b.day)
+	if err != nil {
+		return fmt.Errorf("load breaker states: %w", err)
+	}
+	for _, s := range states {
+		br, ok := b.breakers[s.Scope]
+		if !ok {
+			continue
+		}
+		b.base[s.Scope] = s.BaseCents
+		b.peak[s.Scope] = s.PeakCents
+		if s.Tripped {
+			br.Trip(s.Reason)
+			telemetry.Warnf("[RISK-LIMIT] %s/%s loss breaker restored TRIPPED: %s", b.sport, s.Scope, s.Reason)
+		}
+		b.saved[s.Scope] = s
+	}
+	b.states = st
+	return nil
+}
+
+// OnSettle adds a settled game's realized P&L. Registered with the
+// tracker; runs on the game's goroutine.
+func (b *LossBreaker) OnSettle(gc *game.GameContext, pnlCents int) {
+	b.mu.Lock()
+	b.realized[gc.League] += pnlCents
+	b.mu.Unlock()
+}
+
+// Reset re-arms the breaker for league, or every breaker of the sport
+// when league is empty.
+func (b *LossBreaker) Reset(league string) {
+	pnl := b.dayPnL(b.markToMarket())
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for scope, br := range b.breakers {
+		if league != "" && scope != league {
+			continue
+		}
+		b.base[scope] = pnl[scope]
+		b.peak[scope] = 0
+		br.Reset()
+		telemetry.Infof("[RISK-LIMIT] %s/%s loss breaker reset at day P&L $%.2f",
+			b.sport, scope, float64(pnl[scope])/100.0)
+	}
+	b.save()
+}
+
+// Run re-evaluates the breakers every interval until ctx is done.
+func (b *LossBreaker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.check()
+		}
+	}
+}
+
+func (b *LossBreaker) check() {
+	b.rollover()
+	pnl := b.dayPnL(b.markToMarket())
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for scope, br := range b.breakers {
+		cur := pnl[scope] - b.base[scope]
+		peak := max(b.peak[scope], cur)
+		b.peak[scope] = peak
+
+		lim := b.limits[scope]
+		var reason string
+		switch {
+		case lim.MaxDailyLossCents > 0 && cur <= -lim.MaxDailyLossCents:
+			reason = fmt.Sprintf("day P&L $%.2f breached daily loss limit $%.2f",
+				float64(cur)/100.0, float64(lim.MaxDailyLossCents)/100.0)
+		case lim.MaxDrawdownCents > 0 && peak-cur >= lim.MaxDrawdownCents:
+			reason = fmt.Sprintf("drawdown $%.2f from peak $%.2f breached limit $%.2f",
+				float64(peak-cur)/100.0, float64(peak)/100.0, float64(lim.MaxDrawdownCents)/100.0)
+		}
+		if reason != "" && br.Trip(reason) {
+			telemetry.Errorf("[RISK-LIMIT] %s/%s loss breaker TRIPPED: %s", b.sport, scope, reason)
+		}
+	}
+	b.save()
+}
+
+// save persists the breakers whose state changed since the last save.
+// Must be called with b.mu held.
+func (b *LossBreaker) save() {
+	if b.states == nil {
+		return
+	}
+	var changed []tracking.BreakerState
+	for scope, br := range b.breakers {
+		st := tracking.BreakerState{
+			Scope:     scope,
+			Day:       b.day,
+			BaseCents: b.base[scope],
+			PeakCents: b.peak[scope],
+			Tripped:   br.Tripped(),
+			Reason:    br.Reason(),
+		}
+		if st != b.saved[scope] {
+			changed = append(changed, st)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	if err := b.states.SaveBreakerStates(b.sportKey, changed); err != nil {
+		telemetry.Warnf("[RISK-LIMIT] %s save breaker state: %v", b.sport, err)
+		return
+	}
+	for _, st := range changed {
+		b.saved[st.Scope] = st
+	}
+}
+
+// rollover starts a new trading day: realized P&L, reset bases and peaks
+// are cleared and every breaker is re-armed.
+func (b *LossBreaker) rollover() {
+	day := b.today()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if day == b.day {
+		return
+	}
+	b.day = day
+	clear(b.realized)
+	clear(b.base)
+	clear(b.peak)
+	for _, br := range b.breakers {
+		br.Reset()
+	}
+	b.save()
+	telemetry.Infof("[RISK-LIMIT] %s trading day rolled over to %s — loss breakers re-armed", b.sport, day)
+}
+
+// dayPnL combines realized and mark-to-market P&L (both by league) into
+// per-scope totals, including the sport-wide sportScope.
+func (b *LossBreaker) dayPnL(mtm map[string]int) map[string]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make(map[string]int)
+	for league, v := range b.realized {
+		out[league] += v
+		out[sportScope] += v
+	}
+	for league, v := range mtm {
+		out[league] += v
+		out[sportScope] += v
+	}
+	return out
+}
+
+// markToMarket values the fills of every unfinished game at the current
+// bid, by league. Fills with no bid are carried at cost. Every game is
+// read on its own goroutine at once; a game whose read is dropped or late
+// keeps its last known value.
+func (b *LossBreaker) markToMarket() map[string]int {
+	type mark struct {
+		gc  *game.GameContext
+		pnl int
+	}
+	games := b.gameStore.All()
+	ch := make(chan mark, len(games))
+	sent := 0
+	for _, gc := range games {
+		if gc.TrySend(func() { ch <- mark{gc, gameMark(gc)} }) {
+			sent++
+		}
+	}
+
+	read := make(map[*game.GameContext]int, sent)
+	timeout := time.NewTimer(markReadTimeout)
+	defer timeout.Stop()
+wait:
+	for len(read) < sent {
+		select {
+		case m := <-ch:
+			read[m.gc] = m.pnl
+		case <-timeout.C:
+			break wait
+		}
+	}
+	if stale := len(games) - len(read); stale > 0 {
+		telemetry.Warnf("[RISK-LIMIT] %s mark-to-market: %d of %d games unread, using last known values",
+			b.sport, stale, len(games))
+	}
+
+	b.markMu.Lock()
+	defer b.markMu.Unlock()
+	out := make(map[string]int)
+	marks := make(map[*game.GameContext]int, len(games))
+	for _, gc := range games {
+		pnl, ok := read[gc]
+		if !ok {
+			pnl = b.marks[gc]
+		}
+		if pnl != 0 {
+			marks[gc] = pnl
+		}
+		out[gc.League] += pnl
+	}
+	b.marks = marks
+	return out
+}
+
+// gameMark is gc's mark-to-market P&L in cents. Runs on the game's
+// goroutine.
+func gameMark(gc *game.GameContext) int {
+	if gc.MatchStatus == events.StatusGameFinish || len(gc.Fills) == 0 {
+		return 0
+	}
+	pnl := 0
+	for _, f := range gc.Fills {
+		td := gc.Tickers[f.Ticker]
+		if td == nil {
+			continue
+		}
+		bid := td.YesBid
+		if f.Side == "no" {
+			bid = td.NoBid
+		}
+		if bid > 0 {
+			pnl += int(bid*float64(f.Count)) - f.CostCents
+		}
+	}
+	return pnl
+}
@@ -0,0 +1,195 @@
+package execution
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/core/state/store"
+	"github.com/charleschow/hft-trading/internal/core/tracking"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+// fakeBreakerStates is a BreakerStateStore holding one day's states.
+type fakeBreakerStates struct {
+	mu     sync.Mutex
+	states []tracking.BreakerState
+	saved  []tracking.BreakerState
+}
+
+func (f *fakeBreakerStates) SaveBreakerStates(_ string, states []tracking.BreakerState) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.saved = append(f.saved, states...)
+	return nil
+}
+
+func (f *fakeBreakerStates) BreakerStates(_, day string) ([]tracking.BreakerState, error) {
+	var out []tracking.BreakerState
+	for _, s := range f.states {
+		if s.Day == day {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// newTestBreaker returns a hockey loss breaker over one NHL lane: the sport
+// trips at a $10 day loss or a $5 drawdown, the league at a $3 day loss.
+func newTestBreaker(t *testing.T) (*LossBreaker, *lanes.Lane, *game.GameContext) {
+	t.Helper()
+	lane := lanes.NewLane(0, 1_000_000)
+	router := NewLaneRouter()
+	router.Register(events.SportHockey, "NHL", lane)
+	rl := config.RiskLimits{Sports: map[string]config.SportLimits{
+		"hockey": {
+			LossLimits: config.LossLimits{MaxDailyLossCents: 1000, MaxDrawdownCents: 500},
+			Leagues: map[string]config.LeagueLimits{
+				"NHL": {LossLimits: config.LossLimits{MaxDailyLossCents: 300}},
+			},
+		},
+	}}
+	games := store.New()
+	gc := newTestGame(t)
+	games.Put(gc)
+	b, err := NewLossBreaker(router, games, rl, events.SportHockey, "hockey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, lane, gc
+}
+
+func TestLossBreakerCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		settle     []int // realized P&L settled before each check
+		markCents  int   // mark-to-market of an open fill, 0 for none
+		wantSport  bool
+		wantLeague bool
+	}{
+		{name: "within limits", settle: []int{-200}},
+		{name: "league day loss", settle: []int{-300}, wantLeague: true},
+		{name: "sport day loss", settle: []int{-1000}, wantSport: true, wantLeague: true},
+		{name: "drawdown from the day's peak", settle: []int{400, -600}, wantSport: true},
+		{name: "gain then small loss", settle: []int{400, -200}},
+		{name: "open fills marked to market", settle: []int{0}, markCents: -400, wantLeague: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, lane, gc := newTestBreaker(t)
+			if tt.markCents != 0 {
+				// 10 contracts bid at 10¢, costing what leaves a mark of markCents.
+				onGame(gc, func() {
+					gc.Tickers["BOS"] = &game.TickerData{Ticker: "BOS", YesBid: 10}
+					gc.RecordFill(game.Fill{OrderID: "o1", Ticker: "BOS", Side: "yes", Count: 10, CostCents: 100 - tt.markCents})
+				})
+			}
+			for _, pnl := range tt.settle {
+				b.OnSettle(gc, pnl)
+				b.check()
+			}
+
+			if got := b.breakers[sportScope].Tripped(); got != tt.wantSport {
+				t.Errorf("sport tripped %v, want %v (%s)", got, tt.wantSport, b.breakers[sportScope].Reason())
+			}
+			if got := b.breakers["NHL"].Tripped(); got != tt.wantLeague {
+				t.Errorf("league tripped %v, want %v (%s)", got, tt.wantLeague, b.breakers["NHL"].Reason())
+			}
+			want := lanes.RejectNone
+			if tt.wantSport || tt.wantLeague {
+				want = lanes.RejectLossLimit
+			}
+			if got := lane.CheckHeld(1, lanes.Quote{}); got != want {
+				t.Errorf("lane check %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLossBreakerReset(t *testing.T) {
+	b, _, gc := newTestBreaker(t)
+	b.OnSettle(gc, -1000)
+	b.check()
+
+	b.Reset("NHL")
+	b.check()
+	if !b.breakers[sportScope].Tripped() || b.breakers["NHL"].Tripped() {
+		t.Fatal("league reset should re-arm the league breaker only")
+	}
+
+	b.Reset("")
+	b.check()
+	if b.breakers[sportScope].Tripped() || b.breakers["NHL"].Tripped() {
+		t.Fatal("losses before the reset still count")
+	}
+
+	b.OnSettle(gc, -300)
+	b.check()
+	if b.breakers[sportScope].Tripped() || !b.breakers["NHL"].Tripped() {
+		t.Error("losses after the reset should be measured from it")
+	}
+}
+
+func TestLossBreakerRollover(t *testing.T) {
+	b, _, gc := newTestBreaker(t)
+	st := &fakeBreakerStates{}
+	if err := b.UseStateStore(st); err != nil {
+		t.Fatal(err)
+	}
+	b.OnSettle(gc, -1000)
+	b.check()
+	b.Reset("NHL")
+	b.check()
+
+	today := b.day
+	b.day = "2000-01-01"
+	b.check()
+
+	if b.day != today || b.realized["NHL"] != 0 || b.base["NHL"] != 0 {
+		t.Errorf("day %s realized %d base %d after rollover", b.day, b.realized["NHL"], b.base["NHL"])
+	}
+	if b.breakers[sportScope].Tripped() || b.breakers["NHL"].Tripped() {
+		t.Error("rollover should re-arm every breaker")
+	}
+	last := st.saved[len(st.saved)-1]
+	if last.Day != today || last.Tripped || last.BaseCents != 0 {
+		t.Errorf("last saved state %+v", last)
+	}
+}
+
+func TestLossBreakerRestore(t *testing.T) {
+	b, _, _ := newTestBreaker(t)
+	st := &fakeBreakerStates{states: []tracking.BreakerState{
+		{Scope: sportScope, Day: b.day, BaseCents: -200, PeakCents: 100, Tripped: true, Reason: "daily loss"},
+		{Scope: "NHL", Day: "2000-01-01", Tripped: true, Reason: "yesterday"},
+		{Scope: "AHL", Day: b.day, Tripped: true, Reason: "no such breaker"},
+	}}
+	if err := b.UseStateStore(st); err != nil {
+		t.Fatal(err)
+	}
+	b.Restore(map[string]int{"NHL": -200})
+
+	if !b.breakers[sportScope].Tripped() || b.breakers[sportScope].Reason() != "daily loss" {
+		t.Errorf("sport breaker not restored tripped: %q", b.breakers[sportScope].Reason())
+	}
+	if b.breakers["NHL"].Tripped() {
+		t.Error("an earlier day's trip was restored")
+	}
+
+	b.check()
+	// The sport's base absorbs the restored loss; the league has no base
+	// and -200 is inside its limit. Only the league's state is new.
+	if got := b.base[sportScope]; got != -200 {
+		t.Errorf("sport base %d, want -200", got)
+	}
+	var scopes []string
+	for _, s := range st.saved {
+		scopes = append(scopes, s.Scope)
+	}
+	if !slices.Equal(scopes, []string{"NHL"}) {
+		t.Errorf("saved %v, want only NHL", scopes)
+	}
+}
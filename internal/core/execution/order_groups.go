@@ -0,0 +1,204 @@
+package execution
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// groupWindow is the rolling window a Kalshi order group's contracts
+// limit is measured over.
+const groupWindow = 15 * time.Second
+
+// groupRetryBackoff is how long orders go ungrouped after creating a group
+// failed, so an outage does not add a failed round trip to every order.
+const groupRetryBackoff = 30 * time.Second
+
+// orderGroups holds one Kalshi order group per game, created on the
+// game's first order with its lane's contracts limit.
+//
+// The hard per-game cap is the lane's MaxGameContracts, enforced at
+// approval against the contracts the game holds, filled or resting. A
+// group only caps how many contracts the game's orders match in any 15s
+// window, so it is the exchange-side backstop for what approval cannot
+// see: batches still in flight, or a restart before reconcile. When a
+// group triggers, Kalshi cancels its orders and rejects new ones; the
+// group is reset once the window has passed. Groups are deleted when
+// their game finishes; slams trade after that and are placed ungrouped.
+//
+// orderGroups implements game.GameObserver for the GAME FINISH cleanup.
+type orderGroups struct {
+	grouper OrderGrouper // nil when the exchange has no order groups
+
+	mu      sync.Mutex
+	groups  map[*game.GameContext]*orderGroup
+	retryAt time.Time // no groups are created before then
+}
+
+type orderGroup struct {
+	once sync.Once
+	id   string
+
+	resetting bool // guarded by orderGroups.mu
+	finished  bool // game over: no group, and none is created
+}
+
+// finishedLinger is how long a finished game's entry is kept so an order
+// batch already in flight does not create a fresh group.
+const finishedLinger = time.Minute
+
+func newOrderGroups(client OrderPlacer) *orderGroups {
+	grouper, _ := client.(OrderGrouper)
+	return &orderGroups{
+		grouper: grouper,
+		groups:  make(map[*game.GameContext]*orderGroup),
+	}
+}
+
+// OrderGroupObserver returns the observer that deletes a game's order
+// group when it finishes.
+func (s *Service) OrderGroupObserver() game.GameObserver {
+	return s.groups
+}
+
+// PrepareOrderGroups creates the order group of every game in the store up
+// front, so a game's first order does not wait on the extra round trip.
+// Games added later get theirs on first order.
+func (s *Service) PrepareOrderGroups(ctx context.Context) {
+	if s.groups.grouper == nil {
+		return
+	}
+	n := 0
+	for _, gc := range s.gameStore.All() {
+		if ctx.Err() != nil {
+			return
+		}
+		if s.groups.idFor(ctx, gc, s.router.Route(gc.Sport, gc.League)) != "" {
+			n++
+		}
+	}
+	telemetry.Infof("[EXEC] order groups ready for %d games", n)
+}
+
+// idFor returns the game's order group ID, creating the group on first
+// use. Returns "" when groups are unsupported, the lane has no contracts
+// limit, or creation failed; creation is retried after groupRetryBackoff.
+// Never called from the game's goroutine.
+func (g *orderGroups) idFor(ctx context.Context, gc *game.GameContext, lane *lanes.Lane) string {
+	if g.grouper == nil || lane == nil || lane.MaxGameContracts() == 0 {
+		return ""
+	}
+
+	g.mu.Lock()
+	og := g.groups[gc]
+	if og == nil {
+		if time.Now().Before(g.retryAt) {
+			g.mu.Unlock()
+			return ""
+		}
+		og = &orderGroup{}
+		g.groups[gc] = og
+	}
+	g.mu.Unlock()
+
+	if og.finished {
+		return ""
+	}
+	og.once.Do(func() {
+		limit := lane.MaxGameContracts()
+		id, err := g.grouper.CreateOrderGroup(ctx, limit)
+		if err != nil {
+			telemetry.Warnf("[EXEC] order group for %s: %v — placing ungrouped for %v", gc.EID, err, groupRetryBackoff)
+			g.mu.Lock()
+			g.retryAt = time.Now().Add(groupRetryBackoff)
+			g.mu.Unlock()
+			return
+		}
+		og.id = id
+		telemetry.Debugf("[EXEC] order group %s for %s (limit %d contracts per %v)", id, gc.EID, limit, groupWindow)
+	})
+
+	if og.id == "" {
+		g.mu.Lock()
+		if g.groups[gc] == og {
+			delete(g.groups, gc)
+		}
+		g.mu.Unlock()
+	}
+	return og.id
+}
+
+// triggered notes that gc's group rejected an order because its limit was
+// hit, and resets the group once the window has passed.
+func (g *orderGroups) triggered(gc *game.GameContext, groupID string) {
+	g.mu.Lock()
+	og := g.groups[gc]
+	if og == nil || og.id != groupID || og.resetting {
+		g.mu.Unlock()
+		return
+	}
+	og.resetting = true
+	g.mu.Unlock()
+
+	telemetry.Warnf("[EXEC] order group %s for %s hit its limit — resetting in %v", groupID, gc.EID, groupWindow)
+	time.AfterFunc(groupWindow, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+		defer cancel()
+		err := g.grouper.ResetOrderGroup(ctx, groupID)
+
+		g.mu.Lock()
+		og.resetting = false
+		g.mu.Unlock()
+		if err != nil {
+			telemetry.Warnf("[EXEC] reset order group %s: %v", groupID, err)
+		}
+	})
+}
+
+// OnGameEvent deletes the game's group once it finishes. Runs on the
+// game's goroutine, so the HTTP call is made on its own.
+func (g *orderGroups) OnGameEvent(gc *game.GameContext, eventType string) {
+	if events.MatchStatus(eventType) != events.StatusGameFinish || g.grouper == nil {
+		return
+	}
+	done := &orderGroup{finished: true}
+	g.mu.Lock()
+	og := g.groups[gc]
+	g.groups[gc] = done
+	g.mu.Unlock()
+	time.AfterFunc(finishedLinger, func() {
+		g.mu.Lock()
+		if g.groups[gc] == done {
+			delete(g.groups, gc)
+		}
+		g.mu.Unlock()
+	})
+	if og == nil {
+		return
+	}
+
+	go func() {
+		og.once.Do(func() {}) // waits out a creation in flight
+		if og.id == "" {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+		defer cancel()
+		if err := g.grouper.DeleteOrderGroup(ctx, og.id); err != nil {
+			telemetry.Warnf("[EXEC] delete order group %s: %v", og.id, err)
+		}
+	}()
+}
+
+// groupLimitHit reports whether an order rejection came from its order
+// group being triggered.
+func groupLimitHit(code, message string) bool {
+	s := strings.ToLower(code + " " + message)
+	return strings.Contains(s, "order_group") || strings.Contains(s, "order group")
+}
@@ -0,0 +1,185 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/core/state/trading"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+type fakeGrouper struct {
+	mu      sync.Mutex
+	err     error
+	created []int
+	deleted []string
+}
+
+func (f *fakeGrouper) CreateOrderGroup(_ context.Context, limit int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return "", f.err
+	}
+	f.created = append(f.created, limit)
+	return "g" + strconv.Itoa(len(f.created)), nil
+}
+
+func (f *fakeGrouper) ResetOrderGroup(context.Context, string) error { return nil }
+
+func (f *fakeGrouper) DeleteOrderGroup(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func TestOrderGroupsIDFor(t *testing.T) {
+	fg := &fakeGrouper{}
+	g := &orderGroups{grouper: fg, groups: make(map[*game.GameContext]*orderGroup)}
+	capped := lanes.NewLane(3000, 100_000)
+	capped.SetMaxGameContracts(60)
+	gc, other := newTestGame(t), newTestGame(t)
+
+	if id := g.idFor(context.Background(), gc, lanes.NewLane(0, 100_000)); id != "" {
+		t.Errorf("lane without a game cap got group %q", id)
+	}
+	if id := g.idFor(context.Background(), gc, capped); id != "g1" {
+		t.Errorf("first order got group %q, want g1", id)
+	}
+	if id := g.idFor(context.Background(), gc, capped); id != "g1" {
+		t.Errorf("second order got group %q, want g1 again", id)
+	}
+	if len(fg.created) != 1 || fg.created[0] != 60 {
+		t.Errorf("created %v, want one group of 60", fg.created)
+	}
+
+	fg.err = errors.New("503")
+	if id := g.idFor(context.Background(), other, capped); id != "" {
+		t.Errorf("failed creation returned %q", id)
+	}
+	fg.err = nil
+	if id := g.idFor(context.Background(), other, capped); id != "" || len(fg.created) != 1 {
+		t.Errorf("retried during the backoff: got %q, %d created", id, len(fg.created))
+	}
+	g.retryAt = time.Now().Add(-time.Second)
+	if id := g.idFor(context.Background(), other, capped); id != "g2" {
+		t.Errorf("after the backoff got %q, want g2", id)
+	}
+
+	g.OnGameEvent(gc, string(events.StatusGameFinish))
+	if id := g.idFor(context.Background(), gc, capped); id != "" {
+		t.Errorf("finished game got group %q", id)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		fg.mu.Lock()
+		n := len(fg.deleted)
+		fg.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("finished game's group never deleted")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGroupLimitHit(t *testing.T) {
+	tests := []struct {
+		code, message string
+		want          bool
+	}{
+		{"order_group_limit_exceeded", "", true},
+		{"", "Order group contracts limit reached", true},
+		{"insufficient_balance", "not enough funds", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		if got := groupLimitHit(tt.code, tt.message); got != tt.want {
+			t.Errorf("groupLimitHit(%q, %q) = %v, want %v", tt.code, tt.message, got, tt.want)
+		}
+	}
+}
+
+func TestPerGameContractCap(t *testing.T) {
+	tests := []struct {
+		name    string
+		cap     int
+		filled  int
+		resting int
+		intents int
+		want    []int
+	}{
+		{"sized within the cap", 100, 0, 0, 1, []int{50}},
+		{"clamped to the cap", 10, 0, 0, 1, []int{10}},
+		{"fills and resting orders count", 10, 4, 4, 1, []int{2}},
+		{"cap reached", 10, 6, 4, 1, nil},
+		{"earlier orders in the batch count", 60, 0, 0, 2, []int{50, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lane := lanes.NewLane(1_000_000, 1_000_000)
+			lane.SetMaxGameContracts(tt.cap)
+			lane.SetSizing(lanes.Sizing{KellyFraction: 0.25})
+			x := newFakeExchange()
+			s := newTestService(x, lane)
+			s.SetBankroll(100_000)
+			gc := newTestGame(t)
+			s.gameStore.Put(gc)
+
+			intent := events.OrderIntent{
+				Sport: events.SportHockey, League: "NHL", GameID: "1", EID: "1",
+				Ticker: "BOS", Side: "yes", Outcome: "home", LimitPct: 55, ModelPct: 60,
+			}
+			batch := []events.OrderIntent{intent}
+			if tt.intents == 2 {
+				away := intent
+				away.Ticker, away.Outcome = "TOR", "away"
+				batch = append(batch, away)
+			}
+
+			onGame(gc, func() {
+				if tt.filled > 0 {
+					gc.RecordFill(game.Fill{OrderID: "f", Ticker: "BOS", Side: "yes", Count: tt.filled, CostCents: tt.filled * 50})
+				}
+				if tt.resting > 0 {
+					gc.Orders.TrackOrder(&trading.OpenOrder{OrderID: "r", Ticker: "BOS", Side: "yes", Count: tt.resting + 1, Filled: 1})
+				}
+				s.onOrderIntent(events.Event{Type: events.EventOrderIntent, Payload: batch})
+			})
+
+			if tt.want == nil {
+				if spent := lane.SportSpent(); spent != 0 {
+					t.Errorf("approved %d¢ past the cap", spent)
+				}
+				return
+			}
+			select {
+			case reqs := <-x.placed:
+				var got []int
+				for _, r := range reqs {
+					n, _ := strconv.Atoi(r.CountFP[:len(r.CountFP)-3])
+					got = append(got, n)
+				}
+				if len(got) != len(tt.want) {
+					t.Fatalf("placed %v contracts, want %v", got, tt.want)
+				}
+				for i := range got {
+					if got[i] != tt.want[i] {
+						t.Errorf("placed %v contracts, want %v", got, tt.want)
+					}
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("batch never placed")
+			}
+		})
+	}
+}
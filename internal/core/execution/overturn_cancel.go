@@ -0,0 +1,230 @@
+package execution
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/overturn"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/core/tracking"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+const cancelTimeout = 10 * time.Second
+
+// OverturnCanceler implements game.GameObserver. When a score drop goes
+// pending it pulls every open order on the game, since they were priced
+// off a score that may be about to disappear. The cancels are recorded in
+// the tracking and overturn stores.
+//
+// With replaceOnReject set, the unfilled part of each canceled order is
+// re-placed once the overturn is rejected and the original score stands.
+type OverturnCanceler struct {
+	svc             *Service
+	store           *overturn.Store
+	replaceOnReject bool
+
+	mu       sync.Mutex
+	canceled map[*game.GameContext]*overturnCancels
+}
+
+// overturnCancels is one game's orders pulled for a pending overturn.
+type overturnCancels struct {
+	orders   []*canceledOrder
+	rejected bool // replace has run; orders still canceling follow on
+}
+
+// canceledOrder is an order pulled for an overturn, with its intent sized
+// to the contracts left unfilled. done is set once the cancel succeeds;
+// only done orders are re-placed.
+type canceledOrder struct {
+	orderID string
+	intent  events.OrderIntent
+	done    bool
+}
+
+func NewOverturnCanceler(svc *Service, store *overturn.Store, replaceOnReject bool) *OverturnCanceler {
+	return &OverturnCanceler{
+		svc:             svc,
+		store:           store,
+		replaceOnReject: replaceOnReject,
+		canceled:        make(map[*game.GameContext]*overturnCancels),
+	}
+}
+
+func (c *OverturnCanceler) OnGameEvent(gc *game.GameContext, eventType string) {
+	switch events.MatchStatus(eventType) {
+	case events.StatusOverturnPending:
+		c.cancelOpen(gc)
+	case events.StatusOverturnRejected:
+		c.replace(gc)
+	case events.StatusOverturnConfirmed, events.StatusGameFinish:
+		c.mu.Lock()
+		delete(c.canceled, gc)
+		c.mu.Unlock()
+	}
+}
+
+// cancelOpen runs on the game goroutine: it snapshots what it needs from
+// gc, records the orders to re-place, and does the HTTP cancels on a
+// separate goroutine.
+func (c *OverturnCanceler) cancelOpen(gc *game.GameContext) {
+	orders := c.svc.fills.open(gc)
+	if len(orders) == 0 {
+		return
+	}
+
+	eid := gc.EID
+	label := shortName(gc.Game.GetHomeTeam()) + " vs " + shortName(gc.Game.GetAwayTeam())
+	asks := make(map[string]*float64, len(orders))
+	for _, o := range orders {
+		if td, ok := gc.Tickers[o.ticker]; ok && td.YesAsk > 0 {
+			v := td.YesAsk
+			asks[o.ticker] = &v
+		}
+	}
+
+	pulled := make(map[string]*canceledOrder, len(orders))
+	if c.replaceOnReject {
+		c.mu.Lock()
+		oc := c.canceled[gc]
+		if oc == nil {
+			oc = &overturnCancels{}
+			c.canceled[gc] = oc
+		}
+		oc.rejected = false
+		for _, o := range orders {
+			if o.intent.EID == "" || o.remaining <= 0 {
+				continue
+			}
+			intent := o.intent
+			intent.Count = o.remaining
+			co := &canceledOrder{orderID: o.orderID, intent: intent}
+			oc.orders = append(oc.orders, co)
+			pulled[o.orderID] = co
+		}
+		c.mu.Unlock()
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+		defer cancel()
+
+		var n, contracts int
+		for _, o := range orders {
+			err := c.svc.client.CancelOrder(ctx, o.orderID)
+			c.settle(gc, pulled[o.orderID], err == nil)
+			if err != nil {
+				telemetry.Warnf("[OVERTURN-CANCEL] %s — cancel %s failed: %v", label, o.orderID, err)
+				continue
+			}
+			c.svc.tracker.RecordCancel(tracking.CancelRecord{
+				GameEID:        eid,
+				OrderID:        o.orderID,
+				Ticker:         o.ticker,
+				Side:           o.side,
+				Reason:         "overturn_pending",
+				LimitCents:     o.limitCents,
+				FilledCount:    o.filled,
+				CanceledCount:  o.remaining,
+				YesAskAtCancel: asks[o.ticker],
+			})
+			n++
+			contracts += o.remaining
+		}
+		if n == 0 {
+			return
+		}
+
+		if err := c.store.RecordCancels(eid, n, contracts); err != nil {
+			telemetry.Warnf("overturn store: record cancels failed: %v", err)
+		}
+		telemetry.Infof("[OVERTURN-CANCEL] %s — canceled %d/%d open orders (%d contracts)",
+			label, n, len(orders), contracts)
+	}()
+}
+
+// settle records the outcome of co's cancel: a failed cancel leaves the
+// order resting, so it is dropped rather than re-placed. If the overturn
+// was already rejected, a successful cancel is re-placed on the game
+// goroutine straight away.
+func (c *OverturnCanceler) settle(gc *game.GameContext, co *canceledOrder, ok bool) {
+	if co == nil {
+		return
+	}
+	c.mu.Lock()
+	oc := c.canceled[gc]
+	if oc == nil {
+		c.mu.Unlock()
+		return
+	}
+	if ok {
+		co.done = true
+	} else {
+		oc.orders = slices.DeleteFunc(oc.orders, func(o *canceledOrder) bool { return o == co })
+	}
+	rejected := oc.rejected
+	c.mu.Unlock()
+	if ok && rejected {
+		gc.Send(func() { c.replace(gc) })
+	}
+}
+
+// replace re-places the orders canceled for a pending overturn that was
+// rejected, at their unfilled size and through the same checks as new
+// orders. Orders whose cancel is still in flight stay behind and are
+// re-placed as their cancels return. Runs on the game goroutine.
+func (c *OverturnCanceler) replace(gc *game.GameContext) {
+	c.mu.Lock()
+	oc := c.canceled[gc]
+	if oc == nil {
+		c.mu.Unlock()
+		return
+	}
+	var intents []events.OrderIntent
+	pending := oc.orders[:0]
+	for _, co := range oc.orders {
+		if co.done {
+			intents = append(intents, co.intent)
+		} else {
+			pending = append(pending, co)
+		}
+	}
+	oc.orders = pending
+	oc.rejected = true
+	if len(pending) == 0 {
+		delete(c.canceled, gc)
+	}
+	c.mu.Unlock()
+	if len(intents) == 0 || c.svc.halted.Load() {
+		return
+	}
+
+	label := shortName(gc.Game.GetHomeTeam()) + " vs " + shortName(gc.Game.GetAwayTeam())
+	spent, contracts := gc.TotalExposureCents(), gc.TotalContracts()
+	var kept []events.OrderIntent
+	var depth []int
+	for _, intent := range intents {
+		lane := c.svc.router.Route(intent.Sport, intent.League)
+		if lane == nil {
+			continue
+		}
+		orderCents, ok := approve(lane, gc, true, intent, spent, contracts, true, label)
+		if !ok {
+			continue
+		}
+		spent += orderCents
+		contracts += intent.Count
+		kept = append(kept, intent)
+		depth = append(depth, bookDepth(gc, true, intent))
+	}
+	if len(kept) == 0 {
+		return
+	}
+
+	telemetry.Infof("[OVERTURN-CANCEL] %s — overturn rejected, re-placing %d orders", label, len(kept))
+	go c.svc.placeBatchOrder(kept, depth, time.Time{}, c.svc.router.OrderTTL(kept[0].Sport))
+}
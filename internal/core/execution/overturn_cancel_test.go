@@ -0,0 +1,115 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+// waitFor polls cond until it holds or a second passes.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestOverturnCancel(t *testing.T) {
+	tests := []struct {
+		name      string
+		replace   bool
+		restored  bool // the order was restored by Reconcile and has no intent
+		cancelErr error
+		halt      bool
+		late      bool // the overturn is rejected before the cancel returns
+		outcome   events.MatchStatus
+		want      int // contracts re-placed, 0 for none
+	}{
+		{name: "rejected overturn re-places the unfilled part", replace: true, outcome: events.StatusOverturnRejected, want: 3},
+		{name: "cancel returning after the rejection re-places", replace: true, late: true, outcome: events.StatusOverturnRejected, want: 3},
+		{name: "replace off", outcome: events.StatusOverturnRejected},
+		{name: "confirmed overturn", replace: true, outcome: events.StatusOverturnConfirmed},
+		{name: "failed cancel is left resting", replace: true, cancelErr: errors.New("503"), outcome: events.StatusOverturnRejected},
+		{name: "restored order is not re-placed", replace: true, restored: true, outcome: events.StatusOverturnRejected},
+		{name: "halted", replace: true, halt: true, outcome: events.StatusOverturnRejected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lane := lanes.NewLane(0, 1_000_000)
+			x := newFakeExchange()
+			x.cancelErr = tt.cancelErr
+			if tt.late {
+				x.cancelGate = make(chan struct{})
+			}
+			s := newTestService(x, lane)
+			gc := newTestGame(t)
+			c := NewOverturnCanceler(s, nil, tt.replace)
+
+			intent := events.OrderIntent{
+				Sport: events.SportHockey, League: "NHL", EID: "1",
+				Ticker: "BOS", Side: "yes", LimitPct: 55, Count: 5, HomeScore: 1,
+			}
+			o := &trackedOrder{gc: gc, lane: lane, ticker: "BOS", side: "yes", intent: intent, limitCents: 55, reservedCents: 275, total: 5, filled: 2}
+			if tt.restored {
+				o.intent = events.OrderIntent{}
+			}
+			lane.RecordOrder("BOS", "yes", 1, 0, 275)
+			s.fills.track("o1", o, false)
+			if tt.halt {
+				s.halted.Store(true)
+			}
+
+			onGame(gc, func() { c.OnGameEvent(gc, string(events.StatusOverturnPending)) })
+			if tt.late {
+				onGame(gc, func() { c.OnGameEvent(gc, string(tt.outcome)) })
+				close(x.cancelGate)
+			}
+			waitFor(t, "the cancel", func() bool {
+				x.mu.Lock()
+				n := len(x.canceled)
+				x.mu.Unlock()
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				oc := c.canceled[gc]
+				switch {
+				case tt.cancelErr != nil:
+					return oc != nil && len(oc.orders) == 0
+				case tt.late:
+					return oc == nil
+				case tt.replace && !tt.restored:
+					return oc != nil && len(oc.orders) == 1 && oc.orders[0].done
+				}
+				return n == 1
+			})
+			if !tt.late {
+				onGame(gc, func() { c.OnGameEvent(gc, string(tt.outcome)) })
+			}
+
+			wait := 100 * time.Millisecond
+			if tt.want > 0 {
+				wait = time.Second
+			}
+			select {
+			case batch := <-x.placed:
+				if tt.want == 0 {
+					t.Fatalf("re-placed %+v", batch)
+				}
+				if len(batch) != 1 || batch[0].CountFP != fmt.Sprintf("%d.00", tt.want) || batch[0].Ticker != "BOS" {
+					t.Errorf("re-placed %+v, want %d BOS", batch, tt.want)
+				}
+			case <-time.After(wait):
+				if tt.want > 0 {
+					t.Fatal("nothing re-placed")
+				}
+			}
+		})
+	}
+}
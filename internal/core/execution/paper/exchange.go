@@ -0,0 +1,471 @@
+package paper
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/core/state/store"
+	"github.com/charleschow/hft-trading/internal/core/tracking"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+const (
+	sweepInterval   = 1 * time.Second
+	bookReadTimeout = 2 * time.Second
+)
+
+// Exchange is a simulated Kalshi exchange. It satisfies execution.OrderPlacer
+// and tracking.OrderPoller, so it can be dropped in wherever the real HTTP
+// client is used.
+//
+// Limit orders are matched against the LIVE top-of-book the engine already
+// maintains in GameContext.Tickers. Marketable orders fill immediately as
+// taker at the current ask; the remainder rests (GTC) and is matched as
+// maker at its limit price on every sweep until it fills, expires at
+// ExpirationTS, or is cancelled. IOC / FOK orders never rest.
+//
+// Like the Kalshi WS user channels, every trade is published as a
+// FillEvent and every change to an order as an OrderUpdateEvent, so the
+// execution fill ledger settles paper orders the same way as live ones.
+type Exchange struct {
+	games *store.GameStateStore
+	bus   *events.Bus
+
+	mu           sync.Mutex
+	orders       map[string]*order
+	seq          int64
+	balanceCents int
+
+	// outbox holds events raised under mu; flush publishes them once mu
+	// is released, since bus handlers may call back into the exchange.
+	outbox []events.Event
+}
+
+var (
+	_ execution.OrderPlacer = (*Exchange)(nil)
+	_ tracking.OrderPoller  = (*Exchange)(nil)
+)
+
+type order struct {
+	detail     kalshi_http.OrderDetail
+	ticker     string
+	limitCents int
+	expiresAt  time.Time // zero = good until cancelled
+}
+
+func NewExchange(games *store.GameStateStore, bus *events.Bus, startingBalanceCents int) *Exchange {
+	return &Exchange{
+		games:        games,
+		bus:          bus,
+		orders:       make(map[string]*order),
+		balanceCents: startingBalanceCents,
+	}
+}
+
+// Run sweeps resting orders against the book until ctx is cancelled.
+func (x *Exchange) Run(ctx context.Context) {
+	t := time.NewTicker(sweepInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			x.sweep()
+		}
+	}
+}
+
+func (x *Exchange) PlaceOrder(ctx context.Context, req kalshi_http.CreateOrderRequest) (*kalshi_http.CreateOrderResponse, error) {
+	detail, err := x.submit(req)
+	if err != nil {
+		return nil, err
+	}
+	var resp kalshi_http.CreateOrderResponse
+	resp.Order.OrderID = detail.OrderID
+	resp.Order.Status = detail.Status
+	return &resp, nil
+}
+
+func (x *Exchange) PlaceBatchOrders(ctx context.Context, req kalshi_http.BatchCreateOrdersRequest) (*kalshi_http.BatchCreateOrdersResponse, error) {
+	resp := &kalshi_http.BatchCreateOrdersResponse{
+		Orders: make([]kalshi_http.BatchCreateOrdersIndividualResponse, 0, len(req.Orders)),
+	}
+	for _, r := range req.Orders {
+		detail, err := x.submit(r)
+		if err != nil {
+			resp.Orders = append(resp.Orders, kalshi_http.BatchCreateOrdersIndividualResponse{
+				Error: &struct {
+					Message string `json:"message"`
+					Code    string `json:"code"`
+				}{Message: err.Error(), Code: "invalid_order"},
+			})
+			continue
+		}
+		d := detail
+		resp.Orders = append(resp.Orders, kalshi_http.BatchCreateOrdersIndividualResponse{Order: &d})
+	}
+	return resp, nil
+}
+
+// GetOrder returns the current simulated state of an order.
+func (x *Exchange) GetOrder(ctx context.Context, orderID string) (*kalshi_http.OrderDetail, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	o, ok := x.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("get order: status=404 body=paper order %s not found", orderID)
+	}
+	d := o.detail
+	return &d, nil
+}
+
+// ReadTokens reports an effectively unlimited read budget — the paper
+// exchange is not rate limited.
+func (x *Exchange) ReadTokens() float64 {
+	return math.MaxFloat64
+}
+
+// CancelOrder zeroes the remaining count of a resting order.
+func (x *Exchange) CancelOrder(ctx context.Context, orderID string) error {
+	defer x.flush()
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	o, ok := x.orders[orderID]
+	if !ok {
+		return fmt.Errorf("cancel failed: status=404")
+	}
+	if o.detail.Status == "resting" {
+		o.detail.RemainingCount = 0
+		o.detail.Status = "canceled"
+		x.changed(o)
+	}
+	return nil
+}
+
+// AmendOrder moves a resting order to a new limit. An amended price that
+// crosses the current ask fills immediately as taker, like a new order.
+func (x *Exchange) AmendOrder(ctx context.Context, orderID string, req kalshi_http.AmendOrderRequest) (*kalshi_http.OrderDetail, error) {
+	priceStr := req.YesPriceDollars
+	if req.Side == "no" {
+		priceStr = req.NoPriceDollars
+	}
+	limit := dollarsToCents(priceStr)
+	if limit < 1 || limit > 99 {
+		return nil, fmt.Errorf("invalid limit price %q", priceStr)
+	}
+	ask, haveBook := x.readAsk(req.Ticker, req.Side)
+
+	defer x.flush()
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	o, ok := x.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("amend rejected: status=404 body=paper order %s not found", orderID)
+	}
+	if o.detail.Status != "resting" {
+		return nil, fmt.Errorf("amend rejected: status=400 body=order is %s", o.detail.Status)
+	}
+	o.limitCents = limit
+	if o.detail.Side == "yes" {
+		o.detail.YesPrice, o.detail.NoPrice = limit, 100-limit
+	} else {
+		o.detail.NoPrice, o.detail.YesPrice = limit, 100-limit
+	}
+	if haveBook && ask <= limit {
+		x.fill(o, ask, o.detail.RemainingCount, false)
+	}
+	x.changed(o)
+	d := o.detail
+	return &d, nil
+}
+
+// DecreaseOrder shrinks a resting order's remaining count to reduceTo.
+func (x *Exchange) DecreaseOrder(ctx context.Context, orderID string, reduceTo int) (*kalshi_http.OrderDetail, error) {
+	defer x.flush()
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	o, ok := x.orders[orderID]
+	if !ok {
+		return nil, fmt.Errorf("decrease rejected: status=404 body=paper order %s not found", orderID)
+	}
+	if o.detail.Status == "resting" && reduceTo < o.detail.RemainingCount {
+		o.detail.RemainingCount = max(reduceTo, 0)
+		if o.detail.RemainingCount == 0 {
+			o.detail.Status = "canceled"
+		}
+		x.changed(o)
+	}
+	d := o.detail
+	return &d, nil
+}
+
+// GetBalance returns the simulated cash balance in cents.
+func (x *Exchange) GetBalance(ctx context.Context) (int, error) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.balanceCents, nil
+}
+
+func (x *Exchange) submit(req kalshi_http.CreateOrderRequest) (kalshi_http.OrderDetail, error) {
+	if req.Action != "buy" {
+		return kalshi_http.OrderDetail{}, fmt.Errorf("paper exchange supports buy orders only (got %q)", req.Action)
+	}
+	count, err := parseCount(req.CountFP)
+	if err != nil {
+		return kalshi_http.OrderDetail{}, err
+	}
+	priceStr := req.YesPriceDollars
+	if req.Side == "no" {
+		priceStr = req.NoPriceDollars
+	}
+	limit := dollarsToCents(priceStr)
+	if limit < 1 || limit > 99 {
+		return kalshi_http.OrderDetail{}, fmt.Errorf("invalid limit price %q", priceStr)
+	}
+
+	ask, haveBook := x.readAsk(req.Ticker, req.Side)
+
+	defer x.flush()
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	x.seq++
+	o := &order{
+		ticker:     req.Ticker,
+		limitCents: limit,
+		detail: kalshi_http.OrderDetail{
+			OrderID:        "paper-" + strconv.FormatInt(time.Now().UnixNano(), 36) + "-" + strconv.FormatInt(x.seq, 36),
+			ClientOrderID:  req.ClientID,
+			Ticker:         req.Ticker,
+			Status:         "resting",
+			Side:           req.Side,
+			RemainingCount: count,
+		},
+	}
+	if req.Side == "yes" {
+		o.detail.YesPrice = limit
+		o.detail.NoPrice = 100 - limit
+	} else {
+		o.detail.NoPrice = limit
+		o.detail.YesPrice = 100 - limit
+	}
+	if req.ExpirationTS > 0 {
+		o.expiresAt = time.Unix(req.ExpirationTS, 0)
+	}
+
+	// Top-of-book only: a marketable order takes its full size at the
+	// current ask. Anything unfilled rests unless the TIF forbids it.
+	if haveBook && ask <= limit {
+		x.fill(o, ask, count, false)
+	}
+	if o.detail.RemainingCount > 0 && (req.TimeInForce == "fill_or_kill" || req.TimeInForce == "immediate_or_cancel") {
+		o.detail.RemainingCount = 0
+		o.detail.Status = "canceled"
+	}
+	if o.detail.FillCount > 0 || o.detail.Status != "resting" {
+		x.changed(o)
+	}
+
+	x.orders[o.detail.OrderID] = o
+	telemetry.Debugf("[PAPER] %s %s %s %d @ %d¢ (ask %d¢) -> %s [%d/%d]",
+		o.detail.OrderID, req.Ticker, req.Side, count, limit, ask, o.detail.Status,
+		o.detail.FillCount, o.detail.FillCount+o.detail.RemainingCount)
+	return o.detail, nil
+}
+
+// fill executes n contracts of o at priceCents. Caller must hold mu.
+func (x *Exchange) fill(o *order, priceCents, n int, maker bool) {
+	if n > o.detail.RemainingCount {
+		n = o.detail.RemainingCount
+	}
+	if n <= 0 {
+		return
+	}
+	cost := priceCents * n
+	if maker {
+		fee := execution.FeeCents(false, n, priceCents)
+		o.detail.MakerFillCost += cost
+		o.detail.MakerFees += fee
+		x.balanceCents -= cost + fee
+	} else {
+		fee := execution.FeeCents(true, n, priceCents)
+		o.detail.TakerFillCost += cost
+		o.detail.TakerFees += fee
+		x.balanceCents -= cost + fee
+	}
+	o.detail.FillCount += n
+	o.detail.RemainingCount -= n
+	if o.detail.RemainingCount == 0 {
+		o.detail.Status = "executed"
+	}
+
+	x.seq++
+	x.outbox = append(x.outbox, events.Event{
+		ID:        o.ticker,
+		Type:      events.EventFill,
+		Timestamp: time.Now(),
+		Payload: events.FillEvent{
+			TradeID:       "paper-trade-" + strconv.FormatInt(x.seq, 36),
+			OrderID:       o.detail.OrderID,
+			ClientOrderID: o.detail.ClientOrderID,
+			Ticker:        o.ticker,
+			Side:          o.detail.Side,
+			Action:        "buy",
+			PriceCents:    priceCents,
+			Count:         n,
+			IsTaker:       !maker,
+			Ts:            time.Now(),
+		},
+	})
+}
+
+// changed queues an order update with o's cumulative state. Caller must
+// hold mu.
+func (x *Exchange) changed(o *order) {
+	x.outbox = append(x.outbox, events.Event{
+		ID:        o.ticker,
+		Type:      events.EventOrderUpdate,
+		Timestamp: time.Now(),
+		Payload: events.OrderUpdateEvent{
+			OrderID:        o.detail.OrderID,
+			ClientOrderID:  o.detail.ClientOrderID,
+			Ticker:         o.ticker,
+			Side:           o.detail.Side,
+			Status:         o.detail.Status,
+			FillCount:      o.detail.FillCount,
+			RemainingCount: o.detail.RemainingCount,
+			FillCostCents:  o.detail.TakerFillCost + o.detail.MakerFillCost,
+			FeesCents:      o.detail.TakerFees + o.detail.MakerFees,
+		},
+	})
+}
+
+// flush publishes the queued events. Must not be called with mu held.
+func (x *Exchange) flush() {
+	x.mu.Lock()
+	out := x.outbox
+	x.outbox = nil
+	x.mu.Unlock()
+
+	if x.bus == nil {
+		return
+	}
+	for _, evt := range out {
+		x.bus.Publish(evt)
+	}
+}
+
+// sweep expires stale resting orders and fills those whose limit the
+// book has crossed since the last pass.
+func (x *Exchange) sweep() {
+	defer x.flush()
+
+	type pending struct {
+		id, ticker, side string
+	}
+
+	now := time.Now()
+	var open []pending
+
+	x.mu.Lock()
+	for id, o := range x.orders {
+		if o.detail.Status != "resting" {
+			continue
+		}
+		if !o.expiresAt.IsZero() && now.After(o.expiresAt) {
+			o.detail.RemainingCount = 0
+			o.detail.Status = "canceled"
+			x.changed(o)
+			continue
+		}
+		open = append(open, pending{id: id, ticker: o.ticker, side: o.detail.Side})
+	}
+	x.mu.Unlock()
+
+	for _, p := range open {
+		ask, ok := x.readAsk(p.ticker, p.side)
+		if !ok {
+			continue
+		}
+		x.mu.Lock()
+		if o := x.orders[p.id]; o != nil && o.detail.Status == "resting" && ask <= o.limitCents {
+			x.fill(o, o.limitCents, o.detail.RemainingCount, true)
+			x.changed(o)
+			telemetry.Debugf("[PAPER] %s resting %s %s filled @ %d¢", p.id, p.ticker, p.side, o.limitCents)
+		}
+		x.mu.Unlock()
+	}
+}
+
+// readAsk fetches the current ask (cents) for one side of a ticker from the
+// owning GameContext. Returns false when no LIVE price is available,
+// including the 100/100 sentinel written while the Kalshi WS is down.
+func (x *Exchange) readAsk(ticker, side string) (int, bool) {
+	gcs := x.games.ByTicker(ticker)
+	if len(gcs) == 0 {
+		return 0, false
+	}
+	gc := gcs[0]
+
+	ch := make(chan *game.TickerData, 1)
+	gc.Send(func() {
+		if !gc.KalshiConnected {
+			ch <- nil
+			return
+		}
+		if td, ok := gc.Tickers[ticker]; ok {
+			cp := *td
+			ch <- &cp
+			return
+		}
+		ch <- nil
+	})
+
+	var td *game.TickerData
+	select {
+	case td = <-ch:
+	case <-time.After(bookReadTimeout):
+		telemetry.Warnf("[PAPER] timeout reading book for %s", ticker)
+		return 0, false
+	}
+	if td == nil {
+		return 0, false
+	}
+
+	ask := td.YesAsk
+	if side == "no" {
+		ask = td.NoAsk
+	}
+	if ask <= 0 || ask >= 100 {
+		return 0, false
+	}
+	return int(math.Ceil(ask)), true
+}
+
+func parseCount(s string) (int, error) {
+	if s == "" {
+		return 1, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("invalid count_fp %q", s)
+	}
+	return int(v), nil
+}
+
+func dollarsToCents(s string) int {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(math.Round(v * 100))
+}
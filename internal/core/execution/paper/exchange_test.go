@@ -0,0 +1,248 @@
+package paper
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+	"github.com/charleschow/hft-trading/internal/core/state/store"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+const ticker = "KXNHLGAME-26OCT16TORBOS-BOS"
+
+// testExchange is an Exchange over one hockey game quoting ticker, with
+// every published event recorded.
+type testExchange struct {
+	*Exchange
+	gc *game.GameContext
+
+	mu     sync.Mutex
+	fills  []events.FillEvent
+	update []events.OrderUpdateEvent
+}
+
+func newTestExchange(t *testing.T, balance int) *testExchange {
+	t.Helper()
+	gc := game.NewGameContext(events.SportHockey, "NHL", "1", hockeyState.New("1", "NHL", "Boston", "Toronto"))
+	t.Cleanup(gc.Close)
+	games := store.New()
+	games.Put(gc)
+	games.RegisterTicker(ticker, gc)
+
+	bus := events.NewBus()
+	tx := &testExchange{Exchange: NewExchange(games, bus, balance), gc: gc}
+	bus.Subscribe(events.EventFill, func(e events.Event) error {
+		tx.mu.Lock()
+		tx.fills = append(tx.fills, e.Payload.(events.FillEvent))
+		tx.mu.Unlock()
+		return nil
+	})
+	bus.Subscribe(events.EventOrderUpdate, func(e events.Event) error {
+		tx.mu.Lock()
+		tx.update = append(tx.update, e.Payload.(events.OrderUpdateEvent))
+		tx.mu.Unlock()
+		return nil
+	})
+	return tx
+}
+
+// quote sets the book; connected false writes the disconnect sentinel
+// state the engine leaves while the Kalshi WS is down.
+func (tx *testExchange) quote(yesAsk, noAsk float64, connected bool) {
+	tx.gc.SendWait(func() {
+		tx.gc.KalshiConnected = connected
+		tx.gc.UpdateTicker(&game.TickerData{Ticker: ticker, YesAsk: yesAsk, NoAsk: noAsk, UpdatedAt: time.Now()})
+	})
+}
+
+func buy(side, price, count string) kalshi_http.CreateOrderRequest {
+	req := kalshi_http.CreateOrderRequest{Ticker: ticker, Side: side, Action: "buy", CountFP: count}
+	if side == "yes" {
+		req.YesPriceDollars = price
+	} else {
+		req.NoPriceDollars = price
+	}
+	return req
+}
+
+func TestSubmit(t *testing.T) {
+	ioc := func(r kalshi_http.CreateOrderRequest) kalshi_http.CreateOrderRequest {
+		r.TimeInForce = "immediate_or_cancel"
+		return r
+	}
+	tests := []struct {
+		name       string
+		yesAsk     float64
+		noAsk      float64
+		down       bool
+		req        kalshi_http.CreateOrderRequest
+		wantErr    bool
+		wantStatus string
+		wantFilled int
+		wantCost   int // taker fill cost
+		wantFills  int
+		wantUpdate int
+	}{
+		{name: "marketable fills at the ask", yesAsk: 40, noAsk: 62, req: buy("yes", "0.45", "5.00"),
+			wantStatus: "executed", wantFilled: 5, wantCost: 200, wantFills: 1, wantUpdate: 1},
+		{name: "no side takes the no ask", yesAsk: 40, noAsk: 62, req: buy("no", "0.65", "2.00"),
+			wantStatus: "executed", wantFilled: 2, wantCost: 124, wantFills: 1, wantUpdate: 1},
+		{name: "below the ask rests", yesAsk: 50, noAsk: 52, req: buy("yes", "0.45", "5.00"),
+			wantStatus: "resting"},
+		{name: "ioc below the ask cancels", yesAsk: 50, noAsk: 52, req: ioc(buy("yes", "0.45", "5.00")),
+			wantStatus: "canceled", wantUpdate: 1},
+		{name: "disconnected book never fills", yesAsk: 40, noAsk: 62, down: true, req: buy("yes", "0.45", "5.00"),
+			wantStatus: "resting"},
+		{name: "reset book never fills", yesAsk: 100, noAsk: 100, req: buy("yes", "0.99", "5.00"),
+			wantStatus: "resting"},
+		{name: "count defaults to one", yesAsk: 40, noAsk: 62, req: buy("yes", "0.45", ""),
+			wantStatus: "executed", wantFilled: 1, wantCost: 40, wantFills: 1, wantUpdate: 1},
+		{name: "price out of range", yesAsk: 40, noAsk: 62, req: buy("yes", "1.00", "1.00"), wantErr: true},
+		{name: "bad count", yesAsk: 40, noAsk: 62, req: buy("yes", "0.45", "0.5"), wantErr: true},
+		{name: "sell", yesAsk: 40, noAsk: 62, req: kalshi_http.CreateOrderRequest{Ticker: ticker, Side: "yes", Action: "sell", YesPriceDollars: "0.45"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newTestExchange(t, 10_000)
+			tx.quote(tt.yesAsk, tt.noAsk, !tt.down)
+
+			d, err := tx.submit(tt.req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Status != tt.wantStatus || d.FillCount != tt.wantFilled || d.TakerFillCost != tt.wantCost {
+				t.Errorf("status %s filled %d cost %d, want %s %d %d",
+					d.Status, d.FillCount, d.TakerFillCost, tt.wantStatus, tt.wantFilled, tt.wantCost)
+			}
+			if len(tx.fills) != tt.wantFills || len(tx.update) != tt.wantUpdate {
+				t.Errorf("%d fills %d updates published, want %d %d", len(tx.fills), len(tx.update), tt.wantFills, tt.wantUpdate)
+			}
+			balance, _ := tx.GetBalance(context.Background())
+			if want := 10_000 - d.TakerFillCost - d.TakerFees; balance != want {
+				t.Errorf("balance %d, want %d", balance, want)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	tests := []struct {
+		name       string
+		yesAsk     float64
+		expired    bool
+		wantStatus string
+		wantMaker  int // maker fill cost
+	}{
+		{"ask still above the limit", 50, false, "resting", 0},
+		{"ask crossed fills as maker at the limit", 44, false, "executed", 3 * 45},
+		{"expired order cancels", 44, true, "canceled", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newTestExchange(t, 10_000)
+			tx.quote(50, 52, true)
+			req := buy("yes", "0.45", "3.00")
+			if tt.expired {
+				req.ExpirationTS = time.Now().Add(-time.Second).Unix()
+			}
+			d, err := tx.submit(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tx.quote(tt.yesAsk, 52, true)
+			tx.sweep()
+
+			got, _ := tx.GetOrder(context.Background(), d.OrderID)
+			if got.Status != tt.wantStatus || got.MakerFillCost != tt.wantMaker {
+				t.Errorf("status %s maker cost %d, want %s %d", got.Status, got.MakerFillCost, tt.wantStatus, tt.wantMaker)
+			}
+			if tt.wantMaker > 0 {
+				if len(tx.fills) != 1 || tx.fills[0].IsTaker || tx.fills[0].PriceCents != 45 {
+					t.Errorf("fills %+v, want one maker fill at 45", tx.fills)
+				}
+				if fee := execution.FeeCents(false, 3, 45); got.MakerFees != fee {
+					t.Errorf("maker fees %d, want %d", got.MakerFees, fee)
+				}
+			}
+		})
+	}
+}
+
+func TestRestingOrderChanges(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		change     func(tx *testExchange, id string) error
+		wantStatus string
+		wantFilled int
+		wantLeft   int
+	}{
+		{"cancel", func(tx *testExchange, id string) error {
+			return tx.CancelOrder(ctx, id)
+		}, "canceled", 0, 0},
+		{"decrease", func(tx *testExchange, id string) error {
+			_, err := tx.DecreaseOrder(ctx, id, 2)
+			return err
+		}, "resting", 0, 2},
+		{"decrease to zero cancels", func(tx *testExchange, id string) error {
+			_, err := tx.DecreaseOrder(ctx, id, 0)
+			return err
+		}, "canceled", 0, 0},
+		{"amend under the ask rests", func(tx *testExchange, id string) error {
+			_, err := tx.AmendOrder(ctx, id, kalshi_http.AmendOrderRequest{Ticker: ticker, Side: "yes", Action: "buy", YesPriceDollars: "0.48"})
+			return err
+		}, "resting", 0, 5},
+		{"amend across the ask fills", func(tx *testExchange, id string) error {
+			_, err := tx.AmendOrder(ctx, id, kalshi_http.AmendOrderRequest{Ticker: ticker, Side: "yes", Action: "buy", YesPriceDollars: "0.52"})
+			return err
+		}, "executed", 5, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := newTestExchange(t, 10_000)
+			tx.quote(50, 52, true)
+			d, err := tx.submit(buy("yes", "0.45", "5.00"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.change(tx, d.OrderID); err != nil {
+				t.Fatal(err)
+			}
+
+			got, _ := tx.GetOrder(ctx, d.OrderID)
+			if got.Status != tt.wantStatus || got.FillCount != tt.wantFilled || got.RemainingCount != tt.wantLeft {
+				t.Errorf("status %s filled %d left %d, want %s %d %d",
+					got.Status, got.FillCount, got.RemainingCount, tt.wantStatus, tt.wantFilled, tt.wantLeft)
+			}
+			if n := len(tx.update); n != 1 {
+				t.Fatalf("%d updates published, want 1", n)
+			}
+			if u := tx.update[0]; u.FillCount != got.FillCount || u.RemainingCount != got.RemainingCount || u.Status != got.Status {
+				t.Errorf("update %+v does not match the order", u)
+			}
+		})
+	}
+
+	tx := newTestExchange(t, 10_000)
+	tx.quote(40, 62, true)
+	d, _ := tx.submit(buy("yes", "0.45", "1.00"))
+	if _, err := tx.AmendOrder(ctx, d.OrderID, kalshi_http.AmendOrderRequest{Ticker: ticker, Side: "yes", Action: "buy", YesPriceDollars: "0.50"}); err == nil {
+		t.Error("amended an executed order")
+	}
+	if err := tx.CancelOrder(ctx, "missing"); err == nil {
+		t.Error("canceled a missing order")
+	}
+}
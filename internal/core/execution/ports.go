@@ -6,9 +6,21 @@ import (
 	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
 )
 
-// OrderPlacer abstracts the ability to place orders on an exchange.
-// Satisfied by *kalshi_http.Client.
+// OrderPlacer abstracts the ability to place, reprice and cancel orders on
+// an exchange. Satisfied by *kalshi_http.Client.
 type OrderPlacer interface {
 	PlaceOrder(ctx context.Context, req kalshi_http.CreateOrderRequest) (*kalshi_http.CreateOrderResponse, error)
 	PlaceBatchOrders(ctx context.Context, req kalshi_http.BatchCreateOrdersRequest) (*kalshi_http.BatchCreateOrdersResponse, error)
+	CancelOrder(ctx context.Context, orderID string) error
+	AmendOrder(ctx context.Context, orderID string, req kalshi_http.AmendOrderRequest) (*kalshi_http.OrderDetail, error)
+	DecreaseOrder(ctx context.Context, orderID string, reduceTo int) (*kalshi_http.OrderDetail, error)
+}
+
+// OrderGrouper manages exchange-side order groups that cap how many
+// contracts their orders can match in a rolling window. Satisfied by
+// *kalshi_http.Client; the paper exchange has no groups.
+type OrderGrouper interface {
+	CreateOrderGroup(ctx context.Context, contractsLimit int) (string, error)
+	ResetOrderGroup(ctx context.Context, groupID string) error
+	DeleteOrderGroup(ctx context.Context, groupID string) error
 }
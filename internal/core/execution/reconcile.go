@@ -0,0 +1,215 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// AccountReader is satisfied by *kalshi_http.Client. Reconcile uses it to
+// rebuild exposure from the exchange after a restart.
+type AccountReader interface {
+	GetPositions(ctx context.Context) (*kalshi_http.PositionResponse, error)
+	GetFills(ctx context.Context, minTs int64) ([]kalshi_http.Fill, error)
+	GetOrders(ctx context.Context, status string) ([]kalshi_http.OrderDetail, error)
+}
+
+// reconcileLookback bounds the fill history pulled on startup. Every game
+// a process trades expires well within it.
+const reconcileLookback = 48 * time.Hour
+
+// OrphanPosition is an open Kalshi position on a ticker no game in this
+// process claims.
+type OrphanPosition struct {
+	Ticker        string
+	Position      int // > 0 YES contracts, < 0 NO contracts
+	ExposureCents int
+}
+
+// ReconcileReport summarises what Reconcile restored.
+type ReconcileReport struct {
+	Games      int
+	Orders     int
+	Resting    int
+	SpendCents int
+	Orphans    []OrphanPosition
+}
+
+// orderFills aggregates the buy fills of one order.
+type orderFills struct {
+	ticker    string
+	side      string
+	count     int
+	costCents int
+}
+
+// Reconcile restores what a fresh process forgot: each game's Fills, the
+// lanes' sport spend, and the per-score dedup keys. Fills and resting
+// orders are mapped to games through the store's ticker index; resting
+// orders are handed to the fill ledger so their later fills are tracked.
+// Positions on tickers no game claims are reported as orphaned when owns
+// says they belong to this process (other sports share the account).
+//
+// Must run after InitializeGames and before the Kalshi WS connects.
+func (s *Service) Reconcile(ctx context.Context, acct AccountReader, owns func(ticker string) bool) (ReconcileReport, error) {
+	var rep ReconcileReport
+
+	fills, err := acct.GetFills(ctx, time.Now().Add(-reconcileLookback).Unix())
+	if err != nil {
+		return rep, fmt.Errorf("get fills: %w", err)
+	}
+	resting, err := acct.GetOrders(ctx, "resting")
+	if err != nil {
+		return rep, fmt.Errorf("get resting orders: %w", err)
+	}
+	positions, err := acct.GetPositions(ctx)
+	if err != nil {
+		return rep, fmt.Errorf("get positions: %w", err)
+	}
+
+	byOrder := make(map[string]*orderFills)
+	for _, f := range fills {
+		if f.Action != "" && f.Action != "buy" {
+			continue
+		}
+		of := byOrder[f.OrderID]
+		if of == nil {
+			of = &orderFills{ticker: f.Ticker, side: f.Side}
+			byOrder[f.OrderID] = of
+		}
+		price := f.YesPrice
+		if f.Side == "no" {
+			price = f.NoPrice
+		}
+		of.count += f.Count
+		of.costCents += f.Count*price + FeeCents(f.IsTaker, f.Count, price)
+	}
+
+	games := make(map[*game.GameContext]bool)
+	covered := make(map[string]bool) // tickers explained by fills or orders
+
+	// Resting orders: reserve their full size and let the ledger follow them.
+	for _, o := range resting {
+		gc := s.gameForTicker(o.Ticker)
+		if gc == nil {
+			continue
+		}
+		lane := s.router.Route(gc.Sport, gc.League)
+		limit := o.YesPrice
+		if o.Side == "no" {
+			limit = o.NoPrice
+		}
+		total := o.FillCount + o.RemainingCount
+		reserved := limit * total
+
+		var filled, cost int
+		if of := byOrder[o.OrderID]; of != nil {
+			filled, cost = of.count, of.costCents
+			delete(byOrder, o.OrderID)
+		}
+		s.restoreOrder(lane, o.OrderID, o.Ticker, o.Side, max(reserved, cost))
+		s.fills.track(o.OrderID, &trackedOrder{
+			gc:            gc,
+			lane:          lane,
+			ticker:        o.Ticker,
+			side:          o.Side,
+			clientID:      o.ClientOrderID,
+			limitCents:    limit,
+			reservedCents: reserved,
+			total:         total,
+			filled:        filled,
+			costCents:     cost,
+		}, false)
+
+		games[gc] = true
+		covered[o.Ticker] = true
+		rep.Resting++
+		rep.SpendCents += max(reserved, cost)
+	}
+
+	// Completed orders: record the fills and charge their real cost.
+	for orderID, of := range byOrder {
+		gc := s.gameForTicker(of.ticker)
+		if gc == nil {
+			continue
+		}
+		f := game.Fill{OrderID: orderID, Ticker: of.ticker, Side: of.side, Count: of.count, CostCents: of.costCents}
+		gc.Send(func() { gc.RecordFill(f) })
+		s.restoreOrder(s.router.Route(gc.Sport, gc.League), orderID, of.ticker, of.side, of.costCents)
+
+		games[gc] = true
+		covered[of.ticker] = true
+		rep.Orders++
+		rep.SpendCents += of.costCents
+	}
+
+	// Positions: anything older than the fill window is seeded from the
+	// position's exposure; anything no game claims is orphaned.
+	for _, p := range positions.MarketPositions {
+		if p.Position == 0 {
+			continue
+		}
+		gc := s.gameForTicker(p.Ticker)
+		if gc == nil {
+			if !owns(p.Ticker) {
+				continue
+			}
+			rep.Orphans = append(rep.Orphans, OrphanPosition{
+				Ticker: p.Ticker, Position: p.Position, ExposureCents: p.MarketExposure,
+			})
+			continue
+		}
+		if covered[p.Ticker] {
+			continue
+		}
+		side, count := "yes", p.Position
+		if count < 0 {
+			side, count = "no", -count
+		}
+		f := game.Fill{OrderID: "position:" + p.Ticker, Ticker: p.Ticker, Side: side, Count: count, CostCents: p.MarketExposure}
+		gc.Send(func() { gc.RecordFill(f) })
+		if lane := s.router.Route(gc.Sport, gc.League); lane != nil {
+			lane.AdjustSpend(p.MarketExposure)
+		}
+
+		games[gc] = true
+		rep.SpendCents += p.MarketExposure
+	}
+	rep.Games = len(games)
+
+	telemetry.Infof("[RECONCILE] restored %d filled + %d resting orders across %d games ($%.2f)",
+		rep.Orders, rep.Resting, rep.Games, float64(rep.SpendCents)/100.0)
+	for _, o := range rep.Orphans {
+		telemetry.Warnf("[RECONCILE] orphaned position %s: %+d contracts, exposure $%.2f",
+			o.Ticker, o.Position, float64(o.ExposureCents)/100.0)
+	}
+	return rep, nil
+}
+
+// gameForTicker returns the game a ticker is registered to, or nil.
+func (s *Service) gameForTicker(ticker string) *game.GameContext {
+	gcs := s.gameStore.ByTicker(ticker)
+	if len(gcs) == 0 {
+		return nil
+	}
+	return gcs[0]
+}
+
+// restoreOrder charges a pre-restart order to its lane. When the tracking
+// store knows the score it was placed at, the dedup key is restored too,
+// so the same score cannot be traded twice across a restart.
+func (s *Service) restoreOrder(lane *lanes.Lane, orderID, ticker, side string, cents int) {
+	if lane == nil {
+		return
+	}
+	if home, away, ok := s.tracker.ScoreForOrder(orderID); ok {
+		lane.RecordOrder(ticker, side, home, away, cents)
+		return
+	}
+	lane.AdjustSpend(cents)
+}
@@ -0,0 +1,167 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+)
+
+// fakeAccount is an AccountReader serving fixed exchange state.
+type fakeAccount struct {
+	fills     []kalshi_http.Fill
+	resting   []kalshi_http.OrderDetail
+	positions string // market_positions JSON
+	err       error
+}
+
+func (a *fakeAccount) GetFills(context.Context, int64) ([]kalshi_http.Fill, error) {
+	return a.fills, a.err
+}
+
+func (a *fakeAccount) GetOrders(context.Context, string) ([]kalshi_http.OrderDetail, error) {
+	return a.resting, nil
+}
+
+func (a *fakeAccount) GetPositions(context.Context) (*kalshi_http.PositionResponse, error) {
+	var p kalshi_http.PositionResponse
+	if a.positions != "" {
+		if err := json.Unmarshal([]byte(`{"market_positions":`+a.positions+`}`), &p); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+func TestReconcile(t *testing.T) {
+	const (
+		bos = "KXNHLGAME-26OCT16TORBOS-BOS"
+		tor = "KXNHLGAME-26OCT16TORBOS-TOR"
+	)
+	buyFill := func(order, ticker, side string, n, price int) kalshi_http.Fill {
+		f := kalshi_http.Fill{OrderID: order, Ticker: ticker, Side: side, Action: "buy", Count: n, IsTaker: true}
+		if side == "yes" {
+			f.YesPrice, f.NoPrice = price, 100-price
+		} else {
+			f.NoPrice, f.YesPrice = price, 100-price
+		}
+		return f
+	}
+	cost := func(n, price int) int { return n*price + FeeCents(true, n, price) }
+
+	tests := []struct {
+		name          string
+		acct          fakeAccount
+		wantOrders    int
+		wantResting   int
+		wantSpend     int
+		wantOrphans   []string
+		wantContracts int // filled or resting on the game
+	}{
+		{
+			name:          "fills of one order aggregate",
+			acct:          fakeAccount{fills: []kalshi_http.Fill{buyFill("o1", bos, "yes", 3, 40), buyFill("o1", bos, "yes", 2, 40)}},
+			wantOrders:    1,
+			wantSpend:     cost(3, 40) + cost(2, 40),
+			wantContracts: 5,
+		},
+		{
+			name: "sell fills ignored",
+			acct: fakeAccount{fills: []kalshi_http.Fill{
+				buyFill("o1", bos, "yes", 3, 40),
+				{OrderID: "o2", Ticker: bos, Side: "yes", Action: "sell", Count: 3, YesPrice: 60},
+			}},
+			wantOrders:    1,
+			wantSpend:     cost(3, 40),
+			wantContracts: 3,
+		},
+		{
+			name:       "fills on other games ignored",
+			acct:       fakeAccount{fills: []kalshi_http.Fill{buyFill("o1", "KXNHLGAME-26OCT16MTLOTT-OTT", "yes", 3, 40)}},
+			wantOrders: 0,
+		},
+		{
+			name: "resting order reserves its full size",
+			acct: fakeAccount{
+				fills:   []kalshi_http.Fill{buyFill("o3", tor, "no", 1, 45)},
+				resting: []kalshi_http.OrderDetail{{OrderID: "o3", Ticker: tor, Side: "no", NoPrice: 45, YesPrice: 55, FillCount: 1, RemainingCount: 4}},
+			},
+			wantResting:   1,
+			wantSpend:     45 * 5,
+			wantContracts: 5,
+		},
+		{
+			name: "position older than the fills is seeded",
+			acct: fakeAccount{
+				positions: `[{"ticker":"` + tor + `","position":-4,"market_exposure":200}]`,
+			},
+			wantSpend:     200,
+			wantContracts: 4,
+		},
+		{
+			name: "position explained by fills is not counted twice",
+			acct: fakeAccount{
+				fills:     []kalshi_http.Fill{buyFill("o1", bos, "yes", 3, 40)},
+				positions: `[{"ticker":"` + bos + `","position":3,"market_exposure":120}]`,
+			},
+			wantOrders:    1,
+			wantSpend:     cost(3, 40),
+			wantContracts: 3,
+		},
+		{
+			name: "unclaimed positions orphaned only when owned",
+			acct: fakeAccount{
+				positions: `[{"ticker":"KXNHLGAME-OLD-BOS","position":2,"market_exposure":90},` +
+					`{"ticker":"KXNBAGAME-OLD-LAL","position":5,"market_exposure":250},` +
+					`{"ticker":"KXNHLGAME-FLAT-BOS","position":0,"market_exposure":0}]`,
+			},
+			wantOrphans: []string{"KXNHLGAME-OLD-BOS"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lane := lanes.NewLane(0, 1_000_000)
+			s := newTestService(newFakeExchange(), lane)
+			gc := newTestGame(t)
+			s.gameStore.Put(gc)
+			s.gameStore.RegisterTicker(bos, gc)
+			s.gameStore.RegisterTicker(tor, gc)
+
+			owns := func(ticker string) bool { return strings.HasPrefix(ticker, "KXNHL") }
+			rep, err := s.Reconcile(context.Background(), &tt.acct, owns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rep.Orders != tt.wantOrders || rep.Resting != tt.wantResting || rep.SpendCents != tt.wantSpend {
+				t.Errorf("orders %d resting %d spend %d, want %d %d %d",
+					rep.Orders, rep.Resting, rep.SpendCents, tt.wantOrders, tt.wantResting, tt.wantSpend)
+			}
+			if got := lane.SportSpent(); got != tt.wantSpend {
+				t.Errorf("lane spent %d, want %d", got, tt.wantSpend)
+			}
+			var orphans []string
+			for _, o := range rep.Orphans {
+				orphans = append(orphans, o.Ticker)
+			}
+			if !slices.Equal(orphans, tt.wantOrphans) {
+				t.Errorf("orphans %v, want %v", orphans, tt.wantOrphans)
+			}
+
+			var contracts int
+			onGame(gc, func() { contracts = gc.TotalContracts() })
+			if contracts != tt.wantContracts {
+				t.Errorf("game holds %d contracts, want %d", contracts, tt.wantContracts)
+			}
+		})
+	}
+
+	s := newTestService(newFakeExchange(), lanes.NewLane(0, 0))
+	if _, err := s.Reconcile(context.Background(), &fakeAccount{err: errors.New("503")}, nil); err == nil {
+		t.Error("reconciled without fills")
+	}
+}
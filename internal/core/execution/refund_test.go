@@ -0,0 +1,89 @@
+package execution
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestRefund(t *testing.T) {
+	// Three approved orders reserving 100¢, 40¢ and 90¢.
+	intents := []events.OrderIntent{
+		{Sport: events.SportHockey, League: "NHL", GameID: "1", Ticker: "BOS", Side: "yes", LimitPct: 50, Count: 2},
+		{Sport: events.SportHockey, League: "NHL", GameID: "1", Ticker: "BOS", Side: "no", LimitPct: 40.9, Count: 1},
+		{Sport: events.SportHockey, League: "NHL", GameID: "1", Ticker: "NYR", Side: "yes", LimitPct: 30, Count: 3},
+	}
+	tests := []struct {
+		name      string
+		resp      string // batch response JSON, "" to rest every order
+		err       error
+		halted    bool
+		wantSpent int
+	}{
+		{name: "all resting", wantSpent: 230},
+		{name: "batch failed", err: errors.New("502"), wantSpent: 0},
+		{name: "halted", halted: true, wantSpent: 0},
+		{name: "empty response", resp: `{"orders": []}`, wantSpent: 0},
+		{
+			name: "short response",
+			resp: `{"orders": [
+				{"order": {"order_id": "o1", "status": "resting", "remaining_count": 2}}
+			]}`,
+			wantSpent: 100,
+		},
+		{
+			name: "one rejected",
+			resp: `{"orders": [
+				{"order": {"order_id": "o1", "status": "resting", "remaining_count": 2}},
+				{"error": {"code": "insufficient_balance", "message": "insufficient balance"}},
+				{"order": {"order_id": "o3", "status": "resting", "remaining_count": 3}}
+			]}`,
+			wantSpent: 190,
+		},
+		{
+			name: "nil order",
+			resp: `{"orders": [
+				{"order": {"order_id": "o1", "status": "resting", "remaining_count": 2}},
+				{"order": {"order_id": "o2", "status": "resting", "remaining_count": 1}},
+				{}
+			]}`,
+			wantSpent: 140,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lane := lanes.NewLane(0, 1_000_000)
+			x := newFakeExchange()
+			if tt.resp != "" || tt.err != nil {
+				x.respond = func([]kalshi_http.CreateOrderRequest) (*kalshi_http.BatchCreateOrdersResponse, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					var resp kalshi_http.BatchCreateOrdersResponse
+					if err := json.Unmarshal([]byte(tt.resp), &resp); err != nil {
+						t.Fatal(err)
+					}
+					return &resp, nil
+				}
+			}
+			s := newTestService(x, lane)
+			gc := newTestGame(t)
+			s.gameStore.Put(gc)
+			s.halted.Store(tt.halted)
+
+			for _, intent := range intents {
+				lane.RecordOrder(intent.Ticker, intent.Side, 0, 0, intentCents(intent))
+			}
+			s.placeBatchOrder(intents, []int{-1, -1, -1}, time.Time{}, 60)
+
+			if got := lane.SportSpent(); got != tt.wantSpent {
+				t.Errorf("lane spent %d, want %d", got, tt.wantSpent)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/core/state/trading"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// requoteTimeout bounds one amend or decrease round trip.
+const requoteTimeout = 5 * time.Second
+
+// requote moves the game's resting orders to the strategy's current
+// target prices. Orders whose target fell below 1¢ are decreased to zero;
+// the rest are amended once the target has moved by the sport's minimum.
+//
+// Runs on the game's goroutine (onOrderIntent is invoked synchronously
+// from it). Each HTTP call runs on its own goroutine and the order is
+// marked pending until it returns, so one order never has two in flight.
+func (s *Service) requote(gc *game.GameContext, intents []events.OrderIntent) {
+	minMove := s.router.RequoteMinMove(gc.Sport)
+	if minMove <= 0 || gc.Orders.OpenCount() == 0 {
+		return
+	}
+	lane := s.router.Route(gc.Sport, gc.League)
+	spent := gc.TotalExposureCents()
+
+	for _, intent := range intents {
+		target := min(int(math.Floor(intent.LimitPct)), 99)
+		for _, oo := range gc.Orders.OrdersFor(intent.Ticker, intent.Side) {
+			if oo.Status != trading.StatusOpen {
+				continue
+			}
+			if target < 1 {
+				oo.Status = trading.StatusPending
+				go s.pullOrder(gc, *oo)
+				continue
+			}
+			move := target - oo.Price
+			if move < minMove && -move < minMove {
+				continue
+			}
+			// Raising a bid reserves more; it must still fit the caps
+			// and pass the market checks.
+			if raise := move * (oo.Count - oo.Filled); raise > 0 && lane != nil {
+				if lane.MaxGameCents() > 0 && spent+raise > lane.MaxGameCents() {
+					continue
+				}
+				if lane.SportSpent()+raise > int(lane.SportMax()) {
+					continue
+				}
+				if lane.PreTrade(quoteFor(gc, true, intent)) != lanes.RejectNone {
+					continue
+				}
+			}
+			oo.Status = trading.StatusPending
+			go s.amendOrder(gc, *oo, target)
+		}
+	}
+}
+
+func (s *Service) amendOrder(gc *game.GameContext, oo trading.OpenOrder, target int) {
+	ctx, cancel := context.WithTimeout(context.Background(), requoteTimeout)
+	defer cancel()
+
+	req := kalshi_http.AmendOrderRequest{
+		Ticker:        oo.Ticker,
+		Side:          oo.Side,
+		Action:        "buy",
+		ClientOrderID: oo.ClientOrderID,
+		CountFP:       fmt.Sprintf("%d.00", oo.Count),
+	}
+	priceDollars := fmt.Sprintf("%.2f", float64(target)/100.0)
+	if oo.Side == "yes" {
+		req.YesPriceDollars = priceDollars
+	} else {
+		req.NoPriceDollars = priceDollars
+	}
+
+	d, err := s.client.AmendOrder(ctx, oo.OrderID, req)
+	if err != nil {
+		telemetry.Warnf("[REQUOTE] amend %s %s %d¢ → %d¢ failed: %v", oo.Ticker, oo.Side, oo.Price, target, err)
+		s.releasePending(gc, oo.OrderID)
+		return
+	}
+	if d.OrderID != "" && d.OrderID != oo.OrderID {
+		telemetry.Warnf("[REQUOTE] amend of %s returned order %s — tracking stays on the original ID", oo.OrderID, d.OrderID)
+	}
+
+	s.fills.reprice(oo.OrderID, target)
+	// An amend that crosses the book fills at once.
+	s.fills.onOrderUpdate(detailUpdate(oo.OrderID, d))
+	s.releasePending(gc, oo.OrderID)
+	telemetry.Infof("[REQUOTE] %s %s %d¢ → %d¢", oo.Ticker, strings.ToUpper(oo.Side), oo.Price, target)
+}
+
+// pullOrder decreases a resting order to zero once the model no longer
+// supports any price for it.
+func (s *Service) pullOrder(gc *game.GameContext, oo trading.OpenOrder) {
+	ctx, cancel := context.WithTimeout(context.Background(), requoteTimeout)
+	defer cancel()
+
+	d, err := s.client.DecreaseOrder(ctx, oo.OrderID, 0)
+	if err != nil {
+		telemetry.Warnf("[REQUOTE] decrease %s %s failed: %v", oo.Ticker, oo.Side, err)
+		s.releasePending(gc, oo.OrderID)
+		return
+	}
+	s.fills.onOrderUpdate(detailUpdate(oo.OrderID, d))
+	s.releasePending(gc, oo.OrderID)
+	telemetry.Infof("[REQUOTE] %s %s pulled — no edge left at any price", oo.Ticker, strings.ToUpper(oo.Side))
+}
+
+// releasePending lets the next requote touch the order again.
+func (s *Service) releasePending(gc *game.GameContext, orderID string) {
+	gc.Send(func() {
+		if cur, ok := gc.Orders.GetOrder(orderID); ok {
+			cur.Status = trading.StatusOpen
+		}
+	})
+}
+
+// detailUpdate converts an order returned by amend or decrease into the
+// order update the fill ledger applies.
+func detailUpdate(orderID string, d *kalshi_http.OrderDetail) events.OrderUpdateEvent {
+	return events.OrderUpdateEvent{
+		OrderID:        orderID,
+		ClientOrderID:  d.ClientOrderID,
+		Ticker:         d.Ticker,
+		Side:           d.Side,
+		Status:         d.Status,
+		FillCount:      d.FillCount,
+		RemainingCount: d.RemainingCount,
+		FillCostCents:  d.TakerFillCost + d.MakerFillCost,
+		FeesCents:      d.TakerFees + d.MakerFees,
+	}
+}
@@ -0,0 +1,100 @@
+package execution
+
+import (
+	"cmp"
+	"fmt"
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/trading"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestRequote(t *testing.T) {
+	// One resting order: 4 contracts of BOS yes at 50¢, reserving 200¢.
+	tests := []struct {
+		name      string
+		minMove   int
+		gameCap   int
+		sportCap  int
+		down      bool // the Kalshi WS is disconnected
+		pending   bool // an amend is already in flight
+		target    float64
+		wantPrice int // amended limit, 0 for none
+		wantPull  bool
+		wantSpent int
+	}{
+		{name: "lowered", minMove: 2, target: 46, wantPrice: 46, wantSpent: 184},
+		{name: "raised", minMove: 2, target: 54, wantPrice: 54, wantSpent: 216},
+		{name: "move under the minimum", minMove: 2, target: 51.9, wantSpent: 200},
+		{name: "requoting off", target: 40, wantSpent: 200},
+		{name: "amend in flight", minMove: 2, pending: true, target: 40, wantSpent: 200},
+		{name: "raise over the game cap", minMove: 2, gameCap: 10, target: 54, wantSpent: 200},
+		{name: "raise over the sport cap", minMove: 2, sportCap: 210, target: 54, wantSpent: 200},
+		{name: "raise on a disconnected feed", minMove: 2, down: true, target: 54, wantSpent: 200},
+		{name: "lower on a disconnected feed", minMove: 2, down: true, target: 46, wantPrice: 46, wantSpent: 184},
+		{name: "no price left pulls", minMove: 2, target: 0.5, wantPull: true, wantSpent: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lane := lanes.NewLane(tt.gameCap, cmp.Or(tt.sportCap, 1_000_000))
+			lane.AddCheck(lanes.RequireConnected())
+			x := newFakeExchange()
+			s := newTestService(x, lane)
+			s.router.SetRequoteMinMove(events.SportHockey, tt.minMove)
+			gc := newTestGame(t)
+			s.gameStore.Put(gc)
+
+			lane.RecordOrder("BOS", "yes", 1, 0, 200)
+			s.fills.track("o1", &trackedOrder{gc: gc, lane: lane, ticker: "BOS", side: "yes", limitCents: 50, reservedCents: 200, total: 4}, false)
+
+			intent := events.OrderIntent{
+				Sport: events.SportHockey, League: "NHL", GameID: "1", EID: "1",
+				Ticker: "BOS", Side: "yes", LimitPct: tt.target, Requote: true,
+			}
+			var started bool
+			onGame(gc, func() {
+				gc.KalshiConnected = !tt.down
+				oo, _ := gc.Orders.GetOrder("o1")
+				if tt.pending {
+					oo.Status = trading.StatusPending
+				}
+				s.onOrderIntent(events.Event{Payload: []events.OrderIntent{intent}})
+				started = !tt.pending && oo.Status == trading.StatusPending
+			})
+			if started {
+				waitFor(t, "the requote to return", func() bool {
+					var open bool
+					onGame(gc, func() {
+						oo, ok := gc.Orders.GetOrder("o1")
+						open = !ok || oo.Status == trading.StatusOpen
+					})
+					return open
+				})
+			}
+
+			x.mu.Lock()
+			amended, amendedOK := x.amended["o1"]
+			reduceTo, pulled := x.reduced["o1"]
+			x.mu.Unlock()
+			switch {
+			case tt.wantPrice > 0:
+				if !amendedOK || amended.YesPriceDollars != dollars(tt.wantPrice) || amended.CountFP != "4.00" {
+					t.Errorf("amend %+v, want 4 at %s", amended, dollars(tt.wantPrice))
+				}
+			case amendedOK:
+				t.Errorf("unexpected amend %+v", amended)
+			}
+			if pulled != tt.wantPull || (pulled && reduceTo != 0) {
+				t.Errorf("pulled %v (to %d), want %v", pulled, reduceTo, tt.wantPull)
+			}
+			if got := lane.SportSpent(); got != tt.wantSpent {
+				t.Errorf("lane spent %d, want %d", got, tt.wantSpent)
+			}
+		})
+	}
+}
+
+func dollars(cents int) string {
+	return fmt.Sprintf("%.2f", float64(cents)/100)
+}
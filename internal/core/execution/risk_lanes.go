@@ -1,7 +1,12 @@
 package execution
 
 import (
+	"maps"
+	"slices"
+	"time"
+
 	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
 	"github.com/charleschow/hft-trading/internal/events"
 )
 
@@ -11,6 +16,9 @@ func RegisterLanesFromConfig(router *LaneRouter, rl config.RiskLimits, sport eve
 	sl, ok := rl.SportLimit(sportKey)
 	if !ok {
 		RegisterSportLanes(router, 50000, nil, sport)
+		for _, c := range preTradeChecks(config.PreTradeLimits{}) {
+			router.Route(sport, "*").AddCheck(c)
+		}
 		return
 	}
 	leagues := make(map[string]int, len(sl.Leagues))
@@ -18,7 +26,47 @@ func RegisterLanesFromConfig(router *LaneRouter, rl config.RiskLimits, sport eve
 		leagues[league] = ll.MaxGameCents
 	}
 	RegisterSportLanes(router, sl.MaxSportCents, leagues, sport)
+	for league, ll := range sl.Leagues {
+		lane := router.Route(sport, league)
+		if lane == nil {
+			continue
+		}
+		if ll.MaxGameContracts > 0 {
+			lane.SetMaxGameContracts(ll.MaxGameContracts)
+		}
+		sz := sl.LeagueSizing(league)
+		lane.SetSizing(lanes.Sizing{KellyFraction: sz.Kelly(), MaxOrderContracts: sz.MaxOrderContracts})
+	}
+	if lane := router.Route(sport, "*"); lane != nil {
+		lane.SetSizing(lanes.Sizing{KellyFraction: sl.Sizing.Kelly(), MaxOrderContracts: sl.Sizing.MaxOrderContracts})
+	}
+
+	checks := preTradeChecks(sl.PreTrade)
+	for _, league := range append(slices.Collect(maps.Keys(sl.Leagues)), "*") {
+		if lane := router.Route(sport, league); lane != nil {
+			for _, c := range checks {
+				lane.AddCheck(c)
+			}
+		}
+	}
 	if sl.OrderTTLSeconds > 0 {
 		router.SetOrderTTL(sport, sl.OrderTTLSeconds)
 	}
+	router.SetRequoteMinMove(sport, sl.RequoteMinMoveCents)
+}
+
+// preTradeChecks builds a sport's pre-trade chain. Connectivity and book
+// sanity always run; the rest only when configured.
+func preTradeChecks(pt config.PreTradeLimits) []lanes.PreTradeCheck {
+	checks := []lanes.PreTradeCheck{lanes.RequireConnected(), lanes.SaneBook()}
+	if pt.MaxQuoteAgeSeconds > 0 {
+		checks = append(checks, lanes.MaxQuoteAge(time.Duration(pt.MaxQuoteAgeSeconds)*time.Second))
+	}
+	if pt.MaxLimitCents > 0 {
+		checks = append(checks, lanes.PriceCeiling(pt.MaxLimitCents))
+	}
+	if pt.MaxSpreadCents > 0 {
+		checks = append(checks, lanes.MaxSpread(pt.MaxSpreadCents))
+	}
+	return checks
 }
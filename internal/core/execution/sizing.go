@@ -0,0 +1,123 @@
+package execution
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// BalanceReader is satisfied by *kalshi_http.Client and the paper exchange.
+type BalanceReader interface {
+	GetBalance(ctx context.Context) (int, error)
+}
+
+// sizer turns an intent into a contract count with fractional Kelly. For
+// a binary contract bought at price q with model probability p, the full
+// Kelly stake is (p-q)/(1-q) of bankroll; the lane's KellyFraction of that
+// is spent, capped by the lane's remaining game and sport budget. q is the
+// price the order is expected to pay, not its limit: the limit sits a
+// fixed threshold under the model, which would make every stake alike.
+type sizer struct {
+	bankroll atomic.Int64 // cents
+}
+
+// size returns the contracts to buy for intent on lane, given the cents
+// already committed to the game and the price in cents the order is
+// expected to fill at (see fillPrice). A slam trades a settled result, so
+// it is sized at p = 1. Zero means the order should be skipped.
+func (z *sizer) size(lane *lanes.Lane, intent events.OrderIntent, gameSpent int, priceCents float64) int {
+	limitCents := min(int(math.Floor(intent.LimitPct)), 99)
+	sz := lane.Sizing()
+	p := intent.ModelPct / 100
+	if intent.Slam {
+		p = 1
+	}
+	if sz.KellyFraction <= 0 || p <= 0 || limitCents < 1 {
+		return 1
+	}
+
+	q := math.Min(priceCents, float64(limitCents)) / 100
+	if q <= 0 {
+		q = float64(limitCents) / 100
+	}
+	kelly := (p - q) / (1 - q)
+	if kelly <= 0 {
+		return 0
+	}
+
+	stake := sz.KellyFraction * kelly * float64(z.bankroll.Load())
+	n := int(stake / (q * 100))
+
+	// Orders reserve their limit against the caps, so the budget is
+	// counted at the limit too.
+	budget := int(lane.SportMax()) - lane.SportSpent()
+	if lane.MaxGameCents() > 0 {
+		budget = min(budget, lane.MaxGameCents()-gameSpent)
+	}
+	n = min(n, budget/limitCents)
+	if sz.MaxOrderContracts > 0 {
+		n = min(n, sz.MaxOrderContracts)
+	}
+	return max(n, 0)
+}
+
+// fillPrice estimates what an order for intent pays per contract right
+// now: the average price of the book's liquidity at or below the limit,
+// else the side's ask, never above the limit. Without market data it is
+// the limit. Runs on the game's goroutine.
+func fillPrice(gc *game.GameContext, gcOK bool, intent events.OrderIntent) float64 {
+	limit := math.Floor(intent.LimitPct)
+	if !gcOK {
+		return limit
+	}
+	if avg, ok := gc.Books[intent.Ticker].AvgPriceAtOrBelow(intent.Side, int(limit)); ok {
+		return avg
+	}
+	if td := gc.Tickers[intent.Ticker]; td != nil {
+		ask := td.YesAsk
+		if intent.Side == "no" {
+			ask = td.NoAsk
+		}
+		if ask > 0 {
+			return math.Min(ask, limit)
+		}
+	}
+	return limit
+}
+
+// intentCents is the most an intent can cost: Count contracts at its limit.
+func intentCents(intent events.OrderIntent) int {
+	return int(intent.LimitPct) * max(intent.Count, 1)
+}
+
+// SetBankroll sets the bankroll, in cents, that orders are sized against.
+func (s *Service) SetBankroll(cents int) {
+	s.sizer.bankroll.Store(int64(cents))
+	telemetry.Infof("[SIZING] bankroll $%.2f", float64(cents)/100.0)
+}
+
+// WatchBankroll keeps the sizing bankroll at the account's live balance,
+// refreshed every interval until ctx is done. A failed fetch keeps the
+// last known value.
+func (s *Service) WatchBankroll(ctx context.Context, br BalanceReader, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if cents, err := br.GetBalance(ctx); err != nil {
+			telemetry.Warnf("[SIZING] balance fetch failed: %v", err)
+		} else {
+			s.sizer.bankroll.Store(int64(cents))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package execution
+
+import (
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/core/execution/lanes"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestSizerSize(t *testing.T) {
+	tests := []struct {
+		name       string
+		sizing     lanes.Sizing
+		maxGame    int
+		maxSport   int
+		sportSpent int
+		gameSpent  int
+		intent     events.OrderIntent
+		price      float64
+		want       int
+	}{
+		{
+			name:   "sizing off is one contract",
+			intent: events.OrderIntent{LimitPct: 55, ModelPct: 60},
+			price:  45,
+			want:   1,
+		},
+		{
+			name:   "unsized intent is one contract",
+			sizing: lanes.Sizing{KellyFraction: 0.25},
+			intent: events.OrderIntent{LimitPct: 55},
+			price:  45,
+			want:   1,
+		},
+		{
+			name:   "sized at the fill price",
+			sizing: lanes.Sizing{KellyFraction: 0.25},
+			intent: events.OrderIntent{LimitPct: 55, ModelPct: 60},
+			price:  45,
+			want:   151, // kelly .15/.55 → $68.18 at 45¢
+		},
+		{
+			name:   "fill price at the limit",
+			sizing: lanes.Sizing{KellyFraction: 0.25},
+			intent: events.OrderIntent{LimitPct: 55, ModelPct: 60},
+			price:  55,
+			want:   50, // kelly .05/.45 → $27.78 at 55¢
+		},
+		{
+			name:   "fill price capped by the limit",
+			sizing: lanes.Sizing{KellyFraction: 0.25},
+			intent: events.OrderIntent{LimitPct: 55, ModelPct: 60},
+			price:  70,
+			want:   50,
+		},
+		{
+			name:   "no edge at the fill price",
+			sizing: lanes.Sizing{KellyFraction: 0.25},
+			intent: events.OrderIntent{LimitPct: 55, ModelPct: 50},
+			price:  50,
+			want:   0,
+		},
+		{
+			name:   "slam sized as a certainty",
+			sizing: lanes.Sizing{KellyFraction: 0.25},
+			intent: events.OrderIntent{LimitPct: 99, Slam: true},
+			price:  95,
+			want:   263, // full kelly → $250 at 95¢
+		},
+		{
+			name:   "slam capped per order",
+			sizing: lanes.Sizing{KellyFraction: 0.25, MaxOrderContracts: 40},
+			intent: events.OrderIntent{LimitPct: 99, Slam: true},
+			price:  95,
+			want:   40,
+		},
+		{
+			name:      "game budget at the limit",
+			sizing:    lanes.Sizing{KellyFraction: 0.25},
+			maxGame:   2000,
+			gameSpent: 1500,
+			intent:    events.OrderIntent{LimitPct: 50, ModelPct: 70},
+			price:     40,
+			want:      10,
+		},
+		{
+			name:       "sport budget at the limit",
+			sizing:     lanes.Sizing{KellyFraction: 0.25},
+			maxSport:   10000,
+			sportSpent: 9000,
+			intent:     events.OrderIntent{LimitPct: 50, ModelPct: 70},
+			price:      40,
+			want:       20,
+		},
+		{
+			name:      "game budget spent",
+			sizing:    lanes.Sizing{KellyFraction: 0.25},
+			maxGame:   2000,
+			gameSpent: 2000,
+			intent:    events.OrderIntent{LimitPct: 50, ModelPct: 70},
+			price:     40,
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxSport := tt.maxSport
+			if maxSport == 0 {
+				maxSport = 1_000_000
+			}
+			lane := lanes.NewLane(tt.maxGame, maxSport)
+			lane.SetSizing(tt.sizing)
+			lane.AdjustSpend(tt.sportSpent)
+
+			var z sizer
+			z.bankroll.Store(100_000)
+			if got := z.size(lane, tt.intent, tt.gameSpent, tt.price); got != tt.want {
+				t.Errorf("size = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
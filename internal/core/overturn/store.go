@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/charleschow/hft-trading/internal/events"
 	"github.com/charleschow/hft-trading/internal/telemetry"
 
 	_ "modernc.org/sqlite"
@@ -88,6 +89,8 @@ func OpenStore(path string) (*Store, error) {
 	db.Exec(`ALTER TABLE overturns DROP COLUMN bet365_home_pct`)
 	db.Exec(`ALTER TABLE overturns DROP COLUMN bet365_away_pct`)
 	db.Exec(`ALTER TABLE overturns DROP COLUMN bet365_draw_pct`)
+	db.Exec(`ALTER TABLE overturns ADD COLUMN orders_canceled INTEGER`)
+	db.Exec(`ALTER TABLE overturns ADD COLUMN contracts_canceled INTEGER`)
 
 	var count int64
 	row := db.QueryRow(`SELECT COUNT(*) FROM overturns`)
@@ -134,6 +137,28 @@ func (s *Store) Insert(row Row) error {
 	return err
 }
 
+// RecordCancels adds the orders (and resting contracts) pulled because of
+// a pending overturn to the game's latest OVERTURN PENDING row.
+func (s *Store) RecordCancels(gameID string, orders, contracts int) error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.db.Exec(
+		`UPDATE overturns
+		 SET orders_canceled = COALESCE(orders_canceled, 0) + ?,
+		     contracts_canceled = COALESCE(contracts_canceled, 0) + ?
+		 WHERE id = (
+			SELECT id FROM overturns WHERE game_id = ? AND event_type = ?
+			ORDER BY id DESC LIMIT 1
+		 )`,
+		orders, contracts, gameID, string(events.StatusOverturnPending),
+	)
+	return err
+}
+
 func (s *Store) Close() error {
 	if s == nil || s.db == nil {
 		return nil
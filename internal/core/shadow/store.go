@@ -0,0 +1,188 @@
+package shadow
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/telemetry"
+
+	_ "modernc.org/sqlite"
+)
+
+// Decision is one order a strategy decided on, live or shadow, with the
+// fill it would have got from the book at that moment.
+type Decision struct {
+	Ts         time.Time
+	StrategyID string
+	Sport      string
+	League     string
+	GameID     string
+	HomeTeam   string
+	AwayTeam   string
+	Period     string
+	TimeRemain float64
+	HomeScore  int
+	AwayScore  int
+
+	Ticker     string
+	Side       string // "yes" or "no"
+	Outcome    string // "home", "away", "draw"
+	LimitCents int
+	ModelPct   float64
+	Reason     string
+	Overturn   bool
+	Slam       bool
+
+	// Hypothetical fill. FillSource is "book" when the depth book was
+	// valid, "top" when only the top-of-book ask was known. FillCents is
+	// the best ask at or under the limit (0 when it would have rested) and
+	// Depth the contracts available there (0 for "top").
+	Filled     bool
+	FillCents  int
+	Depth      int
+	FillSource string
+}
+
+// Store persists strategy decisions in a SQLite database so shadow
+// strategies can be compared with the live one on the same feed.
+type Store struct {
+	db *sql.DB
+	mu sync.Mutex
+}
+
+func OpenStore(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create store dir: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(wal)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+
+	db.SetMaxOpenConns(1)
+
+	for _, stmt := range []string{
+		`CREATE TABLE IF NOT EXISTS decisions (
+			id           INTEGER PRIMARY KEY AUTOINCREMENT,
+			ts           TEXT    NOT NULL,
+			strategy_id  TEXT    NOT NULL,
+			sport        TEXT    NOT NULL,
+			league       TEXT,
+			game_id      TEXT    NOT NULL,
+			home_team    TEXT,
+			away_team    TEXT,
+			period       TEXT,
+			time_remain  REAL,
+			home_score   INTEGER NOT NULL,
+			away_score   INTEGER NOT NULL,
+
+			ticker       TEXT    NOT NULL,
+			side         TEXT    NOT NULL,
+			outcome      TEXT,
+			limit_cents  INTEGER NOT NULL,
+			model_pct    REAL,
+			reason       TEXT,
+			overturn     INTEGER NOT NULL DEFAULT 0,
+			slam         INTEGER NOT NULL DEFAULT 0,
+
+			filled       INTEGER NOT NULL DEFAULT 0,
+			fill_cents   INTEGER,
+			depth        INTEGER,
+			fill_source  TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_dec_game_id ON decisions(game_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_dec_strategy ON decisions(strategy_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_dec_ts ON decisions(ts)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("init schema (%s): %w", stmt, err)
+		}
+	}
+
+	var count int64
+	row := db.QueryRow(`SELECT COUNT(*) FROM decisions`)
+	if err := row.Scan(&count); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("read row count: %w", err)
+	}
+
+	telemetry.Infof("Started Decision db  path=%s  rows=%d", path, count)
+
+	return &Store{db: db}, nil
+}
+
+// Record writes decisions in the background; it is called from game
+// goroutines and must not hold them up on disk.
+func (s *Store) Record(ds []Decision) {
+	if s == nil || len(ds) == 0 {
+		return
+	}
+	go func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if err := s.insert(ds); err != nil {
+			telemetry.Warnf("decision log: %v", err)
+		}
+	}()
+}
+
+func (s *Store) insert(ds []Decision) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, d := range ds {
+		_, err := tx.Exec(
+			`INSERT INTO decisions (
+				ts, strategy_id, sport, league, game_id, home_team, away_team,
+				period, time_remain, home_score, away_score,
+				ticker, side, outcome, limit_cents, model_pct, reason, overturn, slam,
+				filled, fill_cents, depth, fill_source
+			) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+			d.Ts.UTC().Format(time.RFC3339Nano),
+			d.StrategyID,
+			d.Sport,
+			d.League,
+			d.GameID,
+			d.HomeTeam,
+			d.AwayTeam,
+			d.Period,
+			d.TimeRemain,
+			d.HomeScore,
+			d.AwayScore,
+			d.Ticker,
+			d.Side,
+			d.Outcome,
+			d.LimitCents,
+			d.ModelPct,
+			d.Reason,
+			d.Overturn,
+			d.Slam,
+			d.Filled,
+			d.FillCents,
+			d.Depth,
+			d.FillSource,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *Store) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Close()
+}
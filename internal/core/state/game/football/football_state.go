@@ -1,6 +1,7 @@
 package football
 
 import (
+	"maps"
 	"strings"
 
 	game "github.com/charleschow/hft-trading/internal/core/state/game"
@@ -20,6 +21,7 @@ type FootballState struct {
 
 	HomePregameStrength float64
 	AwayPregameStrength float64
+	PregameApplied      bool
 
 	HomeTicker string
 	AwayTicker string
@@ -29,6 +31,11 @@ type FootballState struct {
 	ModelHomePct float64 // 0–100
 	ModelAwayPct float64
 
+	EdgeHomeYes float64
+	EdgeAwayYes float64
+	EdgeHomeNo  float64
+	EdgeAwayNo  float64
+
 	game.ScoreDropTracker
 
 	hasLIVEData  bool
@@ -63,7 +70,7 @@ func (f *FootballState) GetAwayScore() int         { return f.AwayScore }
 func (f *FootballState) GetPeriod() string         { return f.Quarter }
 func (f *FootballState) GetTimeRemaining() float64 { return f.TimeLeft }
 func (f *FootballState) HasLIVEData() bool         { return f.hasLIVEData }
-func (f *FootballState) HasPregame() bool          { return true }
+func (f *FootballState) HasPregame() bool          { return f.PregameApplied }
 
 func (f *FootballState) DeduplicateStatus(status events.MatchStatus) events.MatchStatus {
 	return status
@@ -76,6 +83,11 @@ func (f *FootballState) IsOVERTIME() bool {
 	return strings.Contains(q, "overtime") || q == "ot"
 }
 
+func (f *FootballState) IsHalftime() bool {
+	q := strings.ToLower(strings.TrimSpace(f.Quarter))
+	return q == "halftime" || q == "half time" || q == "ht"
+}
+
 func (f *FootballState) IsFinished() bool {
 	q := strings.ToLower(strings.TrimSpace(f.Quarter))
 	return q == "finished" || q == "final" || q == "ended" ||
@@ -112,18 +124,69 @@ func (f *FootballState) SetTickers(home, away, _ string) {
 	f.AwayTicker = away
 }
 
+func (f *FootballState) GetTickers() (home, away, draw string) {
+	return f.HomeTicker, f.AwayTicker, ""
+}
+
 func (f *FootballState) SetIdentifiers(eid, league string) {
 	f.EID = eid
 	f.League = league
 }
 
+// Clone copies the state with its own record of ordered sides.
+func (f *FootballState) Clone() game.GameState {
+	c := *f
+	c.orderedSides = maps.Clone(f.orderedSides)
+	return &c
+}
+
+// SetPregame writes the vig-free moneyline. Called by the engine during
+// initialization — the pregame HTTP is the source of truth for orientation.
 func (f *FootballState) SetPregame(home, away, _ float64, _ float64) {
 	f.HomePregameStrength = home
 	f.AwayPregameStrength = away
+	f.PregameApplied = true
+}
+
+func (f *FootballState) RecalcEdge(tickers map[string]*game.TickerData) {
+	if f.ModelHomePct == 0 && f.ModelAwayPct == 0 {
+		return
+	}
+	f.EdgeHomeYes = edgeFor(f.ModelHomePct, yesAsk(tickers, f.HomeTicker))
+	f.EdgeAwayYes = edgeFor(f.ModelAwayPct, yesAsk(tickers, f.AwayTicker))
+	f.EdgeHomeNo = edgeFor(100-f.ModelHomePct, noAsk(tickers, f.HomeTicker))
+	f.EdgeAwayNo = edgeFor(100-f.ModelAwayPct, noAsk(tickers, f.AwayTicker))
+}
+
+func (f *FootballState) HasSignificantEdge(t float64) bool {
+	for _, e := range []float64{
+		f.EdgeHomeYes, f.EdgeAwayYes,
+		f.EdgeHomeNo, f.EdgeAwayNo,
+	} {
+		if e >= t {
+			return true
+		}
+	}
+	return false
+}
+
+func edgeFor(model, ask float64) float64 {
+	if ask <= 0 {
+		return 0
+	}
+	return model - ask
 }
 
-func (f *FootballState) RecalcEdge(_ map[string]*game.TickerData) {
-	// TODO: implement football edge calculation
+func yesAsk(tickers map[string]*game.TickerData, ticker string) float64 {
+	if td, ok := tickers[ticker]; ok {
+		return td.YesAsk
+	}
+	return -1
 }
 
-func (f *FootballState) HasSignificantEdge() bool { return false }
+func noAsk(tickers map[string]*game.TickerData, ticker string) float64 {
+	if td, ok := tickers[ticker]; ok {
+		return td.NoAsk
+	}
+	return -1
+}
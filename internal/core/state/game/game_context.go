@@ -3,6 +3,7 @@ package game
 import (
 	"time"
 
+	"github.com/charleschow/hft-trading/internal/core/state/trading"
 	"github.com/charleschow/hft-trading/internal/events"
 	"github.com/charleschow/hft-trading/internal/telemetry"
 )
@@ -15,13 +16,19 @@ type TickerData struct {
 	NoAsk  float64
 	NoBid  float64
 	Volume int64
+
+	// UpdatedAt is when the last Kalshi market update for Ticker arrived;
+	// zero until the first one.
+	UpdatedAt time.Time
 }
 
-// Fill records a single filled order against this game's exposure.
+// Fill records one order's fills against this game's exposure.
+// Count and CostCents (fill cost + fees) are cumulative for the order.
 type Fill struct {
 	OrderID   string
 	Ticker    string
 	Side      string
+	Count     int
 	CostCents int
 }
 
@@ -35,6 +42,16 @@ type OverturnInfo struct {
 	NewAway int
 }
 
+// MarketLead describes a Kalshi price move on one of the game's tickers
+// that the live feed had not explained with a score change.
+type MarketLead struct {
+	At        time.Time
+	Ticker    string
+	FromCents float64 // yes mid at the start of the window
+	ToCents   float64 // yes mid when flagged
+	Window    time.Duration
+}
+
 // GameContext is the single source of truth for one game.
 //
 // GameContexts are eagerly created at startup from Kalshi markets matched
@@ -63,9 +80,16 @@ type GameContext struct {
 	// LIVE market prices keyed by Kalshi ticker.
 	Tickers map[string]*TickerData
 
+	// Full-depth order books keyed by Kalshi ticker.
+	Books map[string]*OrderBook
+
 	// Fills recorded against this game.
 	Fills []Fill
 
+	// Orders holds this game's live (resting) orders, kept current by the
+	// execution fill ledger so they can be repriced in place.
+	Orders *trading.OrderState
+
 	// KalshiEventURL is the link to the Kalshi event page for this game.
 	KalshiEventURL string
 
@@ -81,6 +105,11 @@ type GameContext struct {
 	// Set by the engine before notifying observers of SCORE CHANGE.
 	LastScorer string
 
+	// HeldUntil suspends new orders on the game until then. Set by the
+	// strategy engine while Kalshi is leading the feed; slams and requotes
+	// are not held.
+	HeldUntil time.Time
+
 	// GameStartedAt is the actual kickoff / puck-drop time from GoalServe.
 	GameStartedAt time.Time
 
@@ -116,6 +145,10 @@ type GameState interface {
 	SetTickers(home, away, draw string)
 	HasPregame() bool
 
+	// GetTickers returns the moneyline tickers, "" where the sport has
+	// none (draw outside soccer).
+	GetTickers() (home, away, draw string)
+
 	// DeduplicateStatus suppresses repeated one-shot display statuses.
 	// e.g. hockey returns StatusLive after the first StatusOvertime notification.
 	DeduplicateStatus(status events.MatchStatus) events.MatchStatus
@@ -125,8 +158,12 @@ type GameState interface {
 	RecalcEdge(tickers map[string]*TickerData)
 
 	// HasSignificantEdge returns true when the game has a model-vs-market
-	// edge worth displaying.
-	HasSignificantEdge() bool
+	// edge of at least thresholdPct.
+	HasSignificantEdge(thresholdPct float64) bool
+
+	// Clone returns an independent copy of the state, so a shadow
+	// strategy can run its own model over the same feed.
+	Clone() GameState
 }
 
 func NewGameContext(sport events.Sport, league, eid string, gs GameState) *GameContext {
@@ -136,6 +173,8 @@ func NewGameContext(sport events.Sport, league, eid string, gs GameState) *GameC
 		EID:     eid,
 		Game:    gs,
 		Tickers: make(map[string]*TickerData),
+		Books:   make(map[string]*OrderBook),
+		Orders:  trading.NewOrderState(),
 		inbox:   make(chan func(), 256),
 		stop:    make(chan struct{}),
 	}
@@ -164,6 +203,53 @@ func (gc *GameContext) Send(fn func()) {
 	}
 }
 
+// TrySend enqueues a closure like Send but reports a full inbox to the
+// caller instead of logging and dropping, for updates that must land.
+func (gc *GameContext) TrySend(fn func()) bool {
+	select {
+	case gc.inbox <- fn:
+		return true
+	default:
+		return false
+	}
+}
+
+// SendWait enqueues a closure like Send but waits for room in the inbox
+// instead of dropping it. Never call it from the game's own goroutine.
+func (gc *GameContext) SendWait(fn func()) {
+	gc.inbox <- fn
+}
+
+// Shadow returns a context for running a shadow strategy on this game.
+// It shares gc's prices and books but holds its own clone of the game
+// state, no resting orders and no observers. It has no goroutine of its
+// own: use it only from gc's, after SyncShadow.
+func (gc *GameContext) Shadow() *GameContext {
+	sc := &GameContext{
+		Sport:        gc.Sport,
+		HomeTeamNorm: gc.HomeTeamNorm,
+		AwayTeamNorm: gc.AwayTeamNorm,
+		Game:         gc.Game.Clone(),
+		Tickers:      gc.Tickers,
+		Books:        gc.Books,
+		Orders:       trading.NewOrderState(),
+	}
+	gc.SyncShadow(sc)
+	return sc
+}
+
+// SyncShadow copies the fields the engine keeps current on gc (binding,
+// connection and status) onto a shadow context.
+func (gc *GameContext) SyncShadow(sc *GameContext) {
+	sc.League = gc.League
+	sc.EID = gc.EID
+	sc.MatchStatus = gc.MatchStatus
+	sc.KalshiEventURL = gc.KalshiEventURL
+	sc.KalshiConnected = gc.KalshiConnected
+	sc.LastScorer = gc.LastScorer
+	sc.GameStartedAt = gc.GameStartedAt
+}
+
 // AddObserver registers an observer that will be notified on game events.
 // Must be called before the game starts receiving events.
 func (gc *GameContext) AddObserver(o GameObserver) {
@@ -215,9 +301,18 @@ func (gc *GameContext) NoAsk(ticker string) float64 {
 	return -1
 }
 
-// RecordFill appends a fill.
+// RecordFill appends a fill, or replaces the entry for the same order
+// when a later fill update arrives.
 // Must be called from the game's goroutine (inside a Send closure).
 func (gc *GameContext) RecordFill(f Fill) {
+	if f.OrderID != "" {
+		for i := range gc.Fills {
+			if gc.Fills[i].OrderID == f.OrderID {
+				gc.Fills[i] = f
+				return
+			}
+		}
+	}
 	gc.Fills = append(gc.Fills, f)
 }
 
@@ -231,6 +326,20 @@ func (gc *GameContext) TotalVolume() int64 {
 	return total
 }
 
+// TotalContracts counts the game's contracts: filled, plus those its
+// resting orders can still fill.
+// Must be called from the game's goroutine (inside a Send closure).
+func (gc *GameContext) TotalContracts() int {
+	total := 0
+	for _, f := range gc.Fills {
+		total += f.Count
+	}
+	for _, o := range gc.Orders.Open {
+		total += max(o.Count-o.Filled, 0)
+	}
+	return total
+}
+
 // TotalExposureCents sums all fill costs for this game.
 // Must be called from the game's goroutine (inside a Send closure).
 func (gc *GameContext) TotalExposureCents() int {
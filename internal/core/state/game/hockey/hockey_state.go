@@ -1,6 +1,7 @@
 package hockey
 
 import (
+	"slices"
 	"strings"
 
 	game "github.com/charleschow/hft-trading/internal/core/state/game"
@@ -27,6 +28,9 @@ type HockeyState struct {
 	SeriesSlug string
 	SeriesName string
 
+	// Totals and spread markets on the game, priced from the same model.
+	Lines []game.LineMarket
+
 	ModelHomePct float64 // 0–100
 	ModelAwayPct float64 // 0–100
 
@@ -40,6 +44,12 @@ type HockeyState struct {
 	// display can show who had the PP. Cleared when the next PP starts.
 	LastPowerPlayWasHome *bool
 
+	// Minor penalties still being served, as the TimeLeft at which each
+	// expires. HomePPExpiry holds the away side's penalties (home is on the
+	// power play); two or more at once is a 5-on-3.
+	HomePPExpiry []float64
+	AwayPPExpiry []float64
+
 	PregameApplied bool
 	PregameG0      *float64 // expected total goals from O/U market, nil if unavailable
 
@@ -99,6 +109,11 @@ func (h *HockeyState) IsOVERTIME() bool {
 	return strings.Contains(p, "overtime") || p == "ot" || p == "penalties" || p == "shootout"
 }
 
+func (h *HockeyState) IsShootout() bool {
+	p := strings.ToLower(strings.TrimSpace(h.Period))
+	return p == "shootout" || p == "penalties"
+}
+
 func (h *HockeyState) IsFinished() bool {
 	p := strings.ToLower(strings.TrimSpace(h.Period))
 	return p == "finished" || p == "final" || p == "ended" ||
@@ -113,6 +128,27 @@ func (h *HockeyState) IsLIVE() bool {
 func (h *HockeyState) Finaled() bool    { return h.finaled }
 func (h *HockeyState) SetFinaled()      { h.finaled = true }
 
+// PowerPlayMinutes returns the power-play time left for one side, split
+// into 5-on-3 (while two penalties overlap) and 5-on-4.
+func (h *HockeyState) PowerPlayMinutes(home bool) (fiveOnFour, fiveOnThree float64) {
+	exp := h.AwayPPExpiry
+	if home {
+		exp = h.HomePPExpiry
+	}
+	if len(exp) == 0 {
+		return 0, 0
+	}
+	first, last := exp[0], exp[0] // first to expire has the most TimeLeft
+	for _, e := range exp[1:] {
+		first = max(first, e)
+		last = min(last, e)
+	}
+	if len(exp) == 1 {
+		return max(0, h.TimeLeft-first), 0
+	}
+	return max(0, first-last), max(0, h.TimeLeft-first)
+}
+
 func (h *HockeyState) UpdateGameState(homeScore, awayScore int, period string, timeRemain float64) bool {
 	firstUpdate := !h.hasLIVEData
 	scoreChanged := h.HomeScore != homeScore || h.AwayScore != awayScore
@@ -135,11 +171,30 @@ func (h *HockeyState) SetTickers(home, away, _ string) {
 	h.AwayTicker = away
 }
 
+func (h *HockeyState) GetTickers() (home, away, draw string) {
+	return h.HomeTicker, h.AwayTicker, ""
+}
+
+// SetLines attaches the game's totals and spread markets.
+func (h *HockeyState) SetLines(lines []game.LineMarket) {
+	h.Lines = lines
+}
+
 func (h *HockeyState) SetIdentifiers(eid, league string) {
 	h.EID = eid
 	h.League = league
 }
 
+// Clone copies the state; the penalty expiries and lines are edited in
+// place by the strategy, so they get their own backing arrays.
+func (h *HockeyState) Clone() game.GameState {
+	c := *h
+	c.HomePPExpiry = slices.Clone(h.HomePPExpiry)
+	c.AwayPPExpiry = slices.Clone(h.AwayPPExpiry)
+	c.Lines = slices.Clone(h.Lines)
+	return &c
+}
+
 // SetPregame writes pregame odds directly. Called by the engine during
 // initialization — the pregame HTTP is the source of truth for orientation.
 func (h *HockeyState) SetPregame(home, away, _ float64, g0 float64) {
@@ -159,10 +214,10 @@ func (h *HockeyState) RecalcEdge(tickers map[string]*game.TickerData) {
 	h.EdgeAwayYes = edgeFor(h.ModelAwayPct, yesAsk(tickers, h.AwayTicker))
 	h.EdgeHomeNo = edgeFor(100-h.ModelHomePct, noAsk(tickers, h.HomeTicker))
 	h.EdgeAwayNo = edgeFor(100-h.ModelAwayPct, noAsk(tickers, h.AwayTicker))
+	game.RecalcLineEdges(h.Lines, tickers)
 }
 
-func (h *HockeyState) HasSignificantEdge() bool {
-	t := game.EdgeThresholdPct()
+func (h *HockeyState) HasSignificantEdge(t float64) bool {
 	for _, e := range []float64{
 		h.EdgeHomeYes, h.EdgeAwayYes,
 		h.EdgeHomeNo, h.EdgeAwayNo,
@@ -171,7 +226,7 @@ func (h *HockeyState) HasSignificantEdge() bool {
 			return true
 		}
 	}
-	return false
+	return game.LinesHaveEdge(h.Lines, t)
 }
 
 func edgeFor(model, ask float64) float64 {
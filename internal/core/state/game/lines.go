@@ -0,0 +1,85 @@
+package game
+
+import "fmt"
+
+// Line market kinds.
+const (
+	LineTotal  = "total"
+	LineSpread = "spread"
+)
+
+// LineMarket is a Kalshi totals or spread market on the game. YES on a
+// total pays when the final total goes over Line; YES on a spread pays
+// when Team ("home" or "away") wins by more than Line.
+type LineMarket struct {
+	Ticker string
+	Kind   string
+	Line   float64
+	Team   string // spreads only
+
+	// Priced is set once the strategy has run its model over the line;
+	// edges are not computed before that.
+	Priced   bool
+	ModelYes float64 // 0–100
+	EdgeYes  float64
+	EdgeNo   float64
+}
+
+// Outcome labels the line in order intents and logs: "over 5.5" for a
+// total, "home -1.5" for a spread.
+func (l LineMarket) Outcome() string {
+	if l.Kind == LineSpread {
+		return fmt.Sprintf("%s -%g", l.Team, l.Line)
+	}
+	return fmt.Sprintf("over %g", l.Line)
+}
+
+// Settles reports whether YES wins at a final score of home-away.
+func (l LineMarket) Settles(home, away int) bool {
+	switch l.Kind {
+	case LineTotal:
+		return float64(home+away) > l.Line
+	case LineSpread:
+		margin := home - away
+		if l.Team == "away" {
+			margin = -margin
+		}
+		return float64(margin) > l.Line
+	}
+	return false
+}
+
+// RecalcLineEdges recomputes model-vs-market edge on each priced line.
+func RecalcLineEdges(lines []LineMarket, tickers map[string]*TickerData) {
+	for i := range lines {
+		l := &lines[i]
+		if !l.Priced {
+			continue
+		}
+		td, ok := tickers[l.Ticker]
+		if !ok {
+			l.EdgeYes, l.EdgeNo = 0, 0
+			continue
+		}
+		l.EdgeYes = lineEdge(l.ModelYes, td.YesAsk)
+		l.EdgeNo = lineEdge(100-l.ModelYes, td.NoAsk)
+	}
+}
+
+// LinesHaveEdge reports whether any line has an edge of at least
+// thresholdPct on either side.
+func LinesHaveEdge(lines []LineMarket, thresholdPct float64) bool {
+	for _, l := range lines {
+		if l.EdgeYes >= thresholdPct || l.EdgeNo >= thresholdPct {
+			return true
+		}
+	}
+	return false
+}
+
+func lineEdge(model, ask float64) float64 {
+	if ask <= 0 {
+		return 0
+	}
+	return model - ask
+}
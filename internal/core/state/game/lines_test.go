@@ -0,0 +1,32 @@
+package game
+
+import "testing"
+
+func TestLineMarketSettles(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       LineMarket
+		home, away int
+		want       bool
+	}{
+		{"total over", LineMarket{Kind: LineTotal, Line: 5.5}, 4, 2, true},
+		{"total under", LineMarket{Kind: LineTotal, Line: 5.5}, 3, 2, false},
+		{"total on a whole line pushes to NO", LineMarket{Kind: LineTotal, Line: 5}, 3, 2, false},
+		{"goalless total", LineMarket{Kind: LineTotal, Line: 0.5}, 0, 0, false},
+		{"home covers", LineMarket{Kind: LineSpread, Line: 1.5, Team: "home"}, 4, 2, true},
+		{"home wins by one", LineMarket{Kind: LineSpread, Line: 1.5, Team: "home"}, 3, 2, false},
+		{"home loses", LineMarket{Kind: LineSpread, Line: 1.5, Team: "home"}, 1, 3, false},
+		{"away covers", LineMarket{Kind: LineSpread, Line: 1.5, Team: "away"}, 1, 3, true},
+		{"away wins by one", LineMarket{Kind: LineSpread, Line: 1.5, Team: "away"}, 2, 3, false},
+		{"away loses", LineMarket{Kind: LineSpread, Line: 1.5, Team: "away"}, 4, 1, false},
+		{"spread on a whole line pushes to NO", LineMarket{Kind: LineSpread, Line: 2, Team: "home"}, 4, 2, false},
+		{"unknown kind", LineMarket{Kind: "prop", Line: 0.5}, 3, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.line.Settles(tt.home, tt.away); got != tt.want {
+				t.Errorf("Settles(%d, %d) = %v, want %v", tt.home, tt.away, got, tt.want)
+			}
+		})
+	}
+}
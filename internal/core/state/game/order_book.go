@@ -0,0 +1,129 @@
+package game
+
+import (
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+// OrderBook is the full-depth Kalshi book for one ticker, rebuilt from
+// orderbook_snapshot and kept current by orderbook_delta.
+//
+// Kalshi only publishes bids. Buying YES at p¢ lifts NO bids at (100-p)¢
+// and vice versa, so every "ask" query reads the opposite side.
+//
+// Not safe for concurrent use — owned by the game goroutine like the rest
+// of GameContext.
+type OrderBook struct {
+	Ticker  string
+	YesBids map[int]int // price cents → resting contracts
+	NoBids  map[int]int
+
+	// Valid is false until the first snapshot and after a sequence gap,
+	// until the re-snapshot lands.
+	Valid bool
+}
+
+func NewOrderBook(ticker string) *OrderBook {
+	return &OrderBook{
+		Ticker:  ticker,
+		YesBids: make(map[int]int),
+		NoBids:  make(map[int]int),
+	}
+}
+
+// Apply folds a snapshot, delta, or invalidation into the book.
+// Deltas received while the book is invalid are dropped.
+func (b *OrderBook) Apply(ob events.OrderBookEvent) {
+	switch {
+	case ob.Invalidate:
+		b.Valid = false
+	case ob.Snapshot:
+		b.YesBids = levelsToMap(ob.YesBids)
+		b.NoBids = levelsToMap(ob.NoBids)
+		b.Valid = true
+	default:
+		if !b.Valid {
+			return
+		}
+		side := b.YesBids
+		if ob.Side == "no" {
+			side = b.NoBids
+		}
+		if n := side[ob.Price] + ob.Delta; n > 0 {
+			side[ob.Price] = n
+		} else {
+			delete(side, ob.Price)
+		}
+	}
+}
+
+// AvailableAtOrBelow returns how many contracts of side ("yes"/"no") can
+// be bought right now at a price of at most limitCents.
+func (b *OrderBook) AvailableAtOrBelow(side string, limitCents int) int {
+	if b == nil || !b.Valid {
+		return 0
+	}
+	opposite := b.NoBids
+	if side == "no" {
+		opposite = b.YesBids
+	}
+	total := 0
+	for bid, n := range opposite {
+		if 100-bid <= limitCents {
+			total += n
+		}
+	}
+	return total
+}
+
+// AvgPriceAtOrBelow returns the volume-weighted price (cents) of the
+// contracts of side that can be bought right now at a price of at most
+// limitCents, or false when there are none.
+func (b *OrderBook) AvgPriceAtOrBelow(side string, limitCents int) (float64, bool) {
+	if b == nil || !b.Valid {
+		return 0, false
+	}
+	opposite := b.NoBids
+	if side == "no" {
+		opposite = b.YesBids
+	}
+	var total, cost int
+	for bid, n := range opposite {
+		if ask := 100 - bid; ask <= limitCents {
+			total += n
+			cost += n * ask
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return float64(cost) / float64(total), true
+}
+
+// BestAsk returns the lowest price (cents) side can be bought at, or -1
+// when that side has no liquidity.
+func (b *OrderBook) BestAsk(side string) int {
+	if b == nil || !b.Valid {
+		return -1
+	}
+	opposite := b.NoBids
+	if side == "no" {
+		opposite = b.YesBids
+	}
+	best := -1
+	for bid := range opposite {
+		if best < 0 || 100-bid < best {
+			best = 100 - bid
+		}
+	}
+	return best
+}
+
+func levelsToMap(levels []events.BookLevel) map[int]int {
+	m := make(map[int]int, len(levels))
+	for _, l := range levels {
+		if l.Count > 0 {
+			m[l.Price] += l.Count
+		}
+	}
+	return m
+}
@@ -1,6 +1,8 @@
 package soccer
 
 import (
+	"maps"
+	"slices"
 	"strings"
 
 	game "github.com/charleschow/hft-trading/internal/core/state/game"
@@ -19,6 +21,10 @@ type SoccerState struct {
 	Half      string  // "1st Half", "2nd Half", "Half Time", "Extra Time", etc.
 	TimeLeft  float64 // minutes remaining in regulation
 
+	// ExtraTimeLeft is the minutes of extra time remaining while it is
+	// played; TimeLeft is 0 by then.
+	ExtraTimeLeft float64
+
 	HomeStrength float64 // pregame 1X2 probs (0–1)
 	DrawPct      float64
 	AwayStrength float64
@@ -33,6 +39,10 @@ type SoccerState struct {
 	SeriesSlug string
 	SeriesName string
 
+	// Totals and spread markets on the match, settled on the regulation
+	// score and priced from the same model.
+	Lines []game.LineMarket
+
 	ModelHomeYes float64 // 0–100
 	ModelDrawYes float64
 	ModelAwayYes float64
@@ -169,6 +179,16 @@ func (s *SoccerState) CheckScoreDrop(homeScore, awayScore int, confirmSec int) s
 	return s.ScoreDropTracker.CheckDrop(s.HomeScore, s.AwayScore, homeScore, awayScore, confirmSec)
 }
 
+// UpdateExtraTime sets the extra-time clock. Outside extra time it is
+// cleared.
+func (s *SoccerState) UpdateExtraTime(minutesLeft float64) {
+	if !s.IsExtraTime() {
+		s.ExtraTimeLeft = 0
+		return
+	}
+	s.ExtraTimeLeft = minutesLeft
+}
+
 // UpdateRedCards sets the current counts.
 func (s *SoccerState) UpdateRedCards(home, away int) {
 	s.HomeRedCards = home
@@ -176,10 +196,17 @@ func (s *SoccerState) UpdateRedCards(home, away int) {
 }
 
 func (s *SoccerState) RegulationGoalDiff() int {
+	home, away := s.RegulationScore()
+	return home - away
+}
+
+// RegulationScore is the score at the end of regulation once extra time
+// or penalties have started, and the live score before that.
+func (s *SoccerState) RegulationScore() (home, away int) {
 	if s.regulationScoreFrozen && s.regHomeFrozen != nil && s.regAwayFrozen != nil {
-		return *s.regHomeFrozen - *s.regAwayFrozen
+		return *s.regHomeFrozen, *s.regAwayFrozen
 	}
-	return s.GoalDiff()
+	return s.HomeScore, s.AwayScore
 }
 
 func isHalfPostRegulation(half string) bool {
@@ -199,11 +226,28 @@ func (s *SoccerState) SetTickers(home, away, draw string) {
 	s.DrawTicker = draw
 }
 
+func (s *SoccerState) GetTickers() (home, away, draw string) {
+	return s.HomeTicker, s.AwayTicker, s.DrawTicker
+}
+
+// SetLines attaches the match's totals and spread markets.
+func (s *SoccerState) SetLines(lines []game.LineMarket) {
+	s.Lines = lines
+}
+
 func (s *SoccerState) SetIdentifiers(eid, league string) {
 	s.EID = eid
 	s.League = league
 }
 
+// Clone copies the state with its own record of ordered trades and lines.
+func (s *SoccerState) Clone() game.GameState {
+	c := *s
+	c.orderedTrades = maps.Clone(s.orderedTrades)
+	c.Lines = slices.Clone(s.Lines)
+	return &c
+}
+
 // SetPregame writes pregame odds directly. Called by the engine during
 // initialization — the pregame HTTP is the source of truth for orientation.
 func (s *SoccerState) SetPregame(home, away, draw, g0 float64) {
@@ -224,10 +268,10 @@ func (s *SoccerState) RecalcEdge(tickers map[string]*game.TickerData) {
 	s.EdgeHomeNo = edgeFor(s.ModelHomeNo, noAsk(tickers, s.HomeTicker))
 	s.EdgeDrawNo = edgeFor(s.ModelDrawNo, noAsk(tickers, s.DrawTicker))
 	s.EdgeAwayNo = edgeFor(s.ModelAwayNo, noAsk(tickers, s.AwayTicker))
+	game.RecalcLineEdges(s.Lines, tickers)
 }
 
-func (s *SoccerState) HasSignificantEdge() bool {
-	t := game.EdgeThresholdPct()
+func (s *SoccerState) HasSignificantEdge(t float64) bool {
 	for _, e := range []float64{
 		s.EdgeHomeYes, s.EdgeDrawYes, s.EdgeAwayYes,
 		s.EdgeHomeNo, s.EdgeDrawNo, s.EdgeAwayNo,
@@ -236,7 +280,7 @@ func (s *SoccerState) HasSignificantEdge() bool {
 			return true
 		}
 	}
-	return false
+	return game.LinesHaveEdge(s.Lines, t)
 }
 
 func edgeFor(model, ask float64) float64 {
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/config"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+var strategyParams atomic.Pointer[config.StrategyParamsFile]
+
+// LoadStrategyParams reads the strategy parameter file and makes it the
+// one Params resolves from.
+func LoadStrategyParams(path string) error {
+	f, err := config.LoadStrategyParams(path)
+	if err != nil {
+		return err
+	}
+	strategyParams.Store(&f)
+	return nil
+}
+
+// ParamsFor resolves the strategy parameters of a league, or the defaults
+// before any file is loaded.
+func ParamsFor(sport events.Sport, league string) config.StrategyParams {
+	if f := strategyParams.Load(); f != nil {
+		return f.For(string(sport), league)
+	}
+	return config.DefaultStrategyParams
+}
+
+// Params returns the strategy parameters for the game's sport and league.
+func (gc *GameContext) Params() config.StrategyParams {
+	return ParamsFor(gc.Sport, gc.League)
+}
+
+// WatchStrategyParams reloads the parameter file whenever its modification
+// time changes, until ctx is done. A file that fails to load leaves the
+// previous parameters in force.
+func WatchStrategyParams(ctx context.Context, path string, interval time.Duration) {
+	var last time.Time
+	if fi, err := os.Stat(path); err == nil {
+		last = fi.ModTime()
+	}
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+		fi, err := os.Stat(path)
+		if err != nil || fi.ModTime().Equal(last) {
+			continue
+		}
+		last = fi.ModTime()
+		if err := LoadStrategyParams(path); err != nil {
+			telemetry.Warnf("[PARAMS] reload %s failed, keeping previous values: %v", path, err)
+			continue
+		}
+		telemetry.Infof("[PARAMS] reloaded %s", path)
+	}
+}
@@ -1,21 +1,29 @@
 package trading
 
 // OrderState tracks open orders for a single game.
-// NOT thread-safe on its own — protected by GameContext.Mu.
+// NOT thread-safe on its own — owned by the game's goroutine, so it must
+// only be touched inside a GameContext.Send closure.
 type OrderState struct {
 	Open  map[string]*OpenOrder // orderID -> order
 	Dedup map[string]bool       // dedupKey -> placed
 }
 
 type OpenOrder struct {
-	OrderID string
-	Ticker  string
-	Side    string
-	Count   int
-	Price   int
-	Status  string // "pending", "open", "filled", "cancelled"
+	OrderID       string
+	ClientOrderID string
+	Ticker        string
+	Side          string
+	Count         int // max fillable contracts (filled + remaining)
+	Filled        int
+	Price         int    // limit in cents for Side
+	Status        string // "pending", "open", "filled", "cancelled"
 }
 
+const (
+	StatusOpen    = "open"
+	StatusPending = "pending" // an amend or decrease is in flight
+)
+
 func NewOrderState() *OrderState {
 	return &OrderState{
 		Open:  make(map[string]*OpenOrder),
@@ -36,6 +44,17 @@ func (o *OrderState) GetOrder(orderID string) (*OpenOrder, bool) {
 	return order, ok
 }
 
+// OrdersFor returns the open orders on one ticker and side.
+func (o *OrderState) OrdersFor(ticker, side string) []*OpenOrder {
+	var out []*OpenOrder
+	for _, order := range o.Open {
+		if order.Ticker == ticker && order.Side == side {
+			out = append(out, order)
+		}
+	}
+	return out
+}
+
 func (o *OrderState) OpenCount() int {
 	return len(o.Open)
 }
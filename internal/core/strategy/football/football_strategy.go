@@ -1,6 +1,7 @@
 package football
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/charleschow/hft-trading/internal/core/display"
@@ -26,8 +27,9 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 		return strategy.EvalResult{}
 	}
 
+	overturn := false
 	if fs.HasLIVEData() {
-		result := fs.CheckScoreDrop(gu.HomeScore, gu.AwayScore, 15)
+		result := fs.CheckScoreDrop(gu.HomeScore, gu.AwayScore, gc.Params().ConfirmSec)
 		switch result {
 		case "new_drop":
 			telemetry.Infof("[OVERTURN-PENDING] %s vs %s (%d-%d -> %d-%d)",
@@ -55,6 +57,7 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 			}
 			gc.Notify(string(events.StatusOverturnRejected))
 		case "confirmed":
+			overturn = true
 			telemetry.Infof("[OVERTURN-CONFIRMED] %s vs %s (%d-%d -> %d-%d)",
 				gu.HomeTeam, gu.AwayTeam, fs.GetHomeScore(), fs.GetAwayScore(), gu.HomeScore, gu.AwayScore)
 			gc.LastOverturn = &game.OverturnInfo{
@@ -65,23 +68,108 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 		}
 	}
 
+	hadLIVEData := fs.HasLIVEData()
 	changed := fs.UpdateGameState(gu.HomeScore, gu.AwayScore, gu.Period, gu.TimeLeft)
-	if !changed {
+
+	s.computeModel(fs)
+	fs.RecalcEdge(gc.Tickers)
+
+	if !changed && !overturn {
 		return strategy.EvalResult{}
 	}
 
+	scoreChanged := hadLIVEData && changed
+
 	telemetry.Metrics.ScoreChanges.Inc()
 
-	// TODO: plug in football model and edge detection
+	if (scoreChanged || overturn) && !fs.IsFinished() && fs.HasSignificantEdge(gc.Params().EdgeThresholdPct) {
+		return strategy.EvalResult{
+			Intents: s.buildOrderIntents(gc, fs, overturn),
+		}
+	}
+
 	return strategy.EvalResult{}
 }
 
+func (s *Strategy) computeModel(fs *fbState.FootballState) {
+	lead := float64(fs.Lead())
+
+	var home, away float64
+	switch {
+	case fs.IsOVERTIME():
+		home, away = OvertimeWinProb(fs.League, fs.HomePregameStrength, lead, fs.TimeLeft)
+	case fs.IsHalftime():
+		home = WinProb(fs.League, fs.HomePregameStrength, lead, regulationMin/2)
+		away = 1 - home
+	default:
+		home = WinProb(fs.League, fs.HomePregameStrength, lead, fs.TimeLeft)
+		away = 1 - home
+	}
+
+	fs.ModelHomePct = home * 100
+	fs.ModelAwayPct = away * 100
+}
+
 func (s *Strategy) DisplayGame(gc *game.GameContext, eventType string) {
 	display.PrintFootball(gc, eventType)
 }
 
+// OnPriceUpdate requotes the game's resting orders at the current model
+// price, as hockey does.
 func (s *Strategy) OnPriceUpdate(gc *game.GameContext) []events.OrderIntent {
-	return nil
+	fs, ok := gc.Game.(*fbState.FootballState)
+	if !ok || gc.Orders.OpenCount() == 0 {
+		return nil
+	}
+	if fs.IsFinished() || fs.IsScoreDropPending() {
+		return nil
+	}
+
+	intents := s.buildOrderIntents(gc, fs, false)
+	for i := range intents {
+		intents[i].Requote = true
+	}
+	return intents
+}
+
+// buildOrderIntents fires 4 orders covering both markets when a score
+// change (or confirmed overturn) occurs and at least one significant edge
+// exists.
+func (s *Strategy) buildOrderIntents(gc *game.GameContext, fs *fbState.FootballState, overturn bool) []events.OrderIntent {
+	var intents []events.OrderIntent
+	t := gc.Params().EdgeThresholdPct
+
+	for _, m := range []struct {
+		ticker, outcome string
+		model           float64
+	}{
+		{fs.HomeTicker, "home", fs.ModelHomePct},
+		{fs.AwayTicker, "away", fs.ModelAwayPct},
+	} {
+		if m.ticker == "" {
+			continue
+		}
+		intents = append(intents,
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: m.ticker, Side: "yes", Outcome: m.outcome,
+				LimitPct:  m.model - t,
+				ModelPct:  m.model,
+				Reason:    fmt.Sprintf("model %.1f%% YES", m.model),
+				HomeScore: fs.HomeScore, AwayScore: fs.AwayScore, Overturn: overturn,
+			},
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: m.ticker, Side: "no", Outcome: m.outcome,
+				LimitPct:  (100 - m.model) - t,
+				ModelPct:  100 - m.model,
+				Reason:    fmt.Sprintf("model %.1f%% NO", 100-m.model),
+				HomeScore: fs.HomeScore, AwayScore: fs.AwayScore, Overturn: overturn,
+			},
+		)
+	}
+
+	return intents
 }
 
 func (s *Strategy) OnFinish(gc *game.GameContext, gu *events.GameUpdateEvent) []events.OrderIntent {
@@ -90,7 +178,59 @@ func (s *Strategy) OnFinish(gc *game.GameContext, gu *events.GameUpdateEvent) []
 		return nil
 	}
 
-	_ = fs
-	// TODO: emit slam orders for settled markets
-	return nil
+	s.computeModel(fs)
+	return s.slamOrders(gc, fs, gu)
+}
+
+// slamOrders buys YES on the winner and NO on the loser at 99¢. A tied
+// final (NFL regular season overtime can end level) settles neither side
+// as a win, so nothing is slammed.
+func (s *Strategy) slamOrders(gc *game.GameContext, fs *fbState.FootballState, gu *events.GameUpdateEvent) []events.OrderIntent {
+	if gu.HomeScore == gu.AwayScore {
+		return nil
+	}
+
+	winTicker, loseTicker := fs.HomeTicker, fs.AwayTicker
+	winOutcome, loseOutcome := "home", "away"
+	if gu.AwayScore > gu.HomeScore {
+		winTicker, loseTicker = loseTicker, winTicker
+		winOutcome, loseOutcome = loseOutcome, winOutcome
+	}
+
+	reason := fmt.Sprintf("game finished %d-%d", gu.HomeScore, gu.AwayScore)
+
+	var intents []events.OrderIntent
+	if winTicker != "" {
+		intents = append(intents, events.OrderIntent{
+			Sport:     gu.Sport,
+			League:    gu.League,
+			GameID:    gu.EID,
+			EID:       gu.EID,
+			Ticker:    winTicker,
+			Side:      "yes",
+			Outcome:   winOutcome,
+			LimitPct:  99,
+			Reason:    reason,
+			HomeScore: gu.HomeScore,
+			AwayScore: gu.AwayScore,
+			Slam:      true,
+		})
+	}
+	if loseTicker != "" {
+		intents = append(intents, events.OrderIntent{
+			Sport:     gu.Sport,
+			League:    gu.League,
+			GameID:    gu.EID,
+			EID:       gu.EID,
+			Ticker:    loseTicker,
+			Side:      "no",
+			Outcome:   loseOutcome,
+			LimitPct:  99,
+			Reason:    reason,
+			HomeScore: gu.HomeScore,
+			AwayScore: gu.AwayScore,
+			Slam:      true,
+		})
+	}
+	return intents
 }
@@ -0,0 +1,125 @@
+package football
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	regulationMin = 60.0
+
+	// otMinutes is the expected play left once overtime starts. NFL
+	// overtime is a 10-minute period but rarely runs long; NCAAF has no
+	// clock, and each side's possession is treated as the same horizon.
+	otMinutes = 5.0
+
+	// otClockMin is the length of the NFL overtime period. A regular
+	// season game still level when it runs out ends tied.
+	otClockMin = 10.0
+
+	// otStrengthWeight shrinks pregame strength toward a coin flip for
+	// the winner of a tied game going to overtime.
+	otStrengthWeight = 0.5
+)
+
+// marginSigma is the standard deviation of the final scoring margin over a
+// full game, by league. Stern's NFL estimate; college margins are wider.
+var marginSigma = map[string]float64{
+	"nfl":   13.45,
+	"ncaaf": 16.0,
+}
+
+const defaultMarginSigma = 13.45
+
+// WinProb returns the home side's win probability given its pregame
+// strength (0–1), the current lead and the minutes of play left.
+//
+// The margin from here to the final whistle is modeled as normal with the
+// pregame expected margin and variance both scaled by the share of the game
+// left. The expected margin is backed out of the pregame moneyline through
+// the same normal. Final margins within half a point of zero go to
+// overtime, split by otProb.
+func WinProb(league string, homeStrength, lead, minutesLeft float64) float64 {
+	strength := math.Max(0.001, math.Min(0.999, homeStrength))
+	sigma := sigmaFor(league)
+	mu := sigma * normInv(strength)
+
+	if minutesLeft <= 0 {
+		switch {
+		case lead > 0:
+			return 1.0
+		case lead < 0:
+			return 0.0
+		default:
+			return otProb(strength)
+		}
+	}
+
+	frac := minutesLeft / regulationMin
+	mean := lead + mu*frac
+	sd := sigma * math.Sqrt(frac)
+
+	win := 1 - normCDF((0.5-mean)/sd)
+	tie := normCDF((0.5-mean)/sd) - normCDF((-0.5-mean)/sd)
+	return win + tie*otProb(strength)
+}
+
+// OvertimeWinProb returns each side's win probability once overtime has
+// started, from the overtime score difference and the minutes left on the
+// overtime clock. NFL overtime has a clock and the expected play left
+// shrinks with it; a game level when it runs out ends tied, which pays
+// neither side, so that share is in neither probability. NCAAF overtime
+// has no clock and is played until someone wins.
+func OvertimeWinProb(league string, homeStrength, lead, minutesLeft float64) (home, away float64) {
+	strength := math.Max(0.001, math.Min(0.999, homeStrength))
+	sigma := sigmaFor(league)
+	canTie := strings.ToLower(league) == "nfl"
+
+	horizon := otMinutes
+	if canTie {
+		horizon *= math.Max(0, math.Min(1, minutesLeft/otClockMin))
+	}
+	if horizon <= 0 {
+		switch {
+		case lead > 0:
+			return 1, 0
+		case lead < 0:
+			return 0, 1
+		default:
+			return 0, 0
+		}
+	}
+
+	frac := horizon / regulationMin
+	mu := sigma * normInv(0.5+(strength-0.5)*otStrengthWeight)
+	mean := lead + mu*frac
+	sd := sigma * math.Sqrt(frac)
+
+	win := 1 - normCDF((0.5-mean)/sd)
+	tie := normCDF((0.5-mean)/sd) - normCDF((-0.5-mean)/sd)
+	loss := 1 - win - tie
+	if canTie {
+		return win, loss
+	}
+	p := otProb(strength)
+	return win + tie*p, loss + tie*(1-p)
+}
+
+func otProb(strength float64) float64 {
+	return 0.5 + (strength-0.5)*otStrengthWeight
+}
+
+func sigmaFor(league string) float64 {
+	if s, ok := marginSigma[strings.ToLower(league)]; ok {
+		return s
+	}
+	return defaultMarginSigma
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normInv(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
@@ -0,0 +1,94 @@
+package football
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWinProb(t *testing.T) {
+	tests := []struct {
+		name        string
+		league      string
+		strength    float64
+		lead        float64
+		minutesLeft float64
+		want        float64
+		tol         float64
+	}{
+		{"even at kickoff", "NFL", 0.5, 0, 60, 0.5, 1e-9},
+		{"kickoff follows the moneyline", "NFL", 0.7, 0, 60, 0.7, 0.01},
+		{"college kickoff", "NCAAF", 0.3, 0, 60, 0.3, 0.01},
+		{"lead at the whistle", "NFL", 0.2, 3, 0, 1, 0},
+		{"trailing at the whistle", "NFL", 0.8, -1, 0, 0, 0},
+		{"level at the whistle goes to overtime", "NFL", 0.7, 0, 0, 0.6, 1e-9},
+		{"two scores up late", "NFL", 0.5, 14, 2, 1, 0.001},
+		{"one score down late", "NFL", 0.5, -7, 2, 0.04, 0.04},
+		{"clamped strength", "NFL", 1.5, 0, 0, otProb(0.999), 1e-9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WinProb(tt.league, tt.strength, tt.lead, tt.minutesLeft)
+			if math.Abs(got-tt.want) > tt.tol {
+				t.Errorf("WinProb = %.4f, want %.4f ± %g", got, tt.want, tt.tol)
+			}
+		})
+	}
+}
+
+func TestWinProbMonotone(t *testing.T) {
+	for _, league := range []string{"NFL", "NCAAF"} {
+		prev := 0.0
+		for lead := -21.0; lead <= 21; lead++ {
+			p := WinProb(league, 0.6, lead, 20)
+			if p < prev {
+				t.Fatalf("%s: win prob fell from %.4f to %.4f at lead %v", league, prev, p, lead)
+			}
+			prev = p
+		}
+	}
+	// A lead is worth more with less time to overturn it.
+	if early, late := WinProb("NFL", 0.5, 7, 50), WinProb("NFL", 0.5, 7, 5); late <= early {
+		t.Errorf("7-point lead: %.4f with 50 left, %.4f with 5 left", early, late)
+	}
+}
+
+func TestOvertimeWinProb(t *testing.T) {
+	tests := []struct {
+		name        string
+		league      string
+		strength    float64
+		lead        float64
+		minutesLeft float64
+		wantHome    float64 // -1 to check only the tie share
+		wantAway    float64
+		wantTie     bool // some probability that neither side wins
+	}{
+		{"NFL level as the clock runs out ends tied", "NFL", 0.6, 0, 0, 0, 0, true},
+		{"NFL lead as the clock runs out", "NFL", 0.4, 3, 0, 1, 0, false},
+		{"NFL trailing as the clock runs out", "NFL", 0.6, -3, 0, 0, 1, false},
+		{"NFL level with the clock to play", "NFL", 0.5, 0, 10, -1, -1, true},
+		{"NCAAF is played to a winner", "NCAAF", 0.5, 0, 0, 0.5, 0.5, false},
+		{"NCAAF favourite", "NCAAF", 0.8, 0, 0, -1, -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home, away := OvertimeWinProb(tt.league, tt.strength, tt.lead, tt.minutesLeft)
+			if tie := 1 - home - away; (tie > 1e-9) != tt.wantTie {
+				t.Errorf("tie share %.4f, want tie %v", tie, tt.wantTie)
+			}
+			if tt.wantHome >= 0 && (math.Abs(home-tt.wantHome) > 1e-9 || math.Abs(away-tt.wantAway) > 1e-9) {
+				t.Errorf("OvertimeWinProb = %.4f/%.4f, want %.4f/%.4f", home, away, tt.wantHome, tt.wantAway)
+			}
+			if tt.strength > 0.5 && tt.lead == 0 && home < away {
+				t.Errorf("favourite %.4f, underdog %.4f", home, away)
+			}
+		})
+	}
+
+	// Less NFL clock leaves more of a level game to end tied.
+	h10, a10 := OvertimeWinProb("NFL", 0.5, 0, 10)
+	h2, a2 := OvertimeWinProb("NFL", 0.5, 0, 2)
+	if 1-h2-a2 <= 1-h10-a10 {
+		t.Errorf("tie share %.4f with 2 left, %.4f with 10 left", 1-h2-a2, 1-h10-a10)
+	}
+}
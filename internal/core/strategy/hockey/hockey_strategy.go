@@ -2,6 +2,7 @@ package hockey
 
 import (
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/charleschow/hft-trading/internal/core/display"
@@ -17,12 +18,23 @@ import (
 // the strategy only handles live evaluation, model computation, and order generation.
 type Strategy struct {
 	lastPendingLog time.Time
+
+	// model prices both sides (0–100); nil is RawModel with the league
+	// recalibration.
+	model func(hs *hockeyState.HockeyState) (home, away float64)
 }
 
 func NewStrategy() *Strategy {
 	return &Strategy{}
 }
 
+// NewStrategyWithModel returns a strategy that prices games with model
+// instead of RawModel, for running a candidate model as a shadow. The
+// league recalibration is fitted to RawModel and is not applied.
+func NewStrategyWithModel(model func(hs *hockeyState.HockeyState) (home, away float64)) *Strategy {
+	return &Strategy{model: model}
+}
+
 func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) strategy.EvalResult {
 	hs, ok := gc.Game.(*hockeyState.HockeyState)
 	if !ok {
@@ -31,7 +43,7 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 
 	overturn := false
 	if hs.HasLIVEData() {
-		result := hs.CheckScoreDrop(gu.HomeScore, gu.AwayScore, 15)
+		result := hs.CheckScoreDrop(gu.HomeScore, gu.AwayScore, gc.Params().ConfirmSec)
 		switch result {
 		case "new_drop":
 			telemetry.Infof("[OVERTURN-PENDING] %s vs %s (%d-%d -> %d-%d)",
@@ -71,8 +83,9 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 	}
 
 	hadLIVEData := hs.HasLIVEData()
+	prevHome, prevAway := hs.HomeScore, hs.AwayScore
 	changed := hs.UpdateGameState(gu.HomeScore, gu.AwayScore, gu.Period, gu.TimeLeft)
-	s.updatePowerPlay(gc, hs, gu)
+	ppChanged := s.updatePowerPlay(gc, hs, gu, prevHome, prevAway)
 
 	s.computeModel(hs)
 	hs.RecalcEdge(gc.Tickers)
@@ -88,6 +101,11 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 	}
 
 	if !changed && !overturn {
+		// A power play starting, ending or going to 5-on-3 moves the
+		// model without a goal: move the resting orders with it.
+		if ppChanged && hadLIVEData {
+			return strategy.EvalResult{Intents: s.requoteIntents(gc, hs)}
+		}
 		return strategy.EvalResult{}
 	}
 
@@ -96,7 +114,7 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 	telemetry.Metrics.ScoreChanges.Inc()
 
 	// Build orders if the score changed or overturn occurred, and there is a significant edge.
-	if (scoreChanged || overturn) && hs.HasSignificantEdge() {
+	if (scoreChanged || overturn) && hs.HasSignificantEdge(gc.Params().EdgeThresholdPct) {
 		return strategy.EvalResult{
 			Intents: s.buildOrderIntents(gc, hs, overturn),
 		}
@@ -115,37 +133,149 @@ func (s *Strategy) OnFinish(gc *game.GameContext, gu *events.GameUpdateEvent) []
 	return s.slamOrders(gc, hs, gu)
 }
 
+// computeModel sets the state's model prices from RawModel, recalibrated
+// for the league while regulation is live, or from the strategy's own
+// model when it has one. Lines are always priced by the Poisson model.
 func (s *Strategy) computeModel(hs *hockeyState.HockeyState) {
-	lead := float64(hs.Lead())
-	if hs.IsOVERTIME() && lead != 0 {
-		if lead > 0 {
-			hs.ModelHomePct = 100.0
-			hs.ModelAwayPct = 0.0
-		} else {
-			hs.ModelHomePct = 0.0
-			hs.ModelAwayPct = 100.0
-		}
+	priceLines(hs)
+	if s.model != nil {
+		hs.ModelHomePct, hs.ModelAwayPct = s.model(hs)
+		return
+	}
+	hs.ModelHomePct, hs.ModelAwayPct = RawModel(hs)
+	if hs.IsOVERTIME() || hs.IsShootout() {
 		return
 	}
+	r := currentParams().Recal.For(hs.League)
+	hs.ModelHomePct = r.Apply(hs.ModelHomePct/100) * 100
+	hs.ModelAwayPct = r.Apply(hs.ModelAwayPct/100) * 100
+}
+
+// RawModel prices both sides (0–100) with ProjectedOddsV2, then shifts
+// them by what V4 adds over the plain Poisson model (ProjectedOddsV3):
+// remaining power plays, empty-net play and the league's overtime and
+// shootout in place of a 50/50 tie. Once overtime starts it is priced
+// directly.
+func RawModel(hs *hockeyState.HockeyState) (home, away float64) {
+	if home, away, ok := overtimeModel(hs); ok {
+		return home, away
+	}
+	lead := float64(hs.Lead())
+	home = ProjectedOddsV2(hs.HomeStrength, hs.TimeLeft, lead) * 100
+	away = ProjectedOddsV2(hs.AwayStrength, hs.TimeLeft, -lead) * 100
+
+	homePP, awayPP := powerPlays(hs)
+	home = clampPct(home + modelShift(hs.League, hs.HomeStrength, hs.TimeLeft, lead, homePP, awayPP))
+	away = clampPct(away + modelShift(hs.League, hs.AwayStrength, hs.TimeLeft, -lead, awayPP, homePP))
+	return home, away
+}
+
+// PoissonModel prices regulation with ProjectedOddsV4 alone, without the
+// V2 anchor RawModel keeps. Overtime is priced as in RawModel.
+func PoissonModel(hs *hockeyState.HockeyState) (home, away float64) {
+	if home, away, ok := overtimeModel(hs); ok {
+		return home, away
+	}
+	lead := float64(hs.Lead())
+	timeLeft := math.Min(hs.TimeLeft, 60)
+	homePP, awayPP := powerPlays(hs)
+	home = ProjectedOddsV4(hs.League, hs.HomeStrength, timeLeft, lead, homePP, awayPP) * 100
+	away = ProjectedOddsV4(hs.League, hs.AwayStrength, timeLeft, -lead, awayPP, homePP) * 100
+	return clampPct(home), clampPct(away)
+}
+
+// overtimeModel prices overtime and the shootout; ok is false in
+// regulation.
+func overtimeModel(hs *hockeyState.HockeyState) (home, away float64, ok bool) {
+	lead := hs.Lead()
+	switch {
+	case hs.IsOVERTIME() && lead > 0:
+		return 100, 0, true
+	case hs.IsOVERTIME() && lead < 0:
+		return 0, 100, true
+	case hs.IsShootout():
+		return ShootoutWinProb(hs.HomeStrength) * 100, ShootoutWinProb(hs.AwayStrength) * 100, true
+	case hs.IsOVERTIME():
+		return OvertimeWinProb(hs.League, hs.HomeStrength, hs.TimeLeft) * 100,
+			OvertimeWinProb(hs.League, hs.AwayStrength, hs.TimeLeft) * 100, true
+	}
+	return 0, 0, false
+}
+
+// powerPlays returns the power-play time left for each side.
+func powerPlays(hs *hockeyState.HockeyState) (homePP, awayPP PowerPlay) {
+	homePP.FiveOnFour, homePP.FiveOnThree = hs.PowerPlayMinutes(true)
+	awayPP.FiveOnFour, awayPP.FiveOnThree = hs.PowerPlayMinutes(false)
+	return homePP, awayPP
+}
 
-	hs.ModelHomePct = ProjectedOddsV2(hs.HomeStrength, hs.TimeLeft, lead) * 100
-	hs.ModelAwayPct = ProjectedOddsV2(hs.AwayStrength, hs.TimeLeft, -lead) * 100
+// modelShift is the change in win probability (0–100) that V4 makes to
+// the plain Poisson price, both at the league's scoring rate.
+func modelShift(league string, strength, timeLeft, lead float64, teamPP, oppPP PowerPlay) float64 {
+	timeLeft = math.Min(timeLeft, 60)
+	base := ProjectedOddsV3PP(league, strength, timeLeft, lead, PowerPlay{}, PowerPlay{})
+	v4 := ProjectedOddsV4(league, strength, timeLeft, lead, teamPP, oppPP)
+	return (v4 - base) * 100
+}
+
+func clampPct(p float64) float64 {
+	return math.Max(0, math.Min(100, p))
 }
 
 func (s *Strategy) DisplayGame(gc *game.GameContext, eventType string) {
 	display.PrintHockey(gc, eventType)
 }
 
+// OnPriceUpdate requotes the game's resting orders at the current model
+// price. The model itself is refreshed on every game update; a price tick
+// is when the resting quotes get checked against it.
 func (s *Strategy) OnPriceUpdate(gc *game.GameContext) []events.OrderIntent {
-	return nil
+	hs, ok := gc.Game.(*hockeyState.HockeyState)
+	if !ok || gc.Orders.OpenCount() == 0 {
+		return nil
+	}
+	if hs.IsFinished() || hs.Finaled() || hs.IsScoreDropPending() {
+		return nil
+	}
+
+	return s.requoteIntents(gc, hs)
 }
 
-func (s *Strategy) updatePowerPlay(gc *game.GameContext, hs *hockeyState.HockeyState, gu *events.GameUpdateEvent) {
+// requoteIntents reprices the game's resting orders at the current model.
+func (s *Strategy) requoteIntents(gc *game.GameContext, hs *hockeyState.HockeyState) []events.OrderIntent {
+	if gc.Orders.OpenCount() == 0 {
+		return nil
+	}
+	intents := s.buildOrderIntents(gc, hs, false)
+	for i := range intents {
+		intents[i].Requote = true
+	}
+	return intents
+}
+
+// updatePowerPlay tracks who is on the power play and the minor penalties
+// being served, and reports whether the manpower situation changed. A
+// power-play goal ends the earliest of the scoring side's penalties.
+func (s *Strategy) updatePowerPlay(gc *game.GameContext, hs *hockeyState.HockeyState, gu *events.GameUpdateEvent, prevHome, prevAway int) bool {
 	var homeOn, awayOn bool
+	wasHome, wasAway := len(hs.HomePPExpiry), len(hs.AwayPPExpiry)
+
+	if gu.HomeScore > prevHome {
+		hs.HomePPExpiry = dropFirstExpiry(hs.HomePPExpiry)
+	}
+	if gu.AwayScore > prevAway {
+		hs.AwayPPExpiry = dropFirstExpiry(hs.AwayPPExpiry)
+	}
 
 	if gu.PowerPlay {
 		homeDelta := gu.HomePenaltyCount - hs.HomePenaltyCount
 		awayDelta := gu.AwayPenaltyCount - hs.AwayPenaltyCount
+		for range awayDelta - homeDelta {
+			hs.HomePPExpiry = append(hs.HomePPExpiry, hs.TimeLeft-minorPenaltyMin)
+		}
+		for range homeDelta - awayDelta {
+			hs.AwayPPExpiry = append(hs.AwayPPExpiry, hs.TimeLeft-minorPenaltyMin)
+		}
 
 		switch {
 		case awayDelta > homeDelta:
@@ -164,7 +294,12 @@ func (s *Strategy) updatePowerPlay(gc *game.GameContext, hs *hockeyState.HockeyS
 	hs.HomePenaltyCount = gu.HomePenaltyCount
 	hs.AwayPenaltyCount = gu.AwayPenaltyCount
 
+	hs.HomePPExpiry = servingPenalties(hs.HomePPExpiry, homeOn, hs.IsHomePowerPlay, hs.TimeLeft)
+	hs.AwayPPExpiry = servingPenalties(hs.AwayPPExpiry, awayOn, hs.IsAwayPowerPlay, hs.TimeLeft)
+	manpower := len(hs.HomePPExpiry) != wasHome || len(hs.AwayPPExpiry) != wasAway
+
 	if homeOn != hs.IsHomePowerPlay || awayOn != hs.IsAwayPowerPlay {
+		manpower = true
 		if !homeOn && !awayOn {
 			// PP just ended — capture who had it before clearing
 			if hs.IsHomePowerPlay {
@@ -185,13 +320,52 @@ func (s *Strategy) updatePowerPlay(gc *game.GameContext, hs *hockeyState.HockeyS
 			gc.Notify(string(events.StatusPowerPlayEnd))
 		}
 	}
+	return manpower
 }
 
-// buildOrderIntents fires 4 orders covering all markets when a score change
-// (or confirmed overturn) occurs and at least one significant edge exists.
+// minorPenaltyMin is the length of a minor penalty.
+const minorPenaltyMin = 2.0
+
+// servingPenalties drops expired penalties and clears them all once the
+// feed reports the power play over. A power play whose start was not seen
+// is assumed to be a full minor; one the feed still reports after its
+// minors ran out (majors, feed lag) is left at even strength.
+func servingPenalties(exp []float64, on, wasOn bool, timeLeft float64) []float64 {
+	if !on {
+		return nil
+	}
+	if !wasOn && len(exp) == 0 {
+		return []float64{timeLeft - minorPenaltyMin}
+	}
+	live := exp[:0]
+	for _, e := range exp {
+		if e < timeLeft {
+			live = append(live, e)
+		}
+	}
+	return live
+}
+
+// dropFirstExpiry removes the penalty that would expire first.
+func dropFirstExpiry(exp []float64) []float64 {
+	if len(exp) == 0 {
+		return exp
+	}
+	first := 0
+	for i, e := range exp {
+		if e > exp[first] {
+			first = i
+		}
+	}
+	return append(exp[:first], exp[first+1:]...)
+}
+
+// buildOrderIntents fires 4 orders covering the moneyline, plus a YES and
+// a NO on each totals and spread market, when a score change (or confirmed
+// overturn) occurs and at least one significant edge exists.
 func (s *Strategy) buildOrderIntents(gc *game.GameContext, hs *hockeyState.HockeyState, overturn bool) []events.OrderIntent {
 	var intents []events.OrderIntent
-	t := game.EdgeThresholdPct()
+	t := gc.Params().EdgeThresholdPct
 
 	if hs.HomeTicker != "" {
 		intents = append(intents,
@@ -199,6 +373,7 @@ func (s *Strategy) buildOrderIntents(gc *game.GameContext, hs *hockeyState.Hocke
 				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
 				Ticker: hs.HomeTicker, Side: "yes", Outcome: "home",
 				LimitPct:  hs.ModelHomePct - t,
+				ModelPct:  hs.ModelHomePct,
 				Reason:    fmt.Sprintf("model %.1f%% YES", hs.ModelHomePct),
 				HomeScore: hs.HomeScore, AwayScore: hs.AwayScore, Overturn: overturn,
 			},
@@ -206,6 +381,7 @@ func (s *Strategy) buildOrderIntents(gc *game.GameContext, hs *hockeyState.Hocke
 				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
 				Ticker: hs.HomeTicker, Side: "no", Outcome: "home",
 				LimitPct:  (100 - hs.ModelHomePct) - t,
+				ModelPct:  100 - hs.ModelHomePct,
 				Reason:    fmt.Sprintf("model %.1f%% NO", 100-hs.ModelHomePct),
 				HomeScore: hs.HomeScore, AwayScore: hs.AwayScore, Overturn: overturn,
 			},
@@ -219,6 +395,7 @@ func (s *Strategy) buildOrderIntents(gc *game.GameContext, hs *hockeyState.Hocke
 				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
 				Ticker: hs.AwayTicker, Side: "yes", Outcome: "away",
 				LimitPct:  hs.ModelAwayPct - t,
+				ModelPct:  hs.ModelAwayPct,
 				Reason:    fmt.Sprintf("model %.1f%% YES", hs.ModelAwayPct),
 				HomeScore: hs.HomeScore, AwayScore: hs.AwayScore, Overturn: overturn,
 			},
@@ -226,12 +403,37 @@ func (s *Strategy) buildOrderIntents(gc *game.GameContext, hs *hockeyState.Hocke
 				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
 				Ticker: hs.AwayTicker, Side: "no", Outcome: "away",
 				LimitPct:  (100 - hs.ModelAwayPct) - t,
+				ModelPct:  100 - hs.ModelAwayPct,
 				Reason:    fmt.Sprintf("model %.1f%% NO", 100-hs.ModelAwayPct),
 				HomeScore: hs.HomeScore, AwayScore: hs.AwayScore, Overturn: overturn,
 			},
 		)
 	}
 
+	for _, l := range hs.Lines {
+		if !l.Priced {
+			continue
+		}
+		intents = append(intents,
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: l.Ticker, Side: "yes", Outcome: l.Outcome(),
+				LimitPct:  l.ModelYes - t,
+				ModelPct:  l.ModelYes,
+				Reason:    fmt.Sprintf("model %.1f%% YES %s", l.ModelYes, l.Outcome()),
+				HomeScore: hs.HomeScore, AwayScore: hs.AwayScore, Overturn: overturn,
+			},
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: l.Ticker, Side: "no", Outcome: l.Outcome(),
+				LimitPct:  (100 - l.ModelYes) - t,
+				ModelPct:  100 - l.ModelYes,
+				Reason:    fmt.Sprintf("model %.1f%% NO %s", 100-l.ModelYes, l.Outcome()),
+				HomeScore: hs.HomeScore, AwayScore: hs.AwayScore, Overturn: overturn,
+			},
+		)
+	}
+
 	return intents
 }
 
@@ -291,5 +493,27 @@ func (s *Strategy) slamOrders(gc *game.GameContext, hs *hockeyState.HockeyState,
 		})
 	}
 
+	// The final score settles every line too.
+	for _, l := range hs.Lines {
+		side := "no"
+		if l.Settles(gu.HomeScore, gu.AwayScore) {
+			side = "yes"
+		}
+		intents = append(intents, events.OrderIntent{
+			Sport:     gu.Sport,
+			League:    gu.League,
+			GameID:    gu.EID,
+			EID:       gu.EID,
+			Ticker:    l.Ticker,
+			Side:      side,
+			Outcome:   l.Outcome(),
+			LimitPct:  99,
+			Reason:    reason,
+			HomeScore: gu.HomeScore,
+			AwayScore: gu.AwayScore,
+			Slam:      true,
+		})
+	}
+
 	return intents
 }
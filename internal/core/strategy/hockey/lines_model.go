@@ -0,0 +1,86 @@
+package hockey
+
+import (
+	"math"
+
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+)
+
+// Totals and spreads are priced from the V3 Poisson model: the same
+// expected goals per side (power plays included) give a distribution over
+// final scores, and each line sums the scores that settle it YES. A game
+// level after regulation is recorded with one extra goal for the side
+// that wins overtime or the shootout.
+
+// finalScore is one possible final score and its probability.
+type finalScore struct {
+	home, away int
+	p          float64
+}
+
+// priceLines sets each of the game's lines' model price (0–100).
+func priceLines(hs *hockeyState.HockeyState) {
+	if len(hs.Lines) == 0 {
+		return
+	}
+	scores := finalScores(hs)
+	for i := range hs.Lines {
+		l := &hs.Lines[i]
+		var yes float64
+		for _, fs := range scores {
+			if l.Settles(fs.home, fs.away) {
+				yes += fs.p
+			}
+		}
+		l.ModelYes = clampPct(yes * 100)
+		l.Priced = true
+	}
+}
+
+// finalScores returns the distribution of final scores from the live
+// score and clock.
+func finalScores(hs *hockeyState.HockeyState) []finalScore {
+	home, away := hs.HomeScore, hs.AwayScore
+	if hs.IsFinished() || (hs.IsOVERTIME() && home != away) {
+		return []finalScore{{home, away, 1}}
+	}
+
+	var pHomeOT float64
+	switch {
+	case hs.IsShootout():
+		pHomeOT = ShootoutWinProb(hs.HomeStrength)
+	case hs.IsOVERTIME():
+		pHomeOT = OvertimeWinProb(hs.League, hs.HomeStrength, hs.TimeLeft)
+	default:
+		pHomeOT = OvertimeWinProb(hs.League, hs.HomeStrength, overtimeFor(hs.League).Minutes)
+	}
+	level := func(h, a int, p float64) []finalScore {
+		return []finalScore{{h + 1, a, p * pHomeOT}, {h, a + 1, p * (1 - pHomeOT)}}
+	}
+
+	if hs.IsOVERTIME() || hs.IsShootout() {
+		return level(home, away, 1)
+	}
+
+	timeLeft := math.Max(0, math.Min(hs.TimeLeft, 60))
+	homePP, awayPP := powerPlays(hs)
+	strength := math.Max(0.001, math.Min(0.999, hs.HomeStrength))
+	muHome, muAway := expectedGoals(hs.League, strength, timeLeft, homePP, awayPP)
+
+	var out []finalScore
+	for x := 0; x <= poissonMaxGoals; x++ {
+		px := poissPMF(muHome, x)
+		for y := 0; y <= poissonMaxGoals; y++ {
+			p := px * poissPMF(muAway, y)
+			if p < 1e-9 {
+				continue
+			}
+			if h, a := home+x, away+y; h == a {
+				out = append(out, level(h, a, p)...)
+			} else {
+				out = append(out, finalScore{h, a, p})
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package hockey
+
+import (
+	"math"
+	"testing"
+
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+)
+
+func TestFinalScores(t *testing.T) {
+	const strength = 0.6
+	otHome := OvertimeWinProb("NHL", strength, overtimeFor("NHL").Minutes)
+	soHome := ShootoutWinProb(strength)
+
+	tests := []struct {
+		name       string
+		period     string
+		timeLeft   float64
+		home, away int
+		want       []finalScore
+	}{
+		{"final", "Final", 0, 3, 2, []finalScore{{3, 2, 1}}},
+		{"overtime decided", "Overtime", 2, 3, 2, []finalScore{{3, 2, 1}}},
+		{"level in overtime credits the winner a goal", "Overtime", overtimeFor("NHL").Minutes, 2, 2,
+			[]finalScore{{3, 2, otHome}, {2, 3, 1 - otHome}}},
+		{"level in a shootout credits the winner a goal", "Shootout", 0, 1, 1,
+			[]finalScore{{2, 1, soHome}, {1, 2, 1 - soHome}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hs := hockeyState.New("1", "NHL", "Boston", "Toronto")
+			hs.Period, hs.TimeLeft = tt.period, tt.timeLeft
+			hs.HomeScore, hs.AwayScore = tt.home, tt.away
+			hs.HomeStrength = strength
+
+			got := finalScores(hs)
+			if len(got) != len(tt.want) {
+				t.Fatalf("finalScores = %v, want %v", got, tt.want)
+			}
+			for i, fs := range got {
+				w := tt.want[i]
+				if fs.home != w.home || fs.away != w.away || math.Abs(fs.p-w.p) > 1e-9 {
+					t.Errorf("score %d = %v, want %v", i, fs, w)
+				}
+			}
+		})
+	}
+}
+
+func TestFinalScoresRegulationHasNoTies(t *testing.T) {
+	hs := hockeyState.New("1", "NHL", "Boston", "Toronto")
+	hs.Period, hs.TimeLeft = "3rd Period", 10
+	hs.HomeScore, hs.AwayScore = 2, 2
+	hs.HomeStrength = 0.55
+
+	var total float64
+	for _, fs := range finalScores(hs) {
+		if fs.home == fs.away {
+			t.Fatalf("tied final score %d-%d", fs.home, fs.away)
+		}
+		if fs.home < 2 || fs.away < 2 {
+			t.Fatalf("final score %d-%d below the live score", fs.home, fs.away)
+		}
+		total += fs.p
+	}
+	if math.Abs(total-1) > 1e-3 {
+		t.Errorf("probabilities sum to %v, want 1", total)
+	}
+}
@@ -0,0 +1,52 @@
+package hockey
+
+import (
+	"sync/atomic"
+
+	"github.com/charleschow/hft-trading/internal/core/calibration"
+)
+
+// DecayParams are the ProjectedOddsV2 constants; see projected_odds.go.
+type DecayParams struct {
+	K, A, Theta, Eta, Lambda float64
+}
+
+// DefaultDecay is the compiled-in V2 fit.
+var DefaultDecay = DecayParams{K: kCoeff, A: aCoeff, Theta: theta, Eta: eta, Lambda: lambda_}
+
+// ModelParams are fitted overrides of the hockey models, produced by
+// cmd/calibrate.
+type ModelParams struct {
+	Decay DecayParams
+
+	// ShareScale stretches (>1) or shrinks (<1) the Poisson scoring share
+	// away from 50/50, correcting pregame lines that are under- or
+	// over-confident.
+	ShareScale float64
+
+	// Recal is applied to the live regulation price in computeModel.
+	Recal calibration.Table
+}
+
+var defaultParams = ModelParams{Decay: DefaultDecay, ShareScale: 1}
+
+var modelParams atomic.Pointer[ModelParams]
+
+// SetModelParams installs fitted parameters. A zero Decay or ShareScale
+// keeps the default.
+func SetModelParams(p ModelParams) {
+	if p.Decay == (DecayParams{}) {
+		p.Decay = DefaultDecay
+	}
+	if p.ShareScale <= 0 {
+		p.ShareScale = 1
+	}
+	modelParams.Store(&p)
+}
+
+func currentParams() *ModelParams {
+	if p := modelParams.Load(); p != nil {
+		return p
+	}
+	return &defaultParams
+}
@@ -5,6 +5,7 @@ import "math"
 // ProjectedOddsV2 uses the same core formula as V1 (exponential decay on
 // the time exponent) but adds hard floors for large leads: a team up by 3+
 // goals is given at least 92% win probability, and 4+ goals at least 99%.
+// Its constants can be refit with SetModelParams.
 func ProjectedOddsV2(teamStrength, timeRemain, currentLead float64) float64 {
 	strength := math.Max(0.001, math.Min(0.999, teamStrength))
 
@@ -19,9 +20,10 @@ func ProjectedOddsV2(teamStrength, timeRemain, currentLead float64) float64 {
 		}
 	}
 
+	d := currentParams().Decay
 	logOdds := math.Log(strength / (1 - strength))
-	timeFactor := math.Pow(timeRemain/60.0, eta*math.Exp(-lambda_*math.Abs(currentLead)))
-	leadTerm := kCoeff * currentLead * (1 + aCoeff*(60.0/(timeRemain+theta)-1))
+	timeFactor := math.Pow(timeRemain/60.0, d.Eta*math.Exp(-d.Lambda*math.Abs(currentLead)))
+	leadTerm := d.K * currentLead * (1 + d.A*(60.0/(timeRemain+d.Theta)-1))
 
 	exponent := logOdds*timeFactor + leadTerm
 	p := 1.0 / (1.0 + math.Exp(-exponent))
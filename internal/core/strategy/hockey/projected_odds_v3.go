@@ -1,18 +1,79 @@
 package hockey
 
-import "math"
+import (
+	"math"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
 
 // Poisson model: instead of a logistic curve with arbitrary constants, model
 // hockey as two independent Poisson scoring processes. Given the current score
 // and time remaining, enumerate all possible future scorelines and sum
-// probabilities exactly. The only parameter is the NHL average total scoring
-// rate (~6 goals per 60 min regulation), not a fitted constant.
+// probabilities exactly. The only parameter is the league's average total
+// scoring rate (~6 goals per 60 min regulation in the NHL), not a fitted
+// constant.
 
 const (
 	poissonTotalRate = 6.0 / 60.0
 	poissonMaxGoals  = 15
 )
 
+// rateTable is the per-league total scoring rate, in goals per minute.
+type rateTable struct {
+	leagues map[string]float64
+	def     float64
+}
+
+var leagueRates atomic.Pointer[rateTable]
+
+// SetLeagueRates installs the league scoring-rate table, in regulation goals
+// per 60 minutes keyed by lowercase league name. Leagues missing from it use
+// defaultPer60, or the NHL rate when that is not positive.
+func SetLeagueRates(leagues map[string]float64, defaultPer60 float64) {
+	t := &rateTable{leagues: make(map[string]float64, len(leagues)), def: poissonTotalRate}
+	if defaultPer60 > 0 {
+		t.def = defaultPer60 / 60.0
+	}
+	for league, r := range leagues {
+		if r > 0 {
+			t.leagues[strings.ToLower(strings.TrimSpace(league))] = r / 60.0
+		}
+	}
+	leagueRates.Store(t)
+}
+
+// goalRate returns the league's total scoring rate in goals per minute.
+func goalRate(league string) float64 {
+	t := leagueRates.Load()
+	if t == nil {
+		return poissonTotalRate
+	}
+	if r, ok := t.leagues[strings.ToLower(strings.TrimSpace(league))]; ok {
+		return r
+	}
+	return t.def
+}
+
+// Power-play scoring multipliers on a team's rate while the manpower is
+// uneven: roughly 20% conversion on a 5-on-4 minor, 60% on a full 5-on-3,
+// with the shorthanded side still scoring occasionally.
+const (
+	ppBoost5v4 = 2.3
+	ppShort5v4 = 0.3
+	ppBoost5v3 = 6.0
+	ppShort5v3 = 0.1
+)
+
+// PowerPlay is the penalty time a team has left to skate with the man
+// advantage, in minutes, split by manpower.
+type PowerPlay struct {
+	FiveOnFour  float64
+	FiveOnThree float64
+}
+
+func (pp PowerPlay) active() bool { return pp.FiveOnFour > 0 || pp.FiveOnThree > 0 }
+
 var logFact [poissonMaxGoals + 1]float64
 
 func init() {
@@ -50,15 +111,33 @@ func poissonWinProb(muTeam, muOpp float64, lead int) float64 {
 	return pWin + 0.5*pTie
 }
 
+type shareKey struct{ pregamePct, rate float64 }
+
+// shareCache memoizes findScoringShare by pregame probability and league
+// rate, which are fixed per game while the model is re-run on every update.
+var shareCache sync.Map
+
 // findScoringShare binary-searches for the fraction of total scoring rate
 // attributable to a team such that the full-game (60 min, 0-0) Poisson win
-// probability matches the pregame odds exactly.
-func findScoringShare(pregamePct float64) float64 {
+// probability matches the pregame odds exactly, then tilts it by the
+// fitted ShareScale.
+func findScoringShare(pregamePct, rate float64) float64 {
+	key := shareKey{pregamePct, rate}
+	share, ok := shareCache.Load(key)
+	if !ok {
+		share = searchScoringShare(pregamePct, rate)
+		shareCache.Store(key, share)
+	}
+	tilted := 0.5 + (share.(float64)-0.5)*currentParams().ShareScale
+	return math.Max(0.01, math.Min(0.99, tilted))
+}
+
+func searchScoringShare(pregamePct, rate float64) float64 {
 	lo, hi := 0.01, 0.99
 	for i := 0; i < 50; i++ {
 		mid := (lo + hi) / 2
-		mu1 := poissonTotalRate * mid * 60.0
-		mu2 := poissonTotalRate * (1 - mid) * 60.0
+		mu1 := rate * mid * 60.0
+		mu2 := rate * (1 - mid) * 60.0
 		if poissonWinProb(mu1, mu2, 0) < pregamePct {
 			lo = mid
 		} else {
@@ -73,8 +152,15 @@ func findScoringShare(pregamePct float64) float64 {
 // prediction at 0-0 matches the pregame odds. Given the live score and time
 // remaining, the model enumerates all possible future scorelines to compute
 // the exact win probability. No fitted constants — just the Poisson distribution
-// and the NHL average scoring rate.
+// and the default scoring rate.
 func ProjectedOddsV3(teamStrength, timeRemain, currentLead float64) float64 {
+	return ProjectedOddsV3PP("", teamStrength, timeRemain, currentLead, PowerPlay{}, PowerPlay{})
+}
+
+// ProjectedOddsV3PP is ProjectedOddsV3 at the league's scoring rate, with
+// the remaining power-play time of each side: those minutes are played at
+// the boosted (or shorthanded) rates instead of even strength.
+func ProjectedOddsV3PP(league string, teamStrength, timeRemain, currentLead float64, teamPP, oppPP PowerPlay) float64 {
 	strength := math.Max(0.001, math.Min(0.999, teamStrength))
 
 	if timeRemain <= 0 {
@@ -88,10 +174,31 @@ func ProjectedOddsV3(teamStrength, timeRemain, currentLead float64) float64 {
 		}
 	}
 
-	share := findScoringShare(strength)
-	muTeam := poissonTotalRate * share * timeRemain
-	muOpp := poissonTotalRate * (1 - share) * timeRemain
+	muTeam, muOpp := expectedGoals(league, strength, timeRemain, teamPP, oppPP)
 	lead := int(math.Round(currentLead))
 
 	return poissonWinProb(muTeam, muOpp, lead)
 }
+
+// expectedGoals returns each side's expected goals over timeRemain minutes
+// at the league's scoring rate, with the remaining power-play minutes
+// played at the boosted (or shorthanded) rates.
+func expectedGoals(league string, strength, timeRemain float64, teamPP, oppPP PowerPlay) (muTeam, muOpp float64) {
+	left := timeRemain
+	take := func(m float64) float64 {
+		m = math.Max(0, math.Min(m, left))
+		left -= m
+		return m
+	}
+	t53, o53 := take(teamPP.FiveOnThree), take(oppPP.FiveOnThree)
+	t54, o54 := take(teamPP.FiveOnFour), take(oppPP.FiveOnFour)
+	even := left
+
+	rate := goalRate(league)
+	share := findScoringShare(strength, rate)
+	muTeam = rate * share *
+		(even + ppBoost5v4*t54 + ppBoost5v3*t53 + ppShort5v4*o54 + ppShort5v3*o53)
+	muOpp = rate * (1 - share) *
+		(even + ppBoost5v4*o54 + ppBoost5v3*o53 + ppShort5v4*t54 + ppShort5v3*t53)
+	return muTeam, muOpp
+}
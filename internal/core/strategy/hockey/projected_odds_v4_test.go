@@ -0,0 +1,83 @@
+package hockey
+
+import (
+	"math"
+	"testing"
+)
+
+func TestProjectedOddsV4RegulationOver(t *testing.T) {
+	level := OvertimeWinProb("NHL", 0.6, overtimeFor("NHL").Minutes)
+	tests := []struct {
+		name string
+		lead float64
+		want float64
+	}{
+		{"leading", 1, 1},
+		{"trailing", -2, 0},
+		{"level goes to overtime", 0, level},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ProjectedOddsV4("NHL", 0.6, 0, tt.lead, PowerPlay{}, PowerPlay{}); got != tt.want {
+				t.Errorf("ProjectedOddsV4 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectedOddsV4(t *testing.T) {
+	none := PowerPlay{}
+	odds := func(strength, minutes, lead float64, team, opp PowerPlay) float64 {
+		return ProjectedOddsV4("NHL", strength, minutes, lead, team, opp)
+	}
+
+	tests := []struct {
+		name   string
+		higher float64
+		lower  float64
+	}{
+		{"a lead helps", odds(0.5, 30, 1, none, none), odds(0.5, 30, 0, none, none)},
+		{"a bigger lead helps more", odds(0.5, 30, 2, none, none), odds(0.5, 30, 1, none, none)},
+		{"a lead is safer late", odds(0.5, 5, 1, none, none), odds(0.5, 30, 1, none, none)},
+		{"the stronger side is favored", odds(0.65, 60, 0, none, none), odds(0.5, 60, 0, none, none)},
+		{"a power play helps", odds(0.5, 30, 0, PowerPlay{FiveOnFour: 2}, none), odds(0.5, 30, 0, none, none)},
+		{"a 5-on-3 beats a 5-on-4", odds(0.5, 30, 0, PowerPlay{FiveOnThree: 2}, none), odds(0.5, 30, 0, PowerPlay{FiveOnFour: 2}, none)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.higher <= tt.lower {
+				t.Errorf("got %v, want more than %v", tt.higher, tt.lower)
+			}
+		})
+	}
+}
+
+func TestProjectedOddsV4Symmetric(t *testing.T) {
+	tests := []struct {
+		name     string
+		strength float64
+		minutes  float64
+		lead     float64
+		team     PowerPlay
+		opp      PowerPlay
+	}{
+		{"level at puck drop", 0.5, 60, 0, PowerPlay{}, PowerPlay{}},
+		{"favorite trailing", 0.62, 40, -1, PowerPlay{}, PowerPlay{}},
+		{"one-goal lead with the net empty", 0.55, 1.5, 1, PowerPlay{}, PowerPlay{}},
+		{"two-goal lead late", 0.45, 2.5, 2, PowerPlay{}, PowerPlay{}},
+		{"on a power play", 0.5, 10, 0, PowerPlay{FiveOnFour: 1.5}, PowerPlay{}},
+		{"lead beyond the grid", 0.5, 20, 12, PowerPlay{}, PowerPlay{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := ProjectedOddsV4("NHL", tt.strength, tt.minutes, tt.lead, tt.team, tt.opp)
+			q := ProjectedOddsV4("NHL", 1-tt.strength, tt.minutes, -tt.lead, tt.opp, tt.team)
+			if p < 0 || p > 1 {
+				t.Fatalf("ProjectedOddsV4 = %v, outside [0, 1]", p)
+			}
+			if math.Abs(p+q-1) > 1e-3 {
+				t.Errorf("team %v + opponent %v = %v, want 1", p, q, p+q)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package strategy
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// MarketLeadRecorder persists market-leads-feed flags.
+type MarketLeadRecorder interface {
+	// RecordMarketLead stores a flag without blocking. Runs on the game's
+	// goroutine. The returned func notes the feed reporting a score change
+	// while the flag was held, lag after the market moved.
+	RecordMarketLead(gc *game.GameContext, ml game.MarketLead) (catchUp func(lag time.Duration))
+}
+
+// SetMarketLeadRecorder sets where market-leads-feed flags are recorded.
+func (e *Engine) SetMarketLeadRecorder(r MarketLeadRecorder) {
+	e.leadRecorder = r
+}
+
+// midTick is one observation of a ticker's yes mid.
+type midTick struct {
+	at  time.Time
+	mid float64
+}
+
+// leadState is one game's market-leads-feed detector. Only touched from
+// the game's goroutine.
+type leadState struct {
+	ticks           map[string][]midTick
+	lastScoreChange time.Time
+	flag            *game.MarketLead
+	catchUp         func(lag time.Duration) // nil when not recorded
+	flaggedUntil    time.Time
+	caughtUp        bool
+}
+
+// leadGames maps each game to its detector, like shadowGames.
+type leadGames struct {
+	mu    sync.Mutex
+	games map[*game.GameContext]*leadState
+}
+
+func (t *leadGames) get(gc *game.GameContext) *leadState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.games == nil {
+		t.games = make(map[*game.GameContext]*leadState)
+	}
+	ls, ok := t.games[gc]
+	if !ok {
+		ls = &leadState{ticks: make(map[string][]midTick)}
+		t.games[gc] = ls
+	}
+	return ls
+}
+
+// existing returns gc's detector without creating one.
+func (t *leadGames) existing(gc *game.GameContext) *leadState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.games[gc]
+}
+
+// remove drops gc's detector.
+func (t *leadGames) remove(gc *game.GameContext) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.games, gc)
+}
+
+// isMoneyline reports whether ticker is one of gc's moneyline markets.
+// Totals and spreads move on their own and are not watched.
+func isMoneyline(gc *game.GameContext, ticker string) bool {
+	home, away, draw := gc.Game.GetTickers()
+	return ticker != "" && (ticker == home || ticker == away || ticker == draw)
+}
+
+// detectMarketLead looks for a jump on td's moneyline ticker within the
+// league's window that no score change from the feed explains, and flags
+// the game when it finds one. Runs on the game's goroutine after td is
+// updated; finished games are not watched.
+func (e *Engine) detectMarketLead(gc *game.GameContext, td *game.TickerData) {
+	p := gc.Params()
+	if !p.MarketLeadEnabled() || td.YesBid <= 0 || td.YesAsk >= 100 || td.YesBid > td.YesAsk {
+		return
+	}
+	if !isMoneyline(gc, td.Ticker) || e.display.Get(gc.EID).Finaled {
+		return
+	}
+	now := td.UpdatedAt
+	window := time.Duration(p.MarketLeadWindowSec) * time.Second
+	mid := (td.YesBid + td.YesAsk) / 2
+
+	ls := e.leads.get(gc)
+	ticks := ls.ticks[td.Ticker]
+	kept := ticks[:0]
+	for _, t := range ticks {
+		if now.Sub(t.at) <= window {
+			kept = append(kept, t)
+		}
+	}
+	ls.ticks[td.Ticker] = append(kept, midTick{at: now, mid: mid})
+
+	if now.Sub(ls.lastScoreChange) <= window {
+		return
+	}
+	from := mid
+	for _, t := range kept {
+		if math.Abs(mid-t.mid) > math.Abs(mid-from) {
+			from = t.mid
+		}
+	}
+	if math.Abs(mid-from) < p.MarketLeadCents {
+		return
+	}
+
+	hold := time.Duration(p.MarketLeadHoldSec) * time.Second
+	if now.Before(ls.flaggedUntil) {
+		ls.flaggedUntil = now.Add(hold)
+		gc.HeldUntil = ls.flaggedUntil
+		return
+	}
+
+	ml := game.MarketLead{At: now, Ticker: td.Ticker, FromCents: from, ToCents: mid, Window: window}
+	ls.flag = &ml
+	ls.flaggedUntil = now.Add(hold)
+	gc.HeldUntil = ls.flaggedUntil
+	ls.caughtUp = false
+	ls.catchUp = nil
+	if e.leadRecorder != nil {
+		ls.catchUp = e.leadRecorder.RecordMarketLead(gc, ml)
+	}
+	telemetry.Warnf("[MARKET-LEAD] %s vs %s: %s moved %.1f¢ → %.1f¢ in %v with no score change — new orders held %v",
+		gc.Game.GetHomeTeam(), gc.Game.GetAwayTeam(), td.Ticker, from, mid, window, hold)
+}
+
+// noteScoreChange records a score change from the feed: jumps after it are
+// explained, and a held flag learns how far the feed lagged the market.
+func (e *Engine) noteScoreChange(gc *game.GameContext, now time.Time) {
+	if e.display.Get(gc.EID).Finaled {
+		return
+	}
+	ls := e.leads.get(gc)
+	ls.lastScoreChange = now
+	if ls.flag == nil || ls.caughtUp || !now.Before(ls.flaggedUntil) {
+		return
+	}
+	ls.caughtUp = true
+	lag := now.Sub(ls.flag.At)
+	if ls.catchUp != nil {
+		ls.catchUp(lag)
+	}
+	telemetry.Infof("[MARKET-LEAD] %s vs %s: feed reported %d-%d %.1fs after the market moved",
+		gc.Game.GetHomeTeam(), gc.Game.GetAwayTeam(), gc.Game.GetHomeScore(), gc.Game.GetAwayScore(), lag.Seconds())
+}
+
+// holdForMarketLead drops new orders while gc is flagged. Slams trade a
+// settled result and requotes only move resting orders, so both pass.
+func (e *Engine) holdForMarketLead(gc *game.GameContext, intents []events.OrderIntent) []events.OrderIntent {
+	if len(intents) == 0 {
+		return intents
+	}
+	ls := e.leads.existing(gc)
+	if ls == nil || ls.flag == nil || !time.Now().Before(ls.flaggedUntil) {
+		return intents
+	}
+	kept := intents[:0]
+	for _, intent := range intents {
+		if intent.Slam || intent.Requote {
+			kept = append(kept, intent)
+		}
+	}
+	if held := len(intents) - len(kept); held > 0 {
+		telemetry.Infof("[MARKET-LEAD] %s vs %s: held %d order(s), market led the feed on %s",
+			gc.Game.GetHomeTeam(), gc.Game.GetAwayTeam(), held, ls.flag.Ticker)
+	}
+	return kept
+}
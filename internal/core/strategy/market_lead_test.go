@@ -0,0 +1,84 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/display"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+type leadRecorder struct{ flags []game.MarketLead }
+
+func (r *leadRecorder) RecordMarketLead(_ *game.GameContext, ml game.MarketLead) func(time.Duration) {
+	r.flags = append(r.flags, ml)
+	return func(time.Duration) {}
+}
+
+func TestDetectMarketLead(t *testing.T) {
+	const (
+		home   = "KXNHLGAME-26OCT16TORBOS-BOS"
+		away   = "KXNHLGAME-26OCT16TORBOS-TOR"
+		totals = "KXNHLTOTAL-26OCT16TORBOS-5"
+	)
+	tests := []struct {
+		name    string
+		ticker  string
+		finish  bool
+		flagged bool
+	}{
+		{"home moneyline", home, false, true},
+		{"away moneyline", away, false, true},
+		{"totals line", totals, false, false},
+		{"finished game", home, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hs := hockeyState.New("1", "NHL", "Boston", "Toronto")
+			hs.SetTickers(home, away, "")
+			gc := game.NewGameContext(events.SportHockey, "NHL", "1", hs)
+			defer gc.Close()
+			rec := &leadRecorder{}
+			e := &Engine{display: display.NewTracker(), leadRecorder: rec}
+			if tt.finish {
+				e.display.Get(gc.EID).Finaled = true
+				e.finishGame(gc)
+			}
+
+			now := time.Now()
+			e.detectMarketLead(gc, &game.TickerData{Ticker: tt.ticker, YesBid: 40, YesAsk: 42, UpdatedAt: now})
+			e.detectMarketLead(gc, &game.TickerData{Ticker: tt.ticker, YesBid: 55, YesAsk: 57, UpdatedAt: now.Add(time.Second)})
+
+			if got := len(rec.flags) == 1; got != tt.flagged {
+				t.Errorf("flagged = %v, want %v", got, tt.flagged)
+			}
+			if got := !gc.HeldUntil.IsZero(); got != tt.flagged {
+				t.Errorf("held = %v, want %v", got, tt.flagged)
+			}
+			if tt.finish && e.leads.existing(gc) != nil {
+				t.Error("finished game is still tracked")
+			}
+		})
+	}
+}
+
+func TestFinishGameDropsLeads(t *testing.T) {
+	hs := hockeyState.New("1", "NHL", "Boston", "Toronto")
+	gc := game.NewGameContext(events.SportHockey, "NHL", "1", hs)
+	defer gc.Close()
+	e := &Engine{display: display.NewTracker()}
+	e.leads.get(gc).ticks["X"] = []midTick{{at: time.Now(), mid: 50}}
+
+	e.finishGame(gc)
+	if e.leads.existing(gc) != nil {
+		t.Error("detector kept after finish")
+	}
+	if intents := e.holdForMarketLead(gc, []events.OrderIntent{{Ticker: "X"}}); len(intents) != 1 {
+		t.Errorf("holdForMarketLead kept %d intents, want 1", len(intents))
+	}
+	if e.leads.existing(gc) != nil {
+		t.Error("holdForMarketLead recreated the detector")
+	}
+}
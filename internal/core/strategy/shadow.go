@@ -0,0 +1,163 @@
+package strategy
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/shadow"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+// LiveStrategyID tags the live strategy's decisions in the decision log.
+const LiveStrategyID = "live"
+
+// shadowGames maps each game to its shadow contexts, one per shadow
+// strategy. Like display.Tracker, the map is mutex-protected but each
+// game's inner map is only touched from that game's goroutine.
+type shadowGames struct {
+	mu    sync.Mutex
+	games map[*game.GameContext]map[string]*game.GameContext
+}
+
+func (t *shadowGames) get(gc *game.GameContext) map[string]*game.GameContext {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.games == nil {
+		t.games = make(map[*game.GameContext]map[string]*game.GameContext)
+	}
+	m, ok := t.games[gc]
+	if !ok {
+		m = make(map[string]*game.GameContext)
+		t.games[gc] = m
+	}
+	return m
+}
+
+// existing returns gc's shadow contexts without creating any.
+func (t *shadowGames) existing(gc *game.GameContext) map[string]*game.GameContext {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.games[gc]
+}
+
+// remove drops gc's shadow contexts.
+func (t *shadowGames) remove(gc *game.GameContext) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.games, gc)
+}
+
+// SetDecisionLog turns on the decision log: the live strategy's new orders
+// and every shadow strategy's intents are recorded with the fill the book
+// would have given them.
+func (e *Engine) SetDecisionLog(s *shadow.Store) {
+	e.decisions = s
+}
+
+// shadowContexts returns gc's shadow contexts, cloning the live state for
+// any shadow strategy that has not seen the game yet. Call it before the
+// live strategy handles an event so clones start from the same state.
+// Finished games get none.
+func (e *Engine) shadowContexts(gc *game.GameContext) map[string]*game.GameContext {
+	shadows := e.registry.Shadows(gc.Sport)
+	if len(shadows) == 0 || e.display.Get(gc.EID).Finaled {
+		return nil
+	}
+	m := e.shadows.get(gc)
+	for _, sh := range shadows {
+		if _, ok := m[sh.ID]; !ok {
+			m[sh.ID] = gc.Shadow()
+		}
+	}
+	return m
+}
+
+// runShadows runs fn for every shadow strategy on its own context and logs
+// the resulting intents, held like the live ones while the market leads
+// the feed. Nothing is published.
+func (e *Engine) runShadows(gc *game.GameContext, contexts map[string]*game.GameContext, fn func(Strategy, *game.GameContext) []events.OrderIntent) {
+	for _, sh := range e.registry.Shadows(gc.Sport) {
+		sc, ok := contexts[sh.ID]
+		if !ok {
+			continue
+		}
+		gc.SyncShadow(sc)
+		intents := e.holdForMarketLead(gc, applyParams(sc, fn(sh.Strategy, sc)))
+		for i := range intents {
+			intents[i].StrategyID = sh.ID
+		}
+		e.recordDecisions(sc, sh.ID, intents)
+	}
+}
+
+// recordDecisions writes intents to the decision log with hypothetical
+// fills. Requotes only move resting orders and are not logged.
+func (e *Engine) recordDecisions(gc *game.GameContext, id string, intents []events.OrderIntent) {
+	if e.decisions == nil || len(intents) == 0 {
+		return
+	}
+	now := time.Now()
+	ds := make([]shadow.Decision, 0, len(intents))
+	for _, intent := range intents {
+		if intent.Requote {
+			continue
+		}
+		d := shadow.Decision{
+			Ts:         now,
+			StrategyID: id,
+			Sport:      string(gc.Sport),
+			League:     gc.League,
+			GameID:     gc.EID,
+			HomeTeam:   gc.Game.GetHomeTeam(),
+			AwayTeam:   gc.Game.GetAwayTeam(),
+			Period:     gc.Game.GetPeriod(),
+			TimeRemain: gc.Game.GetTimeRemaining(),
+			HomeScore:  intent.HomeScore,
+			AwayScore:  intent.AwayScore,
+			Ticker:     intent.Ticker,
+			Side:       intent.Side,
+			Outcome:    intent.Outcome,
+			LimitCents: min(int(math.Floor(intent.LimitPct)), 99),
+			ModelPct:   intent.ModelPct,
+			Reason:     intent.Reason,
+			Overturn:   intent.Overturn,
+			Slam:       intent.Slam,
+		}
+		hypotheticalFill(gc, &d)
+		ds = append(ds, d)
+	}
+	e.decisions.Record(ds)
+}
+
+// hypotheticalFill fills d at the best ask at or under its limit. The
+// depth book is used when valid; otherwise the top-of-book ask from the
+// ticker feed, which says nothing about size.
+func hypotheticalFill(gc *game.GameContext, d *shadow.Decision) {
+	if d.LimitCents < 1 {
+		return
+	}
+	if book := gc.Books[d.Ticker]; book != nil && book.Valid {
+		d.FillSource = "book"
+		if ask := book.BestAsk(d.Side); ask > 0 && ask <= d.LimitCents {
+			d.Filled = true
+			d.FillCents = ask
+			d.Depth = book.AvailableAtOrBelow(d.Side, d.LimitCents)
+		}
+		return
+	}
+
+	ask := gc.YesAsk(d.Ticker)
+	if d.Side == "no" {
+		ask = gc.NoAsk(d.Ticker)
+	}
+	if ask < 0 {
+		return
+	}
+	d.FillSource = "top"
+	if ask > 0 && ask < 100 && int(math.Ceil(ask)) <= d.LimitCents {
+		d.Filled = true
+		d.FillCents = int(math.Ceil(ask))
+	}
+}
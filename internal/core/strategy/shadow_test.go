@@ -0,0 +1,25 @@
+package strategy
+
+import (
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+)
+
+func TestShadowGamesRemove(t *testing.T) {
+	var sg shadowGames
+	gc, other := &game.GameContext{}, &game.GameContext{}
+	sg.get(gc)["v2"] = &game.GameContext{}
+	sg.get(other)
+
+	sg.remove(gc)
+	if m := sg.existing(gc); m != nil {
+		t.Errorf("existing after remove = %v, want nil", m)
+	}
+	if m := sg.existing(other); m == nil {
+		t.Error("remove dropped another game's shadows")
+	}
+	if n := len(sg.games); n != 1 {
+		t.Errorf("%d games tracked, want 1", n)
+	}
+}
@@ -0,0 +1,47 @@
+package soccer
+
+import (
+	"math"
+
+	soccerState "github.com/charleschow/hft-trading/internal/core/state/game/soccer"
+)
+
+// priceLines sets each of the match's lines' model price (0–100). Lines
+// settle on the regulation score, so they are priced from the same
+// card-adjusted Poisson rates as the 1X2 model over the regulation time
+// left, and are decided once regulation ends. The draw boost only
+// reweights level results against each other side's wins and is left out.
+func priceLines(ss *soccerState.SoccerState) {
+	if len(ss.Lines) == 0 {
+		return
+	}
+	home, away := ss.RegulationScore()
+
+	var ph, pa []float64
+	if ss.IsRegulationOver() {
+		ph, pa = poissonPMF(0), poissonPMF(0)
+	} else {
+		r := calibrate(ss.HomeStrength, ss.DrawPct, ss.AwayStrength, ss.G0)
+		frac := math.Max(0, math.Min(regulationMin, ss.TimeLeft)) / regulationMin
+		muH, muA := cardAdjusted(r.home*frac, r.away*frac, ss.HomeRedCards, ss.AwayRedCards)
+		ph, pa = poissonPMF(muH), poissonPMF(muA)
+	}
+
+	for i := range ss.Lines {
+		l := &ss.Lines[i]
+		var yes, total float64
+		for x, p := range ph {
+			for y, q := range pa {
+				total += p * q
+				if l.Settles(home+x, away+y) {
+					yes += p * q
+				}
+			}
+		}
+		if total > 0 {
+			yes /= total
+		}
+		l.ModelYes = yes * 100
+		l.Priced = true
+	}
+}
@@ -0,0 +1,37 @@
+package soccer
+
+import (
+	"sync/atomic"
+
+	"github.com/charleschow/hft-trading/internal/core/calibration"
+)
+
+// ModelParams are fitted recalibrations of the 1X2 model, produced by
+// cmd/calibrate: one for the home and away prices, one for the draw.
+type ModelParams struct {
+	WinRecal  calibration.Table
+	DrawRecal calibration.Table
+}
+
+var modelParams atomic.Pointer[ModelParams]
+
+// SetModelParams installs fitted parameters.
+func SetModelParams(p ModelParams) {
+	modelParams.Store(&p)
+}
+
+// recalibrated applies the league's recalibration to each outcome and
+// renormalizes the three to sum to one.
+func recalibrated(league string, home, draw, away float64) (float64, float64, float64) {
+	p := modelParams.Load()
+	if p == nil {
+		return home, draw, away
+	}
+	win, dr := p.WinRecal.For(league), p.DrawRecal.For(league)
+	h, d, a := win.Apply(home), dr.Apply(draw), win.Apply(away)
+	total := h + d + a
+	if total <= 0 {
+		return home, draw, away
+	}
+	return h / total, d / total, a / total
+}
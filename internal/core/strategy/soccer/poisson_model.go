@@ -0,0 +1,212 @@
+package soccer
+
+import (
+	"math"
+
+	soccerState "github.com/charleschow/hft-trading/internal/core/state/game/soccer"
+)
+
+// In-play 1X2 model.
+//
+// Each side's goals over the time left are independent Poisson draws. The
+// full-match rates are calibrated once from the pregame line: the total is
+// G0 and the home share is chosen so that the home/away win split matches
+// HomeStrength/AwayStrength. Plain independent Poisson under-prices draws,
+// so tied final scores are scaled up by the factor that reproduces DrawPct
+// pregame, fading linearly to 1 as the clock runs out.
+//
+// Rates are scaled by the share of the period left and by red cards: a
+// side down a man scores less and concedes more.
+
+const (
+	regulationMin = 90.0
+	extraTimeMin  = 30.0
+
+	maxRemainingGoals = 10
+
+	redCardOwnFactor = 0.67 // scoring rate of the side that lost a player
+	redCardOppFactor = 1.25 // scoring rate of its opponent
+)
+
+// rates holds full-regulation expected goals per side and the draw
+// inflation applied to tied outcomes at kickoff.
+type rates struct {
+	home, away float64
+	drawBoost  float64
+}
+
+// calibrate fits rates to the pregame 1X2 probabilities and G0.
+func calibrate(homeP, drawP, awayP, g0 float64) rates {
+	if g0 <= 0 {
+		g0 = 2.5
+	}
+	if homeP+awayP <= 0 {
+		homeP, awayP = 0.5, 0.5
+	}
+	target := homeP / (homeP + awayP)
+
+	lo, hi := 0.01, 0.99
+	for range 50 {
+		mid := (lo + hi) / 2
+		h, _, a := outcomeProbs(g0*mid, g0*(1-mid), 0, 1)
+		if h/(h+a) < target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	share := (lo + hi) / 2
+	r := rates{home: g0 * share, away: g0 * (1 - share), drawBoost: 1}
+
+	if drawP > 0 {
+		_, d, _ := outcomeProbs(r.home, r.away, 0, 1)
+		if d > 0 && d < 1 {
+			// Solve boost·d / (boost·d + 1 − d) = drawP.
+			r.drawBoost = drawP * (1 - d) / (d * (1 - drawP))
+		}
+	}
+	return r
+}
+
+// outcomeProbs returns P(home win), P(draw), P(away win) when the home
+// side leads by diff and each scores Poisson(muH) / Poisson(muA) more
+// goals. Tied final scores are weighted by drawBoost.
+func outcomeProbs(muH, muA float64, diff int, drawBoost float64) (home, draw, away float64) {
+	ph := poissonPMF(muH)
+	pa := poissonPMF(muA)
+	for i, p := range ph {
+		for j, q := range pa {
+			pq := p * q
+			switch final := diff + i - j; {
+			case final > 0:
+				home += pq
+			case final < 0:
+				away += pq
+			default:
+				draw += pq * drawBoost
+			}
+		}
+	}
+	total := home + draw + away
+	if total <= 0 {
+		return 0, 1, 0
+	}
+	return home / total, draw / total, away / total
+}
+
+// poissonPMF returns P(X = k) for k = 0..maxRemainingGoals.
+func poissonPMF(mu float64) []float64 {
+	out := make([]float64, maxRemainingGoals+1)
+	if mu <= 0 {
+		out[0] = 1
+		return out
+	}
+	p := math.Exp(-mu)
+	for k := range out {
+		if k > 0 {
+			p *= mu / float64(k)
+		}
+		out[k] = p
+	}
+	return out
+}
+
+// cardAdjusted scales both rates for the red-card difference.
+func cardAdjusted(home, away float64, homeRC, awayRC int) (float64, float64) {
+	for range homeRC {
+		home *= redCardOwnFactor
+		away *= redCardOppFactor
+	}
+	for range awayRC {
+		away *= redCardOwnFactor
+		home *= redCardOppFactor
+	}
+	return home, away
+}
+
+// computeModel sets the state's six model prices (0–100) from ModelProbs,
+// recalibrated for the league, and prices the lines. Decided outcomes (0
+// or 1) and an even penalty shootout are unchanged by the recalibration.
+func computeModel(ss *soccerState.SoccerState) {
+	priceLines(ss)
+	home, draw, away := ModelProbs(ss)
+	home, draw, away = recalibrated(ss.League, home, draw, away)
+
+	ss.ModelHomeYes = home * 100
+	ss.ModelDrawYes = draw * 100
+	ss.ModelAwayYes = away * 100
+	ss.ModelHomeNo = 100 - ss.ModelHomeYes
+	ss.ModelDrawNo = 100 - ss.ModelDrawYes
+	ss.ModelAwayNo = 100 - ss.ModelAwayYes
+}
+
+// ModelProbs returns the model's home, draw and away probabilities for the
+// state, before recalibration.
+func ModelProbs(ss *soccerState.SoccerState) (home, draw, away float64) {
+	r := calibrate(ss.HomeStrength, ss.DrawPct, ss.AwayStrength, ss.G0)
+
+	switch {
+	case ss.IsFinished():
+		if !ss.ExtraTimeSettlesML {
+			home, draw, away = decided(ss.RegulationGoalDiff())
+			break
+		}
+		// Level after extra time means penalties; the score cannot say who won.
+		home, draw, away = decided(ss.GoalDiff())
+		if draw == 1 {
+			home, draw, away = 0.5, 0, 0.5
+		}
+
+	case ss.IsRegulationOver() && !ss.ExtraTimeSettlesML:
+		// Extra time or penalties do not count: regulation decided it.
+		home, draw, away = decided(ss.RegulationGoalDiff())
+
+	case ss.IsPenalties():
+		home, draw, away = decided(ss.GoalDiff())
+		if draw == 1 {
+			home, draw, away = 0.5, 0, 0.5
+		}
+
+	case ss.IsExtraTime():
+		left := math.Max(0, math.Min(extraTimeMin, ss.ExtraTimeLeft))
+		home, away = extraTime(r, ss, left, ss.GoalDiff())
+
+	default:
+		left := math.Max(0, math.Min(regulationMin, ss.TimeLeft))
+		frac := left / regulationMin
+		muH, muA := cardAdjusted(r.home*frac, r.away*frac, ss.HomeRedCards, ss.AwayRedCards)
+		boost := 1 + (r.drawBoost-1)*frac
+		home, draw, away = outcomeProbs(muH, muA, ss.GoalDiff(), boost)
+
+		if ss.ExtraTimeSettlesML {
+			// A level score goes to extra time and then penalties.
+			etHome, etAway := extraTime(r, ss, extraTimeMin, 0)
+			home += draw * etHome
+			away += draw * etAway
+			draw = 0
+		}
+	}
+	return home, draw, away
+}
+
+// extraTime returns P(home), P(away) from a level-or-diff position with
+// left minutes of extra time to play, with a tie after it split evenly
+// by penalties.
+func extraTime(r rates, ss *soccerState.SoccerState, left float64, diff int) (home, away float64) {
+	frac := left / regulationMin
+	muH, muA := cardAdjusted(r.home*frac, r.away*frac, ss.HomeRedCards, ss.AwayRedCards)
+	h, d, a := outcomeProbs(muH, muA, diff, 1)
+	return h + d/2, a + d/2
+}
+
+// decided returns the certain outcome for a final goal difference.
+func decided(diff int) (home, draw, away float64) {
+	switch {
+	case diff > 0:
+		return 1, 0, 0
+	case diff < 0:
+		return 0, 0, 1
+	default:
+		return 0, 1, 0
+	}
+}
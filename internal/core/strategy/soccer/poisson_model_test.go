@@ -0,0 +1,157 @@
+package soccer
+
+import (
+	"math"
+	"testing"
+
+	soccerState "github.com/charleschow/hft-trading/internal/core/state/game/soccer"
+)
+
+func TestCalibrate(t *testing.T) {
+	tests := []struct {
+		name               string
+		home, draw, away   float64
+		g0                 float64
+		wantG0             float64
+		wantHome, wantAway float64 // 1X2 at kickoff
+		wantDraw           float64
+	}{
+		{"home favourite", 0.50, 0.27, 0.23, 2.7, 2.7, 0.50, 0.23, 0.27},
+		{"even", 0.36, 0.28, 0.36, 2.4, 2.4, 0.36, 0.36, 0.28},
+		{"away favourite", 0.20, 0.25, 0.55, 3.0, 3.0, 0.20, 0.55, 0.25},
+		{"no total defaults to 2.5", 0.45, 0.28, 0.27, 0, 2.5, 0.45, 0.27, 0.28},
+		// Without a draw price the split is kept and no boost applied.
+		{"no draw price", 0.6, 0, 0.4, 2.5, 2.5, -1, -1, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := calibrate(tt.home, tt.draw, tt.away, tt.g0)
+			if math.Abs(r.home+r.away-tt.wantG0) > 1e-9 {
+				t.Errorf("rates %.3f + %.3f, want total %.2f", r.home, r.away, tt.wantG0)
+			}
+			h, d, a := outcomeProbs(r.home, r.away, 0, r.drawBoost)
+			if tt.wantDraw < 0 {
+				if r.drawBoost != 1 || math.Abs(h/(h+a)-tt.home/(tt.home+tt.away)) > 1e-6 {
+					t.Errorf("boost %.3f, home share %.4f", r.drawBoost, h/(h+a))
+				}
+				return
+			}
+			if !near(h, tt.wantHome) || !near(d, tt.wantDraw) || !near(a, tt.wantAway) {
+				t.Errorf("kickoff 1X2 %.4f/%.4f/%.4f, want %.2f/%.2f/%.2f", h, d, a, tt.wantHome, tt.wantDraw, tt.wantAway)
+			}
+		})
+	}
+}
+
+func TestModelProbs(t *testing.T) {
+	type update struct {
+		home, away int
+		half       string
+		left       float64
+	}
+	tests := []struct {
+		name      string
+		updates   []update
+		etLeft    float64
+		etSettles bool
+		homeRC    int
+		want      []float64 // home, draw, away; nil checks only the shape
+		check     func(home, draw, away float64) bool
+	}{
+		{
+			name:    "kickoff reproduces the pregame line",
+			updates: []update{{0, 0, "1st Half", 90}},
+			want:    []float64{0.45, 0.28, 0.27},
+		},
+		{
+			name:    "level with no time left is a draw",
+			updates: []update{{1, 1, "2nd Half", 0}},
+			want:    []float64{0, 1, 0},
+		},
+		{
+			name:    "late lead",
+			updates: []update{{1, 0, "2nd Half", 5}},
+			check:   func(h, d, a float64) bool { return h > 0.9 && d > a },
+		},
+		{
+			name:    "home red card favours the away side",
+			updates: []update{{0, 0, "1st Half", 60}},
+			homeRC:  1,
+			check:   func(h, d, a float64) bool { return a > h },
+		},
+		{
+			name:    "finished",
+			updates: []update{{2, 1, "2nd Half", 2}, {2, 1, "Finished", 0}},
+			want:    []float64{1, 0, 0},
+		},
+		{
+			name:    "extra time does not settle a regulation draw",
+			updates: []update{{1, 1, "2nd Half", 1}, {2, 1, "Extra Time", 0}},
+			etLeft:  20,
+			want:    []float64{0, 1, 0},
+		},
+		{
+			name:    "finished after extra time on a regulation market",
+			updates: []update{{1, 1, "2nd Half", 1}, {2, 1, "Extra Time", 0}, {2, 1, "AET", 0}},
+			want:    []float64{0, 1, 0},
+		},
+		{
+			name:      "finished after extra time on an advancing market",
+			updates:   []update{{1, 1, "2nd Half", 1}, {2, 1, "Extra Time", 0}, {2, 1, "AET", 0}},
+			etSettles: true,
+			want:      []float64{1, 0, 0},
+		},
+		{
+			name:      "level after extra time goes to penalties",
+			updates:   []update{{1, 1, "2nd Half", 1}, {1, 1, "Penalties", 0}},
+			etSettles: true,
+			want:      []float64{0.5, 0, 0.5},
+		},
+		{
+			name:      "lead with extra time run out",
+			updates:   []update{{1, 1, "2nd Half", 1}, {2, 1, "Extra Time", 0}},
+			etSettles: true,
+			want:      []float64{1, 0, 0},
+		},
+		{
+			name:      "level in extra time follows the clock",
+			updates:   []update{{1, 1, "2nd Half", 1}, {1, 1, "Extra Time", 0}},
+			etLeft:    25,
+			etSettles: true,
+			check:     func(h, d, a float64) bool { return d == 0 && h > a && h < 0.6 },
+		},
+		{
+			name:      "regulation on an advancing market has no draw",
+			updates:   []update{{0, 0, "2nd Half", 30}},
+			etSettles: true,
+			check:     func(h, d, a float64) bool { return d == 0 && h > a },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ss := soccerState.New("1", "EPL", "Arsenal", "Chelsea")
+			ss.HomeStrength, ss.DrawPct, ss.AwayStrength, ss.G0 = 0.45, 0.28, 0.27, 2.6
+			ss.ExtraTimeSettlesML = tt.etSettles
+			for _, u := range tt.updates {
+				ss.UpdateGameState(u.home, u.away, u.half, u.left)
+			}
+			ss.UpdateExtraTime(tt.etLeft)
+			ss.UpdateRedCards(tt.homeRC, 0)
+
+			h, d, a := ModelProbs(ss)
+			if !near(h+d+a, 1) {
+				t.Fatalf("probs %.4f/%.4f/%.4f sum to %.4f", h, d, a, h+d+a)
+			}
+			if tt.want != nil && (!near(h, tt.want[0]) || !near(d, tt.want[1]) || !near(a, tt.want[2])) {
+				t.Errorf("probs %.4f/%.4f/%.4f, want %v", h, d, a, tt.want)
+			}
+			if tt.check != nil && !tt.check(h, d, a) {
+				t.Errorf("probs %.4f/%.4f/%.4f", h, d, a)
+			}
+		})
+	}
+}
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) < 1e-4
+}
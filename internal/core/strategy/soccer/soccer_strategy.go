@@ -1,6 +1,7 @@
 package soccer
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/charleschow/hft-trading/internal/core/display"
@@ -30,8 +31,9 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 
 	tracked := len(gc.Tickers) > 0
 
+	overturn := false
 	if ss.HasLIVEData() {
-		result := ss.CheckScoreDrop(gu.HomeScore, gu.AwayScore, 15)
+		result := ss.CheckScoreDrop(gu.HomeScore, gu.AwayScore, gc.Params().ConfirmSec)
 		switch result {
 		case "new_drop":
 			if tracked {
@@ -69,6 +71,7 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 				telemetry.Infof("[OVERTURN-CONFIRMED] %s vs %s (%d-%d -> %d-%d)",
 					ss.HomeTeam, ss.AwayTeam, ss.GetHomeScore(), ss.GetAwayScore(), gu.HomeScore, gu.AwayScore)
 			}
+			overturn = true
 			gc.LastOverturn = &game.OverturnInfo{
 				OldHome: ss.GetHomeScore(), OldAway: ss.GetAwayScore(),
 				NewHome: gu.HomeScore, NewAway: gu.AwayScore,
@@ -77,7 +80,9 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 		}
 	}
 
+	hadLIVEData := ss.HasLIVEData()
 	changed := ss.UpdateGameState(gu.HomeScore, gu.AwayScore, gu.Period, gu.TimeLeft)
+	ss.UpdateExtraTime(gu.ExtraTimeLeft)
 	prevHomeRC, prevAwayRC := ss.HomeRedCards, ss.AwayRedCards
 	if gu.HomeRedCards > 0 || gu.AwayRedCards > 0 {
 		ss.UpdateRedCards(gu.HomeRedCards, gu.AwayRedCards)
@@ -87,17 +92,120 @@ func (s *Strategy) Evaluate(gc *game.GameContext, gu *events.GameUpdateEvent) st
 		gc.Notify(string(events.StatusRedCard))
 	}
 
-	if !changed {
+	computeModel(ss)
+	ss.RecalcEdge(gc.Tickers)
+
+	if !changed && !overturn {
 		return strategy.EvalResult{}
 	}
 
+	scoreChanged := hadLIVEData && changed
+
 	telemetry.Metrics.ScoreChanges.Inc()
 
+	if (scoreChanged || overturn) && live(ss) && ss.HasSignificantEdge(gc.Params().EdgeThresholdPct) {
+		return strategy.EvalResult{
+			Intents: s.buildOrderIntents(gc, ss, overturn),
+		}
+	}
+
 	return strategy.EvalResult{}
 }
 
+// OnPriceUpdate requotes the game's resting orders at the current model
+// price, as hockey does.
 func (s *Strategy) OnPriceUpdate(gc *game.GameContext) []events.OrderIntent {
-	return nil
+	ss, ok := gc.Game.(*soccerState.SoccerState)
+	if !ok || gc.Orders.OpenCount() == 0 {
+		return nil
+	}
+	if !live(ss) || ss.IsScoreDropPending() {
+		return nil
+	}
+
+	intents := s.buildOrderIntents(gc, ss, false)
+	for i := range intents {
+		intents[i].Requote = true
+	}
+	return intents
+}
+
+// live reports whether the 1X2 markets can still move: the match is not
+// over and, unless extra time settles them, regulation is still running.
+func live(ss *soccerState.SoccerState) bool {
+	if ss.IsFinished() {
+		return false
+	}
+	return ss.ExtraTimeSettlesML || !ss.IsRegulationOver()
+}
+
+// buildOrderIntents fires a YES and a NO order on each of the three 1X2
+// markets, and on each totals and spread market while regulation runs,
+// when a score change (or confirmed overturn) occurs and at least one
+// significant edge exists.
+func (s *Strategy) buildOrderIntents(gc *game.GameContext, ss *soccerState.SoccerState, overturn bool) []events.OrderIntent {
+	var intents []events.OrderIntent
+	t := gc.Params().EdgeThresholdPct
+
+	for _, m := range []struct {
+		ticker, outcome string
+		yes, no         float64
+	}{
+		{ss.HomeTicker, "home", ss.ModelHomeYes, ss.ModelHomeNo},
+		{ss.DrawTicker, "draw", ss.ModelDrawYes, ss.ModelDrawNo},
+		{ss.AwayTicker, "away", ss.ModelAwayYes, ss.ModelAwayNo},
+	} {
+		if m.ticker == "" {
+			continue
+		}
+		intents = append(intents,
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: m.ticker, Side: "yes", Outcome: m.outcome,
+				LimitPct:  m.yes - t,
+				ModelPct:  m.yes,
+				Reason:    fmt.Sprintf("model %.1f%% YES", m.yes),
+				HomeScore: ss.HomeScore, AwayScore: ss.AwayScore, Overturn: overturn,
+			},
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: m.ticker, Side: "no", Outcome: m.outcome,
+				LimitPct:  m.no - t,
+				ModelPct:  m.no,
+				Reason:    fmt.Sprintf("model %.1f%% NO", m.no),
+				HomeScore: ss.HomeScore, AwayScore: ss.AwayScore, Overturn: overturn,
+			},
+		)
+	}
+
+	if ss.IsRegulationOver() {
+		return intents
+	}
+	for _, l := range ss.Lines {
+		if !l.Priced {
+			continue
+		}
+		intents = append(intents,
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: l.Ticker, Side: "yes", Outcome: l.Outcome(),
+				LimitPct:  l.ModelYes - t,
+				ModelPct:  l.ModelYes,
+				Reason:    fmt.Sprintf("model %.1f%% YES %s", l.ModelYes, l.Outcome()),
+				HomeScore: ss.HomeScore, AwayScore: ss.AwayScore, Overturn: overturn,
+			},
+			events.OrderIntent{
+				Sport: gc.Sport, League: gc.League, GameID: gc.EID, EID: gc.EID,
+				Ticker: l.Ticker, Side: "no", Outcome: l.Outcome(),
+				LimitPct:  (100 - l.ModelYes) - t,
+				ModelPct:  100 - l.ModelYes,
+				Reason:    fmt.Sprintf("model %.1f%% NO %s", 100-l.ModelYes, l.Outcome()),
+				HomeScore: ss.HomeScore, AwayScore: ss.AwayScore, Overturn: overturn,
+			},
+		)
+	}
+
+	return intents
 }
 
 func (s *Strategy) OnFinish(gc *game.GameContext, gu *events.GameUpdateEvent) []events.OrderIntent {
@@ -106,9 +214,90 @@ func (s *Strategy) OnFinish(gc *game.GameContext, gu *events.GameUpdateEvent) []
 		return nil
 	}
 
-	_ = ss
-	// TODO: emit slam orders for settled 1X2 markets
-	return nil
+	computeModel(ss)
+	return s.slamOrders(gc, ss, gu)
+}
+
+// slamOrders buys the settled side of each 1X2 market at 99¢: YES on the
+// result that happened and NO on the other two. Markets settle on the
+// regulation score unless ExtraTimeSettlesML, in which case extra time
+// counts and a level score means penalties decided it — the feed does not
+// say who won those, so only the draw NO is certain. Lines always settle
+// on the regulation score.
+func (s *Strategy) slamOrders(gc *game.GameContext, ss *soccerState.SoccerState, gu *events.GameUpdateEvent) []events.OrderIntent {
+	diff := ss.RegulationGoalDiff()
+	reason := fmt.Sprintf("match finished %d-%d", gu.HomeScore, gu.AwayScore)
+	if ss.ExtraTimeSettlesML {
+		diff = ss.GoalDiff()
+	} else if diff != ss.GoalDiff() {
+		reason += fmt.Sprintf(" (regulation goal diff %+d)", diff)
+	}
+
+	winner := "draw"
+	switch {
+	case diff > 0:
+		winner = "home"
+	case diff < 0:
+		winner = "away"
+	}
+	penalties := ss.ExtraTimeSettlesML && diff == 0
+
+	var intents []events.OrderIntent
+	for _, m := range []struct{ ticker, outcome string }{
+		{ss.HomeTicker, "home"},
+		{ss.DrawTicker, "draw"},
+		{ss.AwayTicker, "away"},
+	} {
+		if m.ticker == "" {
+			continue
+		}
+		side := "no"
+		switch {
+		case penalties:
+			if m.outcome != "draw" {
+				continue
+			}
+		case m.outcome == winner:
+			side = "yes"
+		}
+		intents = append(intents, events.OrderIntent{
+			Sport:     gu.Sport,
+			League:    gu.League,
+			GameID:    gu.EID,
+			EID:       gu.EID,
+			Ticker:    m.ticker,
+			Side:      side,
+			Outcome:   m.outcome,
+			LimitPct:  99,
+			Reason:    reason,
+			HomeScore: gu.HomeScore,
+			AwayScore: gu.AwayScore,
+			Slam:      true,
+		})
+	}
+
+	regHome, regAway := ss.RegulationScore()
+	for _, l := range ss.Lines {
+		side := "no"
+		if l.Settles(regHome, regAway) {
+			side = "yes"
+		}
+		intents = append(intents, events.OrderIntent{
+			Sport:     gu.Sport,
+			League:    gu.League,
+			GameID:    gu.EID,
+			EID:       gu.EID,
+			Ticker:    l.Ticker,
+			Side:      side,
+			Outcome:   l.Outcome(),
+			LimitPct:  99,
+			Reason:    reason,
+			HomeScore: gu.HomeScore,
+			AwayScore: gu.AwayScore,
+			Slam:      true,
+		})
+	}
+	return intents
 }
 
 func (s *Strategy) DisplayGame(gc *game.GameContext, eventType string) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/charleschow/hft-trading/internal/core/display"
 	"github.com/charleschow/hft-trading/internal/core/odds"
+	"github.com/charleschow/hft-trading/internal/core/shadow"
 	"github.com/charleschow/hft-trading/internal/core/state/game"
 	"github.com/charleschow/hft-trading/internal/core/state/store"
 	"github.com/charleschow/hft-trading/internal/core/ticker"
@@ -47,6 +48,15 @@ type Engine struct {
 	subscriber TickerSubscriber
 	observers  []game.GameObserver
 
+	// Shadow strategies' per-game contexts, and the log of live and
+	// shadow decisions (nil when off).
+	shadows   shadowGames
+	decisions *shadow.Store
+
+	// Market-leads-feed detection per game, and where flags are recorded.
+	leads        leadGames
+	leadRecorder MarketLeadRecorder
+
 	kalshiWSUp atomic.Bool
 }
 
@@ -64,6 +74,7 @@ func NewEngine(bus *events.Bus, gameStore *store.GameStateStore, registry *Regis
 
 	bus.Subscribe(events.EventGameUpdate, e.onGameUpdate)
 	bus.Subscribe(events.EventMarketData, e.onMarketData)
+	bus.Subscribe(events.EventOrderBook, e.onOrderBook)
 	bus.Subscribe(events.EventWSStatus, e.onWSStatus)
 
 	return e
@@ -119,8 +130,9 @@ func (e *Engine) onGameUpdate(evt events.Event) error {
 			if ds.Finaled {
 				return
 			}
+			shadows := e.shadowContexts(gc)
 			ds.Finaled = true
-			defer gc.SetMatchStatus(events.StatusGameFinish)
+			defer e.finishGame(gc)
 			telemetry.Metrics.ActiveGames.Dec()
 
 			gc.Game.UpdateGameState(gu.HomeScore, gu.AwayScore, gu.Period, gu.TimeLeft)
@@ -131,8 +143,16 @@ func (e *Engine) onGameUpdate(evt events.Event) error {
 				return
 			}
 
-			intents := strat.OnFinish(gc, &gu)
+			intents := applyParams(gc, strat.OnFinish(gc, &gu))
 			e.publishIntents(intents, gu.Sport, gu.League, gu.EID, evt.Timestamp)
+			e.recordDecisions(gc, LiveStrategyID, intents)
+
+			e.runShadows(gc, shadows, func(s Strategy, sc *game.GameContext) []events.OrderIntent {
+				g := gu
+				sc.Game.UpdateGameState(g.HomeScore, g.AwayScore, g.Period, g.TimeLeft)
+				sc.Game.RecalcEdge(sc.Tickers)
+				return s.OnFinish(sc, &g)
+			})
 			return
 		}
 
@@ -146,16 +166,28 @@ func (e *Engine) onGameUpdate(evt events.Event) error {
 		prevHome := gc.Game.GetHomeScore()
 		prevAway := gc.Game.GetAwayScore()
 
+		shadows := e.shadowContexts(gc)
 		result := strat.Evaluate(gc, &gu)
 
-		e.publishIntents(result.Intents, gu.Sport, gu.League, gu.EID, evt.Timestamp)
+		intents := e.holdForMarketLead(gc, applyParams(gc, result.Intents))
+		e.publishIntents(intents, gu.Sport, gu.League, gu.EID, evt.Timestamp)
+		e.recordDecisions(gc, LiveStrategyID, intents)
+
+		if hadLIVEData && (gc.Game.GetHomeScore() != prevHome || gc.Game.GetAwayScore() != prevAway) {
+			e.noteScoreChange(gc, time.Now())
+		}
+
+		e.runShadows(gc, shadows, func(s Strategy, sc *game.GameContext) []events.OrderIntent {
+			g := gu
+			return s.Evaluate(sc, &g).Intents
+		})
 
 		if result.Finished {
 			ds := e.display.Get(gc.EID)
 			if !ds.Finaled {
 				ds.Finaled = true
 				telemetry.Metrics.ActiveGames.Dec()
-				gc.SetMatchStatus(events.StatusGameFinish)
+				e.finishGame(gc)
 			}
 			return
 		}
@@ -309,6 +341,7 @@ func (e *Engine) onMarketData(evt events.Event) error {
 			if me.Volume > 0 {
 				td.Volume = me.Volume
 			}
+			td.UpdatedAt = time.Now()
 
 			if !gc.Game.HasPregame() {
 				return
@@ -322,21 +355,51 @@ func (e *Engine) onMarketData(evt events.Event) error {
 				return
 			}
 
+			e.detectMarketLead(gc, td)
+
 			strat, ok := e.registry.Get(gc.Sport)
 			if !ok {
 				return
 			}
-			intents := strat.OnPriceUpdate(gc)
+			intents := e.holdForMarketLead(gc, applyParams(gc, strat.OnPriceUpdate(gc)))
 			if len(intents) > 0 {
 				e.publishIntents(intents, gc.Sport, gc.League, gc.EID, evt.Timestamp)
 			}
 
+			// Shadows only price games the live strategy has evaluated.
+			e.runShadows(gc, e.shadows.existing(gc), func(s Strategy, sc *game.GameContext) []events.OrderIntent {
+				sc.Game.RecalcEdge(sc.Tickers)
+				return s.OnPriceUpdate(sc)
+			})
+
 			gc.Notify("PRICE_UPDATE")
 		})
 	}
 	return nil
 }
 
+// onOrderBook folds Kalshi orderbook_snapshot / orderbook_delta messages
+// into the game's depth book. Depth is read on demand by strategies and
+// execution; it does not trigger evaluation on its own.
+func (e *Engine) onOrderBook(evt events.Event) error {
+	ob, ok := evt.Payload.(events.OrderBookEvent)
+	if !ok {
+		return nil
+	}
+
+	for _, gc := range e.store.ByTicker(ob.Ticker) {
+		gc.Send(func() {
+			book := gc.Books[ob.Ticker]
+			if book == nil {
+				book = game.NewOrderBook(ob.Ticker)
+				gc.Books[ob.Ticker] = book
+			}
+			book.Apply(ob)
+		})
+	}
+	return nil
+}
+
 func (e *Engine) onWSStatus(evt events.Event) error {
 	ws, ok := evt.Payload.(events.WSStatusEvent)
 	if !ok {
@@ -362,6 +425,9 @@ func (e *Engine) onWSStatus(evt events.Event) error {
 					td.NoAsk = 100
 					td.NoBid = 100
 				}
+				for _, b := range gc.Books {
+					b.Valid = false
+				}
 			}
 		})
 	}
@@ -463,6 +529,7 @@ func (e *Engine) initializeGame(ctx context.Context, sport events.Sport, p odds.
 	gc.Send(func() {
 		gc.KalshiConnected = e.kalshiWSUp.Load()
 		gc.Game.SetTickers(resolved.HomeTicker, resolved.AwayTicker, resolved.DrawTicker)
+		setLines(gc.Game, resolved.Lines)
 		gc.KalshiEventURL = ticker.KalshiEventURL(resolved.EventTicker)
 
 		for _, t := range allTickers {
@@ -500,6 +567,23 @@ func (e *Engine) applyPregameToState(gc *game.GameContext, sport events.Sport, p
 	})
 }
 
+// setLines attaches the resolved totals and spread markets to states
+// that price them.
+func setLines(gs game.GameState, resolved []ticker.LineMarket) {
+	type lineSetter interface {
+		SetLines(lines []game.LineMarket)
+	}
+	ls, ok := gs.(lineSetter)
+	if !ok || len(resolved) == 0 {
+		return
+	}
+	lines := make([]game.LineMarket, len(resolved))
+	for i, l := range resolved {
+		lines[i] = game.LineMarket{Ticker: l.Ticker, Kind: l.Kind, Line: l.Line, Team: l.Team}
+	}
+	ls.SetLines(lines)
+}
+
 // startPeriodicRefresh re-fetches Kalshi markets and GoalServe pregame odds
 // every refreshInterval, creating GameContexts for any new matches.
 func (e *Engine) startPeriodicRefresh(ctx context.Context, sport events.Sport, provider PregameProvider) {
@@ -541,6 +625,37 @@ func (e *Engine) startPeriodicRefresh(ctx context.Context, sport events.Sport, p
 	}
 }
 
+// applyParams enforces the league's strategy parameters on a strategy's
+// intents: disabled sides are dropped, new orders stop once less than
+// min_time_remaining is left, limits are capped at max_price_cents and
+// the order TTL is set. Slams are exempt from the clock and the price cap;
+// requotes, which never add exposure, from the side and clock checks.
+func applyParams(gc *game.GameContext, intents []events.OrderIntent) []events.OrderIntent {
+	if len(intents) == 0 {
+		return intents
+	}
+	p := gc.Params()
+	late := p.MinTimeRemaining > 0 && gc.Game.GetTimeRemaining() < p.MinTimeRemaining
+
+	kept := intents[:0]
+	for _, intent := range intents {
+		if !intent.Requote && !p.SideEnabled(intent.Side) {
+			continue
+		}
+		if late && !intent.Slam && !intent.Requote {
+			continue
+		}
+		if !intent.Slam {
+			if p.MaxPriceCents > 0 && intent.LimitPct > float64(p.MaxPriceCents) {
+				intent.LimitPct = float64(p.MaxPriceCents)
+			}
+			intent.TTLSeconds = p.OrderTTLSeconds
+		}
+		kept = append(kept, intent)
+	}
+	return kept
+}
+
 func (e *Engine) publishIntents(intents []events.OrderIntent, sport events.Sport, league, gameID string, ts time.Time) {
 	if len(intents) == 0 {
 		return
@@ -556,3 +671,11 @@ func (e *Engine) publishIntents(intents []events.OrderIntent, sport events.Sport
 		Payload:   intents,
 	})
 }
+
+// finishGame marks gc finished and drops the engine's per-game state.
+// Runs on the game's goroutine once the finish has been evaluated.
+func (e *Engine) finishGame(gc *game.GameContext) {
+	gc.SetMatchStatus(events.StatusGameFinish)
+	e.shadows.remove(gc)
+	e.leads.remove(gc)
+}
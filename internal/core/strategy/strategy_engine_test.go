@@ -0,0 +1,67 @@
+package strategy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestApplyParams(t *testing.T) {
+	// Only the test league is configured, so every other league in the
+	// package's tests still resolves to the defaults.
+	const doc = `
+sports:
+  hockey:
+    leagues:
+      PARAMS:
+        min_time_remaining: 5
+        max_price_cents: 80
+        order_ttl_seconds: 30
+        sides: [yes]
+`
+	path := filepath.Join(t.TempDir(), "strategy_params.yaml")
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.LoadStrategyParams(path); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		timeLeft float64
+		intent   events.OrderIntent
+		kept     bool
+		limit    float64
+		ttl      int
+	}{
+		{"order capped and given the TTL", 20, events.OrderIntent{Side: "yes", LimitPct: 85}, true, 80, 30},
+		{"order under the cap", 20, events.OrderIntent{Side: "yes", LimitPct: 60}, true, 60, 30},
+		{"disabled side", 20, events.OrderIntent{Side: "no", LimitPct: 60}, false, 0, 0},
+		{"requote on a disabled side", 20, events.OrderIntent{Side: "no", LimitPct: 60, Requote: true}, true, 60, 30},
+		{"order too late", 3, events.OrderIntent{Side: "yes", LimitPct: 60}, false, 0, 0},
+		{"requote late", 3, events.OrderIntent{Side: "yes", LimitPct: 60, Requote: true}, true, 60, 30},
+		{"slam late and over the cap", 3, events.OrderIntent{Side: "yes", LimitPct: 99, Slam: true}, true, 99, 0},
+		{"slam on a disabled side", 0, events.OrderIntent{Side: "no", LimitPct: 99, Slam: true}, false, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hs := hockeyState.New("1", "PARAMS", "Boston", "Toronto")
+			hs.TimeLeft = tt.timeLeft
+			gc := game.NewGameContext(events.SportHockey, "PARAMS", "1", hs)
+			defer gc.Close()
+
+			got := applyParams(gc, []events.OrderIntent{tt.intent})
+			if len(got) == 1 != tt.kept {
+				t.Fatalf("kept %d intents, want kept=%v", len(got), tt.kept)
+			}
+			if tt.kept && (got[0].LimitPct != tt.limit || got[0].TTLSeconds != tt.ttl) {
+				t.Errorf("limit %v ttl %d, want %v %d", got[0].LimitPct, got[0].TTLSeconds, tt.limit, tt.ttl)
+			}
+		})
+	}
+}
@@ -31,14 +31,25 @@ type Strategy interface {
 	DisplayGame(gc *game.GameContext, eventType string)
 }
 
-// Registry maps sport -> strategy implementation.
+// Shadow is a strategy evaluated alongside a sport's live strategy on the
+// same events. Its intents are tagged with ID and go to the decision log
+// with hypothetical fills; they are never sent to execution.
+type Shadow struct {
+	ID       string
+	Strategy Strategy
+}
+
+// Registry maps sport -> live strategy implementation, plus any shadow
+// strategies run next to it.
 type Registry struct {
 	strategies map[events.Sport]Strategy
+	shadows    map[events.Sport][]Shadow
 }
 
 func NewRegistry() *Registry {
 	return &Registry{
 		strategies: make(map[events.Sport]Strategy),
+		shadows:    make(map[events.Sport][]Shadow),
 	}
 }
 
@@ -51,6 +62,17 @@ func (r *Registry) Get(sport events.Sport) (Strategy, bool) {
 	return s, ok
 }
 
+// RegisterShadow adds a shadow strategy for sport. IDs must be unique per
+// sport and must not be LiveStrategyID.
+func (r *Registry) RegisterShadow(sport events.Sport, id string, s Strategy) {
+	r.shadows[sport] = append(r.shadows[sport], Shadow{ID: id, Strategy: s})
+}
+
+// Shadows returns sport's shadow strategies in registration order.
+func (r *Registry) Shadows(sport events.Sport) []Shadow {
+	return r.shadows[sport]
+}
+
 // CreateGameState is a factory that produces the correct sport-specific
 // GameState implementation for a new game.
 func (r *Registry) CreateGameState(sport events.Sport, eid, league, home, away string) game.GameState {
@@ -0,0 +1,164 @@
+package ticker
+
+// FootballAliases maps alternate spellings to canonical team names for
+// American football. NFL teams are canonical by full name; Kalshi's city
+// labels ("Buffalo", "New York G") already fuzzy-match those, so only
+// abbreviations and old names are listed. College teams are canonical by
+// school, since GoalServe appends the mascot and Kalshi abbreviates "State".
+var FootballAliases = map[string]string{
+	// NFL
+	"ari":                      "arizona cardinals",
+	"ari cardinals":            "arizona cardinals",
+	"atl":                      "atlanta falcons",
+	"atl falcons":              "atlanta falcons",
+	"bal":                      "baltimore ravens",
+	"bal ravens":               "baltimore ravens",
+	"buf":                      "buffalo bills",
+	"buf bills":                "buffalo bills",
+	"car":                      "carolina panthers",
+	"car panthers":             "carolina panthers",
+	"chi":                      "chicago bears",
+	"chi bears":                "chicago bears",
+	"cin":                      "cincinnati bengals",
+	"cin bengals":              "cincinnati bengals",
+	"cle":                      "cleveland browns",
+	"cle browns":               "cleveland browns",
+	"dal":                      "dallas cowboys",
+	"dal cowboys":              "dallas cowboys",
+	"den":                      "denver broncos",
+	"den broncos":              "denver broncos",
+	"det":                      "detroit lions",
+	"det lions":                "detroit lions",
+	"gb":                       "green bay packers",
+	"gb packers":               "green bay packers",
+	"hou":                      "houston texans",
+	"hou texans":               "houston texans",
+	"ind":                      "indianapolis colts",
+	"ind colts":                "indianapolis colts",
+	"jax":                      "jacksonville jaguars",
+	"jax jaguars":              "jacksonville jaguars",
+	"kc":                       "kansas city chiefs",
+	"kc chiefs":                "kansas city chiefs",
+	"lv":                       "las vegas raiders",
+	"lv raiders":               "las vegas raiders",
+	"lac":                      "los angeles chargers",
+	"la chargers":              "los angeles chargers",
+	"lar":                      "los angeles rams",
+	"la rams":                  "los angeles rams",
+	"mia":                      "miami dolphins",
+	"mia dolphins":             "miami dolphins",
+	"min":                      "minnesota vikings",
+	"min vikings":              "minnesota vikings",
+	"ne":                       "new england patriots",
+	"ne patriots":              "new england patriots",
+	"no":                       "new orleans saints",
+	"no saints":                "new orleans saints",
+	"nyg":                      "new york giants",
+	"ny giants":                "new york giants",
+	"nyj":                      "new york jets",
+	"ny jets":                  "new york jets",
+	"phi":                      "philadelphia eagles",
+	"phi eagles":               "philadelphia eagles",
+	"pit":                      "pittsburgh steelers",
+	"pit steelers":             "pittsburgh steelers",
+	"sf":                       "san francisco 49ers",
+	"sf 49ers":                 "san francisco 49ers",
+	"sea":                      "seattle seahawks",
+	"sea seahawks":             "seattle seahawks",
+	"tb":                       "tampa bay buccaneers",
+	"tb buccaneers":            "tampa bay buccaneers",
+	"ten":                      "tennessee titans",
+	"ten titans":               "tennessee titans",
+	"was":                      "washington commanders",
+	"was commanders":           "washington commanders",
+	"tampa bay bucs":           "tampa bay buccaneers",
+	"washington football team": "washington commanders",
+	"oakland raiders":          "las vegas raiders",
+	"san diego chargers":       "los angeles chargers",
+	"st. louis rams":           "los angeles rams",
+
+	// NCAAF — GoalServe "School Mascot" and Kalshi "St." forms
+	"alabama crimson tide":        "alabama",
+	"auburn tigers":               "auburn",
+	"georgia bulldogs":            "georgia",
+	"lsu tigers":                  "lsu",
+	"louisiana state":             "lsu",
+	"ole miss rebels":             "ole miss",
+	"mississippi":                 "ole miss",
+	"mississippi st.":             "mississippi state",
+	"mississippi state bulldogs":  "mississippi state",
+	"florida gators":              "florida",
+	"florida st.":                 "florida state",
+	"florida state seminoles":     "florida state",
+	"tennessee volunteers":        "tennessee",
+	"texas longhorns":             "texas",
+	"texas a&m aggies":            "texas a&m",
+	"oklahoma sooners":            "oklahoma",
+	"oklahoma st.":                "oklahoma state",
+	"oklahoma state cowboys":      "oklahoma state",
+	"ohio st.":                    "ohio state",
+	"ohio state buckeyes":         "ohio state",
+	"michigan wolverines":         "michigan",
+	"michigan st.":                "michigan state",
+	"michigan state spartans":     "michigan state",
+	"penn st.":                    "penn state",
+	"penn state nittany lions":    "penn state",
+	"oregon ducks":                "oregon",
+	"oregon st.":                  "oregon state",
+	"oregon state beavers":        "oregon state",
+	"usc trojans":                 "usc",
+	"southern california":         "usc",
+	"southern california trojans": "usc",
+	"ucla bruins":                 "ucla",
+	"washington huskies":          "washington",
+	"washington st.":              "washington state",
+	"washington state cougars":    "washington state",
+	"notre dame fighting irish":   "notre dame",
+	"clemson tigers":              "clemson",
+	"miami (fl)":                  "miami",
+	"miami hurricanes":            "miami",
+	"miami (fl) hurricanes":       "miami",
+	"miami (oh)":                  "miami ohio",
+	"miami (oh) redhawks":         "miami ohio",
+	"miami redhawks":              "miami ohio",
+	"iowa hawkeyes":               "iowa",
+	"iowa st.":                    "iowa state",
+	"iowa state cyclones":         "iowa state",
+	"kansas st.":                  "kansas state",
+	"kansas state wildcats":       "kansas state",
+	"arizona st.":                 "arizona state",
+	"arizona state sun devils":    "arizona state",
+	"boise st.":                   "boise state",
+	"boise state broncos":         "boise state",
+	"san diego st.":               "san diego state",
+	"san diego state aztecs":      "san diego state",
+	"fresno st.":                  "fresno state",
+	"fresno state bulldogs":       "fresno state",
+	"nc state":                    "north carolina state",
+	"nc st.":                      "north carolina state",
+	"north carolina st.":          "north carolina state",
+	"nc state wolfpack":           "north carolina state",
+	"byu cougars":                 "byu",
+	"brigham young":               "byu",
+	"tcu horned frogs":            "tcu",
+	"smu mustangs":                "smu",
+	"utah utes":                   "utah",
+	"utah st.":                    "utah state",
+	"colorado buffaloes":          "colorado",
+	"wisconsin badgers":           "wisconsin",
+	"nebraska cornhuskers":        "nebraska",
+	"minnesota golden gophers":    "minnesota",
+	"virginia tech hokies":        "virginia tech",
+	"louisville cardinals":        "louisville",
+	"georgia tech yellow jackets": "georgia tech",
+	"south carolina gamecocks":    "south carolina",
+	"kentucky wildcats":           "kentucky",
+	"missouri tigers":             "missouri",
+	"arkansas razorbacks":         "arkansas",
+	"texas tech red raiders":      "texas tech",
+	"baylor bears":                "baylor",
+	"houston cougars":             "houston",
+	"cincinnati bearcats":         "cincinnati",
+	"ucf knights":                 "ucf",
+	"central florida":             "ucf",
+}
@@ -0,0 +1,128 @@
+package ticker
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// Line market kinds, matching game.LineTotal and game.LineSpread.
+const (
+	LineTotal  = "total"
+	LineSpread = "spread"
+)
+
+// LineMarket is a totals or spread market resolved for a game. YES on a
+// total pays when the final total goes over Line; YES on a spread pays
+// when Team ("home" or "away") wins by more than Line.
+type LineMarket struct {
+	Ticker string
+	Kind   string
+	Line   float64
+	Team   string // spreads only
+}
+
+// lineSports are the sports whose strategies price totals and spreads;
+// the resolver only looks for line markets for these.
+var lineSports = map[events.Sport]bool{
+	events.SportHockey: true,
+	events.SportSoccer: true,
+}
+
+// lineSeriesFor returns the totals and spread series that accompany a
+// game-winner series: KXNHLGAME → KXNHLTOTAL, KXNHLSPREAD. Series not
+// named *GAME have none.
+func lineSeriesFor(gameSeries string) []string {
+	base, ok := strings.CutSuffix(strings.ToUpper(gameSeries), "GAME")
+	if !ok || base == "" {
+		return nil
+	}
+	return []string{base + "TOTAL", base + "SPREAD"}
+}
+
+// attachLines adds the line markets Kalshi lists for rt's event. Line
+// events share the game event's suffix: the totals for
+// KXNHLGAME-25OCT16BOSTOR are under KXNHLTOTAL-25OCT16BOSTOR.
+func (r *Resolver) attachLines(sport events.Sport, rt *ResolvedTickers, homeNorm, awayNorm string, aliases map[string]string) {
+	series, suffix, ok := strings.Cut(strings.ToUpper(rt.EventTicker), "-")
+	if !ok {
+		return
+	}
+	want := make(map[string]bool, 2)
+	for _, ls := range lineSeriesFor(series) {
+		want[ls+"-"+suffix] = true
+	}
+
+	r.mu.RLock()
+	markets := r.lineMarkets[sport]
+	r.mu.RUnlock()
+
+	for _, m := range markets {
+		if !want[strings.ToUpper(m.EventTicker)] {
+			continue
+		}
+		lm, ok := parseLineMarket(m, homeNorm, awayNorm, aliases)
+		if !ok {
+			telemetry.Debugf("ticker: cannot parse line market %s (%q)", m.Ticker, m.YesSubTitle)
+			continue
+		}
+		rt.Lines = append(rt.Lines, lm)
+		rt.Prices[m.Ticker] = TickerSnapshot{YesAsk: m.EffectiveYesAsk(), YesBid: m.EffectiveYesBid(), NoAsk: m.EffectiveNoAsk(), NoBid: m.EffectiveNoBid(), Volume: m.Volume}
+	}
+
+	slices.SortFunc(rt.Lines, func(a, b LineMarket) int {
+		return cmp.Or(cmp.Compare(b.Kind, a.Kind), cmp.Compare(a.Team, b.Team), cmp.Compare(a.Line, b.Line))
+	})
+}
+
+var lineNumber = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// parseLineMarket reads a line market's kind from its series, the line
+// from floor_strike (or the first number in the YES label), and for a
+// spread the team from the label: "Boston wins by over 1.5 goals".
+func parseLineMarket(m kalshi_http.Market, homeNorm, awayNorm string, aliases map[string]string) (LineMarket, bool) {
+	series, _, _ := strings.Cut(strings.ToUpper(m.EventTicker), "-")
+	lm := LineMarket{Ticker: m.Ticker, Line: m.FloorStrike}
+	switch {
+	case strings.HasSuffix(series, "TOTAL"):
+		lm.Kind = LineTotal
+	case strings.HasSuffix(series, "SPREAD"):
+		lm.Kind = LineSpread
+	default:
+		return LineMarket{}, false
+	}
+
+	if lm.Line <= 0 {
+		n := lineNumber.FindString(m.YesSubTitle)
+		v, err := strconv.ParseFloat(n, 64)
+		if err != nil || v <= 0 {
+			return LineMarket{}, false
+		}
+		lm.Line = v
+	}
+
+	if lm.Kind == LineSpread {
+		label := m.YesSubTitle
+		idx := strings.Index(strings.ToLower(label), " wins by")
+		if idx <= 0 {
+			return LineMarket{}, false
+		}
+		name := Normalize(label[:idx], aliases)
+		home, away := teamMatchScore(name, homeNorm), teamMatchScore(name, awayNorm)
+		switch {
+		case home > away:
+			lm.Team = "home"
+		case away > home:
+			lm.Team = "away"
+		default:
+			return LineMarket{}, false
+		}
+	}
+	return lm, true
+}
@@ -0,0 +1,68 @@
+package ticker
+
+import (
+	"testing"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+)
+
+func TestParseLineMarket(t *testing.T) {
+	aliases := map[string]string{"leafs": "toronto maple leafs"}
+	const home, away = "boston bruins", "toronto maple leafs"
+
+	tests := []struct {
+		name   string
+		market kalshi_http.Market
+		want   LineMarket
+		ok     bool
+	}{
+		{
+			name:   "total from floor strike",
+			market: kalshi_http.Market{EventTicker: "KXNHLTOTAL-25OCT16BOSTOR", Ticker: "KXNHLTOTAL-25OCT16BOSTOR-5", FloorStrike: 5.5},
+			want:   LineMarket{Ticker: "KXNHLTOTAL-25OCT16BOSTOR-5", Kind: LineTotal, Line: 5.5},
+			ok:     true,
+		},
+		{
+			name:   "total from the label",
+			market: kalshi_http.Market{EventTicker: "KXNHLTOTAL-25OCT16BOSTOR", Ticker: "KXNHLTOTAL-25OCT16BOSTOR-6", YesSubTitle: "Over 6.5 goals scored"},
+			want:   LineMarket{Ticker: "KXNHLTOTAL-25OCT16BOSTOR-6", Kind: LineTotal, Line: 6.5},
+			ok:     true,
+		},
+		{
+			name:   "home spread",
+			market: kalshi_http.Market{EventTicker: "KXNHLSPREAD-25OCT16BOSTOR", Ticker: "KXNHLSPREAD-25OCT16BOSTOR-BOS1", FloorStrike: 1.5, YesSubTitle: "Boston Bruins wins by over 1.5 goals"},
+			want:   LineMarket{Ticker: "KXNHLSPREAD-25OCT16BOSTOR-BOS1", Kind: LineSpread, Line: 1.5, Team: "home"},
+			ok:     true,
+		},
+		{
+			name:   "away spread through an alias",
+			market: kalshi_http.Market{EventTicker: "KXNHLSPREAD-25OCT16BOSTOR", Ticker: "KXNHLSPREAD-25OCT16BOSTOR-TOR2", YesSubTitle: "Leafs wins by over 2.5 goals"},
+			want:   LineMarket{Ticker: "KXNHLSPREAD-25OCT16BOSTOR-TOR2", Kind: LineSpread, Line: 2.5, Team: "away"},
+			ok:     true,
+		},
+		{
+			name:   "spread for neither team",
+			market: kalshi_http.Market{EventTicker: "KXNHLSPREAD-25OCT16BOSTOR", Ticker: "KXNHLSPREAD-25OCT16BOSTOR-MTL1", FloorStrike: 1.5, YesSubTitle: "Montreal wins by over 1.5 goals"},
+		},
+		{
+			name:   "spread label without a team",
+			market: kalshi_http.Market{EventTicker: "KXNHLSPREAD-25OCT16BOSTOR", Ticker: "KXNHLSPREAD-25OCT16BOSTOR-X", FloorStrike: 1.5, YesSubTitle: "Over 1.5 goals"},
+		},
+		{
+			name:   "no line",
+			market: kalshi_http.Market{EventTicker: "KXNHLTOTAL-25OCT16BOSTOR", Ticker: "KXNHLTOTAL-25OCT16BOSTOR-X", YesSubTitle: "Over"},
+		},
+		{
+			name:   "game-winner series",
+			market: kalshi_http.Market{EventTicker: "KXNHLGAME-25OCT16BOSTOR", Ticker: "KXNHLGAME-25OCT16BOSTOR-BOS", FloorStrike: 0.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseLineMarket(tt.market, home, away, aliases)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("parseLineMarket = %+v, %v; want %+v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
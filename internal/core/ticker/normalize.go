@@ -15,6 +15,8 @@ func AliasesForSport(sport events.Sport) map[string]string {
 		return HockeyAliases
 	case events.SportSoccer:
 		return SoccerAliases
+	case events.SportFootball:
+		return FootballAliases
 	default:
 		return map[string]string{}
 	}
@@ -155,6 +155,9 @@ type ResolvedTickers struct {
 	AwayTicker  string
 	DrawTicker  string // soccer only
 
+	// Lines are the event's totals and spread markets (hockey and soccer).
+	Lines []LineMarket
+
 	Prices map[string]TickerSnapshot
 }
 
@@ -172,9 +175,11 @@ type Resolver struct {
 	client        MarketFetcher
 	mu            sync.RWMutex
 	markets       map[events.Sport][]kalshi_http.Market
+	lineMarkets   map[events.Sport][]kalshi_http.Market
 	lastFetch     map[events.Sport]time.Time
 	aliases       map[events.Sport]map[string]string
 	seriesTickers map[events.Sport][]string
+	lineSeries    map[events.Sport][]string
 	sfGroup       singleflight.Group
 }
 
@@ -184,14 +189,22 @@ func NewResolver(client MarketFetcher, tickersConfigDir string, sports ...events
 	}
 
 	series := make(map[events.Sport][]string, len(sports))
+	lines := make(map[events.Sport][]string, len(sports))
 	aliases := make(map[events.Sport]map[string]string, len(sports))
 	for _, sport := range sports {
 		series[sport] = loadSeriesTickers(tickersConfigDir, sport)
+		if lineSports[sport] {
+			for _, s := range series[sport] {
+				lines[sport] = append(lines[sport], lineSeriesFor(s)...)
+			}
+		}
 		switch sport {
 		case events.SportHockey:
 			aliases[sport] = HockeyAliases
 		case events.SportSoccer:
 			aliases[sport] = SoccerAliases
+		case events.SportFootball:
+			aliases[sport] = FootballAliases
 		default:
 			aliases[sport] = map[string]string{}
 		}
@@ -200,12 +213,27 @@ func NewResolver(client MarketFetcher, tickersConfigDir string, sports ...events
 	return &Resolver{
 		client:        client,
 		markets:       make(map[events.Sport][]kalshi_http.Market),
+		lineMarkets:   make(map[events.Sport][]kalshi_http.Market),
 		lastFetch:     make(map[events.Sport]time.Time),
 		seriesTickers: series,
+		lineSeries:    lines,
 		aliases:       aliases,
 	}
 }
 
+// OwnsTicker reports whether a market ticker belongs to one of the
+// sport's configured series or their totals and spread series.
+func (r *Resolver) OwnsTicker(sport events.Sport, ticker string) bool {
+	for _, series := range [][]string{r.seriesTickers[sport], r.lineSeries[sport]} {
+		for _, s := range series {
+			if strings.HasPrefix(ticker, s+"-") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 const marketCacheTTL = 1 * time.Hour
 
 // Markets whose expiration is more than this far from the game's start time
@@ -213,6 +241,7 @@ const marketCacheTTL = 1 * time.Hour
 // PREGAME_KALSHI_MATCH_WINDOW_SEC (12h hockey, 16h soccer).
 const matchWindowHockey = 12 * time.Hour
 const matchWindowSoccer = 16 * time.Hour
+const matchWindowFootball = 12 * time.Hour
 
 // marketHorizon is the maximum time into the future we keep markets for.
 // Games further out than this are unlikely to have GoalServe pregame data
@@ -247,12 +276,29 @@ func (r *Resolver) RefreshMarkets(ctx context.Context, sport events.Sport) error
 		}
 	}
 
+	// Not every league lists totals or spreads, so a failed fetch here
+	// is not worth a warning.
+	var lines []kalshi_http.Market
+	for _, s := range r.lineSeries[sport] {
+		markets, err := r.client.GetMarkets(ctx, s)
+		if err != nil {
+			telemetry.Debugf("ticker: failed to fetch line series %s: %v", s, err)
+			continue
+		}
+		for _, m := range markets {
+			if expiry := parseMarketExpiry(m); expiry.IsZero() || !expiry.After(cutoff) {
+				lines = append(lines, m)
+			}
+		}
+	}
+
 	r.mu.Lock()
 	r.markets[sport] = all
+	r.lineMarkets[sport] = lines
 	r.lastFetch[sport] = time.Now()
 	r.mu.Unlock()
 
-	telemetry.Infof("ticker: fetched %d markets for %s (%d series, %d skipped >48h)", len(all), sport, len(series), skipped)
+	telemetry.Infof("ticker: fetched %d markets for %s (%d series, %d skipped >48h, %d line markets)", len(all), sport, len(series), skipped, len(lines))
 	return nil
 }
 
@@ -283,12 +329,19 @@ func (r *Resolver) Resolve(ctx context.Context, sport events.Sport, homeTeam, aw
 	markets := r.markets[sport]
 	r.mu.RUnlock()
 
-	window := matchWindowHockey
-	if sport == events.SportSoccer {
-		window = matchWindowSoccer
-		return r.resolveSoccer(markets, homeNorm, awayNorm, aliases, gameStartedAt, window)
+	var result *ResolvedTickers
+	switch sport {
+	case events.SportSoccer:
+		result = r.resolveSoccer(markets, homeNorm, awayNorm, aliases, gameStartedAt, matchWindowSoccer)
+	case events.SportFootball:
+		result = r.resolveFootball(markets, homeNorm, awayNorm, aliases, gameStartedAt, matchWindowFootball)
+	default:
+		result = r.resolveHockey(markets, homeNorm, awayNorm, aliases, gameStartedAt, matchWindowHockey)
+	}
+	if result != nil && lineSports[sport] {
+		r.attachLines(sport, result, homeNorm, awayNorm, aliases)
 	}
-	return r.resolveHockey(markets, homeNorm, awayNorm, aliases, gameStartedAt, window)
+	return result
 }
 
 // matchCandidate pairs a matching market with its temporal distance from the game.
@@ -303,7 +356,7 @@ type hockeyEventCandidate struct {
 	timeDiff    time.Duration
 }
 
-// resolveHockey matches hockey/football markets by grouping all markets
+// resolveHockey matches hockey markets by grouping all markets
 // under the same EventTicker, then picking the event closest in time.
 func (r *Resolver) resolveHockey(markets []kalshi_http.Market, homeNorm, awayNorm string, aliases map[string]string, gameStartedAt time.Time, window time.Duration) *ResolvedTickers {
 	byEvent := make(map[string][]kalshi_http.Market)
@@ -386,6 +439,99 @@ func (r *Resolver) resolveHockey(markets []kalshi_http.Market, homeNorm, awayNor
 	return result
 }
 
+// footballEventCandidate is a parsed football event group that matched the
+// team pair, with its markets already assigned to home and away.
+type footballEventCandidate struct {
+	home, away kalshi_http.Market
+	timeDiff   time.Duration
+}
+
+// resolveFootball matches NFL/NCAAF markets (2 markets per event, one per
+// team). Unlike hockey, each market is assigned by scoring its YES label
+// against both teams: Kalshi labels NFL teams by city, and shared cities
+// ("New York G" / "New York J", "Los Angeles C" / "Los Angeles R") or
+// college names that prefix one another ("Miami" / "Miami (OH)") must not
+// land on the wrong side.
+func (r *Resolver) resolveFootball(markets []kalshi_http.Market, homeNorm, awayNorm string, aliases map[string]string, gameStartedAt time.Time, window time.Duration) *ResolvedTickers {
+	byEvent := make(map[string][]kalshi_http.Market)
+	for _, m := range markets {
+		if m.EventTicker != "" {
+			byEvent[m.EventTicker] = append(byEvent[m.EventTicker], m)
+		}
+	}
+
+	var candidates []footballEventCandidate
+
+	for _, group := range byEvent {
+		if len(group) != 2 {
+			continue
+		}
+		a := normalizeYesSubTitle(group[0].YesSubTitle, aliases)
+		b := normalizeYesSubTitle(group[1].YesSubTitle, aliases)
+
+		straight := min(teamMatchScore(a, homeNorm), teamMatchScore(b, awayNorm))
+		swapped := min(teamMatchScore(a, awayNorm), teamMatchScore(b, homeNorm))
+		if straight == 0 && swapped == 0 {
+			continue
+		}
+
+		c := footballEventCandidate{home: group[0], away: group[1]}
+		if swapped > straight {
+			c.home, c.away = group[1], group[0]
+		}
+
+		var maxExpiry time.Time
+		for _, m := range group {
+			if t := parseMarketExpiry(m); !t.IsZero() && t.After(maxExpiry) {
+				maxExpiry = t
+			}
+		}
+		c.timeDiff = absTimeDiff(gameStartedAt, maxExpiry)
+		candidates = append(candidates, c)
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	best := candidates[0]
+	for _, c := range candidates[1:] {
+		if c.timeDiff < best.timeDiff {
+			best = c
+		}
+	}
+
+	if len(candidates) > 1 && best.timeDiff > window {
+		telemetry.Warnf("ticker: football doubleheader best match for %s vs %s is %v away (window=%v)",
+			homeNorm, awayNorm, best.timeDiff, window)
+	}
+
+	result := &ResolvedTickers{
+		EventTicker: best.home.EventTicker,
+		HomeTicker:  best.home.Ticker,
+		AwayTicker:  best.away.Ticker,
+		Prices:      make(map[string]TickerSnapshot),
+	}
+	for _, m := range []kalshi_http.Market{best.home, best.away} {
+		result.Prices[m.Ticker] = TickerSnapshot{YesAsk: m.EffectiveYesAsk(), YesBid: m.EffectiveYesBid(), NoAsk: m.EffectiveNoAsk(), NoBid: m.EffectiveNoBid(), Volume: m.Volume}
+	}
+	return result
+}
+
+// teamMatchScore rates how well a Kalshi team label matches a normalized
+// team name: 0 no match, 1 fuzzy containment, then higher the more of the
+// longer name the shorter one covers, with an exact match scoring highest.
+func teamMatchScore(label, team string) int {
+	if !FuzzyContains(label, team) {
+		return 0
+	}
+	short, long := len(label), len(team)
+	if short > long {
+		short, long = long, short
+	}
+	return 1 + 100*short/long
+}
+
 // soccerEventCandidate is a parsed soccer event group that matched the team pair.
 type soccerEventCandidate struct {
 	drawTicker  string
@@ -516,7 +662,7 @@ func (r *Resolver) resolveSoccer(markets []kalshi_http.Market, homeNorm, awayNor
 	return result
 }
 
-// AllTickers returns all resolved ticker strings (non-empty).
+// AllTickers returns all resolved ticker strings (non-empty), lines last.
 func (rt *ResolvedTickers) AllTickers() []string {
 	var out []string
 	if rt.HomeTicker != "" {
@@ -528,6 +674,9 @@ func (rt *ResolvedTickers) AllTickers() []string {
 	if rt.DrawTicker != "" {
 		out = append(out, rt.DrawTicker)
 	}
+	for _, l := range rt.Lines {
+		out = append(out, l.Ticker)
+	}
 	return out
 }
 
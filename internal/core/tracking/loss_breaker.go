@@ -0,0 +1,94 @@
+package tracking
+
+import "time"
+
+// lossBreakerSchema holds each loss breaker's trading-day state, so a
+// restart mid-day resumes with the same reset base, peak and trip.
+const lossBreakerSchema = `CREATE TABLE IF NOT EXISTS loss_breakers (
+	sport      TEXT    NOT NULL,
+	scope      TEXT    NOT NULL,
+	day        TEXT    NOT NULL,
+	base_cents INTEGER NOT NULL,
+	peak_cents INTEGER NOT NULL,
+	tripped    INTEGER NOT NULL,
+	reason     TEXT    NOT NULL DEFAULT '',
+	updated_at TEXT    NOT NULL,
+	PRIMARY KEY (sport, scope)
+);`
+
+// BreakerState is one loss breaker's state on a trading day. Scope is a
+// league, or "*" for the sport-wide breaker.
+type BreakerState struct {
+	Scope     string
+	Day       string
+	BaseCents int
+	PeakCents int
+	Tripped   bool
+	Reason    string
+}
+
+// SaveBreakerStates upserts sport's breaker states.
+func (s *Store) SaveBreakerStates(sport string, states []BreakerState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	for _, st := range states {
+		if _, err := tx.Exec(
+			`INSERT INTO loss_breakers (sport, scope, day, base_cents, peak_cents, tripped, reason, updated_at)
+			 VALUES (?,?,?,?,?,?,?,?)
+			 ON CONFLICT(sport, scope) DO UPDATE SET
+				day=excluded.day, base_cents=excluded.base_cents, peak_cents=excluded.peak_cents,
+				tripped=excluded.tripped, reason=excluded.reason, updated_at=excluded.updated_at`,
+			sport, st.Scope, st.Day, st.BaseCents, st.PeakCents, st.Tripped, st.Reason, now,
+		); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// BreakerStates returns sport's breaker states saved for day.
+func (s *Store) BreakerStates(sport, day string) ([]BreakerState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.db.Query(
+		`SELECT scope, day, base_cents, peak_cents, tripped, reason FROM loss_breakers
+		 WHERE sport = ? AND day = ?`, sport, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []BreakerState
+	for rows.Next() {
+		var st BreakerState
+		if err := rows.Scan(&st.Scope, &st.Day, &st.BaseCents, &st.PeakCents, &st.Tripped, &st.Reason); err != nil {
+			return nil, err
+		}
+		out = append(out, st)
+	}
+	return out, rows.Err()
+}
+
+// SaveBreakerStates persists sport's loss breaker states.
+func (t *Tracker) SaveBreakerStates(sport string, states []BreakerState) error {
+	if t == nil || t.store == nil {
+		return nil
+	}
+	return t.store.SaveBreakerStates(sport, states)
+}
+
+// BreakerStates returns sport's loss breaker states saved for day.
+func (t *Tracker) BreakerStates(sport, day string) ([]BreakerState, error) {
+	if t == nil || t.store == nil {
+		return nil, nil
+	}
+	return t.store.BreakerStates(sport, day)
+}
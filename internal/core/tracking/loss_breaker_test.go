@@ -0,0 +1,47 @@
+package tracking
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestBreakerStates(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "tracking.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	tr := NewTracker(store, nil)
+
+	day1 := []BreakerState{
+		{Scope: "*", Day: "2026-10-16", BaseCents: -500, PeakCents: 200, Tripped: true, Reason: "daily loss"},
+		{Scope: "NHL", Day: "2026-10-16", BaseCents: 0, PeakCents: 50},
+	}
+	if err := tr.SaveBreakerStates("hockey", day1); err != nil {
+		t.Fatal(err)
+	}
+	// A rollover overwrites the sport-wide row only.
+	day2 := BreakerState{Scope: "*", Day: "2026-10-17"}
+	if err := tr.SaveBreakerStates("hockey", []BreakerState{day2}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sport, day string
+		want       []BreakerState
+	}{
+		{"hockey", "2026-10-16", day1[1:]},
+		{"hockey", "2026-10-17", []BreakerState{day2}},
+		{"soccer", "2026-10-16", nil},
+	}
+	for _, tt := range tests {
+		got, err := tr.BreakerStates(tt.sport, tt.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s %s: %+v, want %+v", tt.sport, tt.day, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package tracking
+
+import (
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// marketLeadSchema records games flagged because Kalshi moved before the
+// feed reported a score change, with the same follow-up price snapshots
+// as batch orders so the move can be judged afterwards.
+const marketLeadSchema = `CREATE TABLE IF NOT EXISTS market_leads (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	flagged_at  TEXT    NOT NULL,
+	eid         TEXT    NOT NULL,
+	sport       TEXT    NOT NULL,
+	league      TEXT    NOT NULL DEFAULT '',
+	home_team   TEXT    NOT NULL DEFAULT '',
+	away_team   TEXT    NOT NULL DEFAULT '',
+	home_score  INTEGER NOT NULL,
+	away_score  INTEGER NOT NULL,
+	period      TEXT    NOT NULL DEFAULT '',
+	time_left   TEXT    NOT NULL DEFAULT '',
+
+	ticker      TEXT    NOT NULL,
+	from_cents  REAL    NOT NULL,
+	to_cents    REAL    NOT NULL,
+	window_ms   INTEGER NOT NULL,
+
+	-- Set when the feed reports a score change while the flag is held.
+	feed_lag_ms INTEGER,
+
+	price_0s_home_yes_ask  REAL,
+	price_0s_away_yes_ask  REAL,
+	price_0s_draw_yes_ask  REAL,
+	price_1s_home_yes_ask  REAL,
+	price_1s_away_yes_ask  REAL,
+	price_1s_draw_yes_ask  REAL,
+	price_5s_home_yes_ask  REAL,
+	price_5s_away_yes_ask  REAL,
+	price_5s_draw_yes_ask  REAL,
+	price_10s_home_yes_ask REAL,
+	price_10s_away_yes_ask REAL,
+	price_10s_draw_yes_ask REAL
+);
+CREATE INDEX IF NOT EXISTS idx_market_leads_eid ON market_leads(eid);`
+
+// MarketLeadRecord is one market-leads-feed flag.
+type MarketLeadRecord struct {
+	FlaggedAt time.Time
+	GameEID   string
+	Sport     string
+	League    string
+	HomeTeam  string
+	AwayTeam  string
+	HomeScore int
+	AwayScore int
+	Period    string
+	TimeLeft  string
+
+	Ticker    string
+	FromCents float64
+	ToCents   float64
+	Window    time.Duration
+
+	Prices PriceSnapshot // at the flag
+}
+
+// InsertMarketLead persists a flag and returns its row ID.
+func (s *Store) InsertMarketLead(rec MarketLeadRecord) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res, err := s.db.Exec(
+		`INSERT INTO market_leads (
+			flagged_at, eid, sport, league, home_team, away_team,
+			home_score, away_score, period, time_left,
+			ticker, from_cents, to_cents, window_ms,
+			price_0s_home_yes_ask, price_0s_away_yes_ask, price_0s_draw_yes_ask
+		) VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`,
+		rec.FlaggedAt.UTC().Format(time.RFC3339Nano),
+		rec.GameEID, rec.Sport, rec.League, rec.HomeTeam, rec.AwayTeam,
+		rec.HomeScore, rec.AwayScore, rec.Period, rec.TimeLeft,
+		rec.Ticker, rec.FromCents, rec.ToCents, rec.Window.Milliseconds(),
+		rec.Prices.HomeYesAsk, rec.Prices.AwayYesAsk, rec.Prices.DrawYesAsk,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// UpdateMarketLeadPrices sets a flag's snapshot columns for a checkpoint.
+func (s *Store) UpdateMarketLeadPrices(rowID int64, checkpoint string, snap PriceSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var query string
+	switch checkpoint {
+	case "1s":
+		query = `UPDATE market_leads SET price_1s_home_yes_ask=?, price_1s_away_yes_ask=?, price_1s_draw_yes_ask=? WHERE id=?`
+	case "5s":
+		query = `UPDATE market_leads SET price_5s_home_yes_ask=?, price_5s_away_yes_ask=?, price_5s_draw_yes_ask=? WHERE id=?`
+	case "10s":
+		query = `UPDATE market_leads SET price_10s_home_yes_ask=?, price_10s_away_yes_ask=?, price_10s_draw_yes_ask=? WHERE id=?`
+	default:
+		return
+	}
+
+	if _, err := s.db.Exec(query, snap.HomeYesAsk, snap.AwayYesAsk, snap.DrawYesAsk, rowID); err != nil {
+		telemetry.Warnf("tracking: update market lead %s prices (row %d): %v", checkpoint, rowID, err)
+	}
+}
+
+// UpdateMarketLeadLag records how long after the flag the feed reported
+// the score change.
+func (s *Store) UpdateMarketLeadLag(rowID int64, lag time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.db.Exec(`UPDATE market_leads SET feed_lag_ms=? WHERE id=?`, lag.Milliseconds(), rowID); err != nil {
+		telemetry.Warnf("tracking: update market lead lag (row %d): %v", rowID, err)
+	}
+}
+
+// RecordMarketLead persists a market-leads-feed flag with the game's
+// prices now and at T+1s, T+5s and T+10s. The flag is read on the game's
+// goroutine and written on its own. The returned func stores the feed's
+// lag behind the flag once the insert is done.
+func (t *Tracker) RecordMarketLead(gc *game.GameContext, ml game.MarketLead) func(lag time.Duration) {
+	if t == nil || t.store == nil {
+		return func(time.Duration) {}
+	}
+
+	home, away, draw := gc.Game.GetTickers()
+	read := func() PriceSnapshot {
+		var snap PriceSnapshot
+		if td := gc.Tickers[home]; td != nil {
+			snap.HomeYesAsk = td.YesAsk
+		}
+		if td := gc.Tickers[away]; td != nil {
+			snap.AwayYesAsk = td.YesAsk
+		}
+		if td := gc.Tickers[draw]; td != nil {
+			snap.DrawYesAsk = td.YesAsk
+		}
+		return snap
+	}
+
+	rec := MarketLeadRecord{
+		FlaggedAt: ml.At,
+		GameEID:   gc.EID,
+		Sport:     string(gc.Sport),
+		League:    gc.League,
+		HomeTeam:  gc.Game.GetHomeTeam(),
+		AwayTeam:  gc.Game.GetAwayTeam(),
+		HomeScore: gc.Game.GetHomeScore(),
+		AwayScore: gc.Game.GetAwayScore(),
+		Period:    gc.Game.GetPeriod(),
+		TimeLeft:  formatTimeLeft(gc.Game.GetTimeRemaining()),
+		Ticker:    ml.Ticker,
+		FromCents: ml.FromCents,
+		ToCents:   ml.ToCents,
+		Window:    ml.Window,
+		Prices:    read(),
+	}
+
+	var id int64 // set before inserted is closed; 0 when the insert failed
+	inserted := make(chan struct{})
+	go func() {
+		rowID, err := t.store.InsertMarketLead(rec)
+		if err != nil {
+			telemetry.Warnf("tracking: insert market lead: %v", err)
+			close(inserted)
+			return
+		}
+		id = rowID
+		close(inserted)
+		followUpPrices(gc, "market lead", read,
+			func(label string, snap PriceSnapshot) { t.store.UpdateMarketLeadPrices(id, label, snap) })
+	}()
+
+	return func(lag time.Duration) {
+		go func() {
+			<-inserted
+			if id != 0 {
+				t.store.UpdateMarketLeadLag(id, lag)
+			}
+		}()
+	}
+}
@@ -0,0 +1,64 @@
+package tracking
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestRecordMarketLead(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "tracking.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	tr := NewTracker(store, nil)
+
+	hs := hockeyState.New("1", "NHL", "Boston", "Toronto")
+	hs.SetTickers("KXNHLGAME-BOS", "KXNHLGAME-TOR", "")
+	gc := game.NewGameContext(events.SportHockey, "NHL", "1", hs)
+	gc.Tickers["KXNHLGAME-BOS"] = &game.TickerData{Ticker: "KXNHLGAME-BOS", YesAsk: 57}
+
+	catchUp := tr.RecordMarketLead(gc, game.MarketLead{
+		At: time.Now(), Ticker: "KXNHLGAME-BOS", FromCents: 41, ToCents: 56, Window: 5 * time.Second,
+	})
+	catchUp(1500 * time.Millisecond)
+
+	var (
+		ticker string
+		ask    float64
+		lag    sql.NullInt64
+	)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		store.mu.Lock()
+		err = store.db.QueryRow(
+			`SELECT ticker, price_0s_home_yes_ask, feed_lag_ms FROM market_leads WHERE eid = ?`, "1",
+		).Scan(&ticker, &ask, &lag)
+		store.mu.Unlock()
+		if err == nil && lag.Valid {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("market lead not recorded with its lag: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if ticker != "KXNHLGAME-BOS" || ask != 57 || lag.Int64 != 1500 {
+		t.Errorf("got ticker %s, ask %v, lag %dms; want KXNHLGAME-BOS, 57, 1500ms", ticker, ask, lag.Int64)
+	}
+}
+
+func TestRecordMarketLeadWithoutStore(t *testing.T) {
+	var tr *Tracker
+	hs := hockeyState.New("1", "NHL", "Boston", "Toronto")
+	gc := game.NewGameContext(events.SportHockey, "NHL", "1", hs)
+	defer gc.Close()
+	tr.RecordMarketLead(gc, game.MarketLead{})(time.Second)
+}
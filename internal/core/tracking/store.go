@@ -60,6 +60,18 @@ func OpenStore(path string) (*Store, error) {
 		db.Close()
 		return nil, fmt.Errorf("init tracking schema: %w", err)
 	}
+	if _, err := db.Exec(cancelSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init order_cancels schema: %w", err)
+	}
+	if _, err := db.Exec(marketLeadSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init market_leads schema: %w", err)
+	}
+	if _, err := db.Exec(lossBreakerSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init loss_breakers schema: %w", err)
+	}
 
 	for _, col := range []string{"home_order_id", "away_order_id", "draw_order_id"} {
 		db.Exec(fmt.Sprintf(`ALTER TABLE batch_orders ADD COLUMN %s TEXT`, col))
@@ -77,6 +89,7 @@ func OpenStore(path string) (*Store, error) {
 		"draw_yes_cost_cents INTEGER", "draw_yes_fill_count INTEGER", "draw_yes_total_count INTEGER",
 		"draw_no_order_id TEXT", "draw_no_ticker TEXT", "draw_no_limit_cents INTEGER",
 		"draw_no_cost_cents INTEGER", "draw_no_fill_count INTEGER", "draw_no_total_count INTEGER",
+		"settled_at TEXT",
 	} {
 		db.Exec(fmt.Sprintf(`ALTER TABLE batch_orders ADD COLUMN %s`, col))
 	}
@@ -263,6 +276,72 @@ func (s *Store) UpdateFinalFill(rowID int64, outcomeKey, orderID string, costCen
 	}
 }
 
+// ScoreForOrder returns the score a tracked order was placed at, so a
+// restarted process can rebuild its per-score dedup state.
+func (s *Store) ScoreForOrder(orderID string) (homeScore, awayScore int, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.db.QueryRow(
+		`SELECT home_score, away_score FROM batch_orders
+		 WHERE ? IN (home_yes_order_id, home_no_order_id, away_yes_order_id,
+		             away_no_order_id, draw_yes_order_id, draw_no_order_id)
+		 ORDER BY id DESC LIMIT 1`, orderID,
+	).Scan(&homeScore, &awayScore)
+	if err != nil {
+		return 0, 0, false
+	}
+	return homeScore, awayScore, true
+}
+
+// cancelSchema records orders we pulled from the book ourselves, with the
+// price at the time, so avoided adverse fills can be measured afterwards.
+const cancelSchema = `CREATE TABLE IF NOT EXISTS order_cancels (
+	id                INTEGER PRIMARY KEY AUTOINCREMENT,
+	canceled_at       TEXT    NOT NULL,
+	eid               TEXT    NOT NULL,
+	order_id          TEXT    NOT NULL,
+	ticker            TEXT    NOT NULL,
+	side              TEXT    NOT NULL,
+	reason            TEXT    NOT NULL,
+	limit_cents       INTEGER NOT NULL,
+	filled_count      INTEGER NOT NULL,
+	canceled_count    INTEGER NOT NULL,
+	yes_ask_at_cancel REAL
+);
+CREATE INDEX IF NOT EXISTS idx_cancels_eid ON order_cancels(eid);`
+
+// CancelRecord describes one order canceled by the system.
+type CancelRecord struct {
+	GameEID        string
+	OrderID        string
+	Ticker         string
+	Side           string
+	Reason         string // e.g. "overturn_pending"
+	LimitCents     int
+	FilledCount    int
+	CanceledCount  int      // contracts still resting when canceled
+	YesAskAtCancel *float64 // nil when no live price was held
+}
+
+// InsertCancel persists a CancelRecord.
+func (s *Store) InsertCancel(rec CancelRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.db.Exec(
+		`INSERT INTO order_cancels (
+			canceled_at, eid, order_id, ticker, side, reason,
+			limit_cents, filled_count, canceled_count, yes_ask_at_cancel
+		) VALUES (?,?,?,?,?,?,?,?,?,?)`,
+		time.Now().UTC().Format(time.RFC3339Nano),
+		rec.GameEID, rec.OrderID, rec.Ticker, rec.Side, rec.Reason,
+		rec.LimitCents, rec.FilledCount, rec.CanceledCount, rec.YesAskAtCancel,
+	); err != nil {
+		telemetry.Warnf("tracking: insert cancel %s: %v", rec.OrderID, err)
+	}
+}
+
 // batchRow is the subset of columns needed for settlement P&L calculation.
 type batchRow struct {
 	ID           int64
@@ -272,6 +351,13 @@ type batchRow struct {
 	AwayNoCost   sql.NullInt64
 	DrawYesCost  sql.NullInt64
 	DrawNoCost   sql.NullInt64
+
+	HomeYesFills sql.NullInt64
+	HomeNoFills  sql.NullInt64
+	AwayYesFills sql.NullInt64
+	AwayNoFills  sql.NullInt64
+	DrawYesFills sql.NullInt64
+	DrawNoFills  sql.NullInt64
 }
 
 // UnsettledForEID returns all batch orders for a game that lack a final outcome.
@@ -283,7 +369,10 @@ func (s *Store) UnsettledForEID(eid string) ([]batchRow, error) {
 		`SELECT id,
 			home_yes_cost_cents, home_no_cost_cents,
 			away_yes_cost_cents, away_no_cost_cents,
-			draw_yes_cost_cents, draw_no_cost_cents
+			draw_yes_cost_cents, draw_no_cost_cents,
+			home_yes_fill_count, home_no_fill_count,
+			away_yes_fill_count, away_no_fill_count,
+			draw_yes_fill_count, draw_no_fill_count
 		 FROM batch_orders WHERE eid = ? AND final_outcome IS NULL`, eid)
 	if err != nil {
 		return nil, err
@@ -297,6 +386,9 @@ func (s *Store) UnsettledForEID(eid string) ([]batchRow, error) {
 			&r.HomeYesCost, &r.HomeNoCost,
 			&r.AwayYesCost, &r.AwayNoCost,
 			&r.DrawYesCost, &r.DrawNoCost,
+			&r.HomeYesFills, &r.HomeNoFills,
+			&r.AwayYesFills, &r.AwayNoFills,
+			&r.DrawYesFills, &r.DrawNoFills,
 		); err != nil {
 			return nil, err
 		}
@@ -311,13 +403,40 @@ func (s *Store) UpdateSettlement(rowID int64, outcome string, pnlCents int) {
 	defer s.mu.Unlock()
 
 	if _, err := s.db.Exec(
-		`UPDATE batch_orders SET final_outcome=?, final_pnl=? WHERE id=?`,
-		outcome, pnlCents, rowID,
+		`UPDATE batch_orders SET final_outcome=?, final_pnl=?, settled_at=? WHERE id=?`,
+		outcome, pnlCents, time.Now().UTC().Format(time.RFC3339Nano), rowID,
 	); err != nil {
 		telemetry.Warnf("tracking: update settlement (row %d): %v", rowID, err)
 	}
 }
 
+// RealizedPnLSince sums the settled P&L of a sport's batches settled at or
+// after since, keyed by league.
+func (s *Store) RealizedPnLSince(sport string, since time.Time) (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows, err := s.db.Query(
+		`SELECT league, COALESCE(SUM(final_pnl), 0) FROM batch_orders
+		 WHERE sport = ? AND settled_at >= ? GROUP BY league`,
+		sport, since.UTC().Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string]int)
+	for rows.Next() {
+		var league string
+		var pnl int
+		if err := rows.Scan(&league, &pnl); err != nil {
+			return nil, err
+		}
+		out[league] = pnl
+	}
+	return out, rows.Err()
+}
+
 // refreshSize re-reads the database file size from SQLite pragmas.
 // Must be called with s.mu held.
 func (s *Store) refreshSize() {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
@@ -73,15 +74,41 @@ type PriceSnapshot struct {
 
 // Tracker records batch orders, schedules follow-up price captures,
 // and settles P&L on game finish. It implements game.GameObserver.
+//
+// Fill data normally arrives pushed from the Kalshi WS user channels via
+// UpdateFill. backfillFills only polls REST for legs that never reported
+// a final state (e.g. the WS was down, or the paper exchange is in use).
+//
+// A nil *Tracker records nothing: every exported method is a no-op on it,
+// so callers never check for one.
 type Tracker struct {
 	store  *Store
 	poller OrderPoller
+
+	mu    sync.Mutex
+	legs  map[string]legRef // order ID → batch row + leg
+	final map[string]bool   // order IDs whose final fill was pushed
+
+	onSettle   func(gc *game.GameContext, pnlCents int)
+	onBackfill func(detail kalshi_http.OrderDetail)
+}
+
+// legRef locates one order's columns in batch_orders.
+type legRef struct {
+	rowID      int64
+	outcomeKey string
+	backfill   bool // a backfillFills goroutine will clean this entry up
 }
 
 var _ game.GameObserver = (*Tracker)(nil)
 
 func NewTracker(store *Store, poller OrderPoller) *Tracker {
-	return &Tracker{store: store, poller: poller}
+	return &Tracker{
+		store:  store,
+		poller: poller,
+		legs:   make(map[string]legRef),
+		final:  make(map[string]bool),
+	}
 }
 
 // RecordBatch builds a BatchOrderContext from the fill results and persists it.
@@ -172,12 +199,27 @@ func (t *Tracker) RecordBatch(
 	telemetry.Debugf("[TRACKING] batch #%d recorded for %s vs %s (eid=%s, type=%s)",
 		rowID, boc.HomeTeam, boc.AwayTeam, boc.GameEID, boc.OrderType)
 
+	backfill := t.poller != nil && boc.OrderType != "slam"
+	t.mu.Lock()
+	for key, orderID := range collectOrderIDs(boc) {
+		t.legs[orderID] = legRef{rowID: rowID, outcomeKey: key, backfill: backfill}
+	}
+	t.mu.Unlock()
+
 	go t.captureFollowUpPrices(gc, boc)
 	go t.backfillFills(boc)
 }
 
 // captureFollowUpPrices reads ticker prices at T+1s, T+5s, T+10s via gc.Send.
 func (t *Tracker) captureFollowUpPrices(gc *game.GameContext, boc *BatchOrderContext) {
+	followUpPrices(gc, fmt.Sprintf("batch #%d", boc.ID),
+		func() PriceSnapshot { return readPriceSnapshot(gc, boc) },
+		func(label string, snap PriceSnapshot) { t.store.UpdateFollowUpPrices(boc.ID, label, snap) })
+}
+
+// followUpPrices runs read on the game's goroutine at T+1s, T+5s and T+10s
+// and hands each snapshot to save with its checkpoint label.
+func followUpPrices(gc *game.GameContext, what string, read func() PriceSnapshot, save func(label string, snap PriceSnapshot)) {
 	checkpoints := []struct {
 		delay time.Duration
 		label string
@@ -192,14 +234,14 @@ func (t *Tracker) captureFollowUpPrices(gc *game.GameContext, boc *BatchOrderCon
 
 		ch := make(chan PriceSnapshot, 1)
 		gc.Send(func() {
-			ch <- readPriceSnapshot(gc, boc)
+			ch <- read()
 		})
 
 		select {
 		case snap := <-ch:
-			t.store.UpdateFollowUpPrices(boc.ID, cp.label, snap)
+			save(cp.label, snap)
 		case <-time.After(5 * time.Second):
-			telemetry.Warnf("tracking: timeout reading %s prices for batch #%d", cp.label, boc.ID)
+			telemetry.Warnf("tracking: timeout reading %s prices for %s", cp.label, what)
 		}
 	}
 }
@@ -239,9 +281,52 @@ func tickerFrom(a, b *OutcomeOrder) string {
 	return ""
 }
 
+// UpdateFill writes an order's cumulative fill state to its batch row.
+// Orders not recorded by RecordBatch (other processes, MOCK games) are
+// ignored. Once final, the leg is excluded from the REST backfill.
+//
+// Called from the execution service on every pushed fill or order update.
+func (t *Tracker) UpdateFill(orderID string, costCents, fillCount, totalCount int, final bool) {
+	if t == nil || t.store == nil {
+		return
+	}
+
+	t.mu.Lock()
+	ref, ok := t.legs[orderID]
+	if ok && final {
+		if ref.backfill {
+			t.final[orderID] = true
+		} else {
+			delete(t.legs, orderID)
+		}
+	}
+	t.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	t.store.UpdateFinalFill(ref.rowID, ref.outcomeKey, orderID, costCents, fillCount, totalCount)
+}
+
+// RecordCancel persists an order the system canceled.
+func (t *Tracker) RecordCancel(rec CancelRecord) {
+	if t == nil || t.store == nil {
+		return
+	}
+	t.store.InsertCancel(rec)
+}
+
+// ScoreForOrder looks up the score an order was placed at.
+func (t *Tracker) ScoreForOrder(orderID string) (homeScore, awayScore int, ok bool) {
+	if t == nil || t.store == nil {
+		return 0, 0, false
+	}
+	return t.store.ScoreForOrder(orderID)
+}
+
 // backfillFills waits for orders to expire (TTL + 5s buffer), then polls
-// Kalshi for final fill data once the read rate-limit bucket has > 8 tokens
-// (waiting up to 30s for budget).
+// Kalshi for final fill data on any leg the WS did not already settle,
+// once the read rate-limit bucket has > 8 tokens (waiting up to 30s).
 func (t *Tracker) backfillFills(boc *BatchOrderContext) {
 	if t.poller == nil || boc.OrderType == "slam" {
 		return
@@ -251,6 +336,7 @@ func (t *Tracker) backfillFills(boc *BatchOrderContext) {
 	if len(orderIDs) == 0 {
 		return
 	}
+	defer t.forget(orderIDs)
 
 	sleepSec := boc.OrderTTLSec + 5
 	if sleepSec < 10 {
@@ -258,6 +344,18 @@ func (t *Tracker) backfillFills(boc *BatchOrderContext) {
 	}
 	time.Sleep(time.Duration(sleepSec) * time.Second)
 
+	t.mu.Lock()
+	for outcome, orderID := range orderIDs {
+		if t.final[orderID] {
+			delete(orderIDs, outcome)
+		}
+	}
+	t.mu.Unlock()
+	if len(orderIDs) == 0 {
+		telemetry.Debugf("tracking: batch #%d fills arrived over WS — no backfill needed", boc.ID)
+		return
+	}
+
 	if !t.waitForBudget(boc.ID) {
 		return
 	}
@@ -274,6 +372,9 @@ func (t *Tracker) backfillFills(boc *BatchOrderContext) {
 
 		finalCost := detail.TakerFillCost + detail.MakerFillCost + detail.TakerFees + detail.MakerFees
 		t.store.UpdateFinalFill(boc.ID, outcome, detail.OrderID, finalCost, detail.FillCount, detail.FillCount+detail.RemainingCount)
+		if t.onBackfill != nil {
+			t.onBackfill(*detail)
+		}
 	}
 
 	telemetry.Debugf("tracking: backfilled fills for batch #%d (%d orders polled)", boc.ID, len(orderIDs))
@@ -298,6 +399,16 @@ func (t *Tracker) waitForBudget(batchID int64) bool {
 	}
 }
 
+// forget drops the leg bookkeeping for a batch once backfill is done.
+func (t *Tracker) forget(orderIDs map[string]string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, orderID := range orderIDs {
+		delete(t.legs, orderID)
+		delete(t.final, orderID)
+	}
+}
+
 func collectOrderIDs(boc *BatchOrderContext) map[string]string {
 	ids := make(map[string]string)
 	for key, oo := range map[string]*OutcomeOrder{
@@ -315,7 +426,7 @@ func collectOrderIDs(boc *BatchOrderContext) map[string]string {
 // OnGameEvent implements game.GameObserver. On GAME_FINISH, it settles
 // all unsettled batch orders for the game by computing realized P&L.
 func (t *Tracker) OnGameEvent(gc *game.GameContext, eventType string) {
-	if eventType != string(events.StatusGameFinish) {
+	if t == nil || eventType != string(events.StatusGameFinish) {
 		return
 	}
 
@@ -342,12 +453,47 @@ func (t *Tracker) OnGameEvent(gc *game.GameContext, eventType string) {
 		return
 	}
 
+	total := 0
 	for _, r := range rows {
 		pnl := computePnL(r, outcome)
 		t.store.UpdateSettlement(r.ID, outcome, pnl)
+		total += pnl
 	}
 
 	telemetry.Infof("[TRACKING] settled %d batch(es) for eid=%s outcome=%s", len(rows), eid, outcome)
+	if t.onSettle != nil {
+		t.onSettle(gc, total)
+	}
+}
+
+// OnSettle registers fn to receive each game's realized P&L when its
+// batches settle. It runs on the game's goroutine. Must be set before
+// games start.
+func (t *Tracker) OnSettle(fn func(gc *game.GameContext, pnlCents int)) {
+	if t == nil {
+		return
+	}
+	t.onSettle = fn
+}
+
+// OnBackfill registers fn to receive each order polled by the REST
+// backfill, so the execution layer can settle orders whose final state
+// never arrived over the WS. It runs on the backfill goroutine. Must be
+// set before any batch is recorded.
+func (t *Tracker) OnBackfill(fn func(detail kalshi_http.OrderDetail)) {
+	if t == nil {
+		return
+	}
+	t.onBackfill = fn
+}
+
+// RealizedPnLSince sums settled P&L for sport since the given time, keyed
+// by league.
+func (t *Tracker) RealizedPnLSince(sport string, since time.Time) (map[string]int, error) {
+	if t == nil || t.store == nil {
+		return nil, nil
+	}
+	return t.store.RealizedPnLSince(sport, since)
 }
 
 // computePnL calculates realized P&L across all outcome legs of a batch.
@@ -355,20 +501,22 @@ func (t *Tracker) OnGameEvent(gc *game.GameContext, eventType string) {
 // at 100 cents, otherwise 0. P&L = settlement_value - cost.
 func computePnL(r batchRow, outcome string) int {
 	pnl := 0
-	pnl += legPnL(r.HomeYesCost, "yes", "home", outcome)
-	pnl += legPnL(r.HomeNoCost, "no", "home", outcome)
-	pnl += legPnL(r.AwayYesCost, "yes", "away", outcome)
-	pnl += legPnL(r.AwayNoCost, "no", "away", outcome)
-	pnl += legPnL(r.DrawYesCost, "yes", "draw", outcome)
-	pnl += legPnL(r.DrawNoCost, "no", "draw", outcome)
+	pnl += legPnL(r.HomeYesCost, r.HomeYesFills, "yes", "home", outcome)
+	pnl += legPnL(r.HomeNoCost, r.HomeNoFills, "no", "home", outcome)
+	pnl += legPnL(r.AwayYesCost, r.AwayYesFills, "yes", "away", outcome)
+	pnl += legPnL(r.AwayNoCost, r.AwayNoFills, "no", "away", outcome)
+	pnl += legPnL(r.DrawYesCost, r.DrawYesFills, "yes", "draw", outcome)
+	pnl += legPnL(r.DrawNoCost, r.DrawNoFills, "no", "draw", outcome)
 	return pnl
 }
 
 // legPnL computes P&L for one outcome leg.
 //
-// A YES buy on the winning outcome settles at 100. A NO buy on a losing
-// outcome also settles at 100 (you bought NO on a team that lost = correct).
-func legPnL(costCents sql.NullInt64, side, legOutcome, gameOutcome string) int {
+// A YES buy on the winning outcome settles at 100 per contract. A NO buy
+// on a losing outcome also settles at 100 (you bought NO on a team that
+// lost = correct). Rows from before fill counts were recorded count as
+// one contract.
+func legPnL(costCents, fills sql.NullInt64, side, legOutcome, gameOutcome string) int {
 	if !costCents.Valid {
 		return 0
 	}
@@ -376,6 +524,10 @@ func legPnL(costCents sql.NullInt64, side, legOutcome, gameOutcome string) int {
 	if cost == 0 {
 		return 0
 	}
+	count := 1
+	if fills.Valid && fills.Int64 > 0 {
+		count = int(fills.Int64)
+	}
 
 	won := false
 	switch side {
@@ -386,7 +538,7 @@ func legPnL(costCents sql.NullInt64, side, legOutcome, gameOutcome string) int {
 	}
 
 	if won {
-		return 100 - cost
+		return 100*count - cost
 	}
 	return -cost
 }
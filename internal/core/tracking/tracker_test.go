@@ -0,0 +1,36 @@
+package tracking
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/core/state/game"
+	hockeyState "github.com/charleschow/hft-trading/internal/core/state/game/hockey"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestNilTracker(t *testing.T) {
+	var tr *Tracker
+	gc := game.NewGameContext(events.SportHockey, "NHL", "1", hockeyState.New("1", "NHL", "Boston", "Toronto"))
+	defer gc.Close()
+
+	tr.RecordBatch(gc, []events.OrderIntent{{EID: "1"}}, nil, 30)
+	tr.UpdateFill("o1", 100, 2, 2, true)
+	tr.RecordCancel(CancelRecord{OrderID: "o1"})
+	tr.OnGameEvent(gc, string(events.StatusGameFinish))
+	tr.OnSettle(func(*game.GameContext, int) {})
+	tr.OnBackfill(func(kalshi_http.OrderDetail) {})
+	if _, _, ok := tr.ScoreForOrder("o1"); ok {
+		t.Error("ScoreForOrder found an order")
+	}
+	if pnl, err := tr.RealizedPnLSince("hockey", time.Now()); pnl != nil || err != nil {
+		t.Errorf("RealizedPnLSince = %v, %v", pnl, err)
+	}
+	if err := tr.SaveBreakerStates("hockey", []BreakerState{{Scope: "*"}}); err != nil {
+		t.Error(err)
+	}
+	if err := tr.Close(); err != nil {
+		t.Error(err)
+	}
+}
@@ -29,8 +29,17 @@ const (
 	EventGameUpdate EventType = "game_update"
 	// Kalshi Ticker Events
 	EventMarketData EventType = "market_data"
+	// Kalshi Orderbook Events (orderbook_snapshot / orderbook_delta)
+	EventOrderBook EventType = "orderbook"
+	// Kalshi user channels (authenticated): fill and user_orders
+	EventFill        EventType = "fill"
+	EventOrderUpdate EventType = "order_update"
 	// Kalshi WebSocket status
 	EventWSStatus EventType = "ws_status"
 	// Internal Order Events — payload is []OrderIntent (batch)
 	EventOrderIntent EventType = "order_intent"
+	// Kill switch control message, relayed to every sport process
+	EventHalt EventType = "halt"
+	// Manual loss-breaker reset, relayed to the named sport's process
+	EventBreakerReset EventType = "breaker_reset"
 )
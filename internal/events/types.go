@@ -1,5 +1,7 @@
 package events
 
+import "time"
+
 // MatchStatus represents the current state of a game for display and logic.
 type MatchStatus string
 
@@ -39,6 +41,10 @@ type GameUpdateEvent struct {
 	// Zero when GoalServe doesn't provide it (some hockey feeds).
 	GameStartUTC int64 `json:"game_start_utc,omitempty"`
 
+	// Soccer minutes of extra time left (of 30) while it is played.
+	// TimeLeft counts regulation only and is 0 by then.
+	ExtraTimeLeft float64 `json:"extra_time_left,omitempty"`
+
 	// Soccer red card counts from the current webhook snapshot.
 	HomeRedCards int `json:"home_red_cards,omitempty"`
 	AwayRedCards int `json:"away_red_cards,omitempty"`
@@ -58,6 +64,73 @@ type MarketEvent struct {
 	Volume int64   `json:"volume"`
 }
 
+// BookLevel is one aggregated price level: Count resting contracts bid at
+// Price cents.
+type BookLevel struct {
+	Price int `json:"price"`
+	Count int `json:"count"`
+}
+
+// OrderBookEvent is published for every Kalshi orderbook_delta channel
+// message. Exactly one of the three shapes is set:
+//
+//   - Snapshot: YesBids/NoBids replace the whole book for Ticker.
+//   - Delta:    Count resting contracts at Price on Side change by Delta.
+//   - Invalidate: a sequence gap was detected; the book must not be used
+//     until the next snapshot arrives.
+//
+// Kalshi books only hold bids. A YES bid at p is a NO offer at 100-p, so
+// asks are derived from the opposite side.
+type OrderBookEvent struct {
+	Ticker string `json:"ticker"`
+
+	Snapshot bool        `json:"snapshot,omitempty"`
+	YesBids  []BookLevel `json:"yes_bids,omitempty"`
+	NoBids   []BookLevel `json:"no_bids,omitempty"`
+
+	Side  string `json:"side,omitempty"` // "yes" or "no"
+	Price int    `json:"price,omitempty"`
+	Delta int    `json:"delta,omitempty"`
+
+	Invalidate bool `json:"invalidate,omitempty"`
+}
+
+// FillEvent is published for every trade on one of our orders, from the
+// authenticated Kalshi fill channel. PriceCents is the price paid for Side
+// (a NO fill at yes_price 40 has PriceCents 60).
+type FillEvent struct {
+	TradeID       string    `json:"trade_id"`
+	OrderID       string    `json:"order_id"`
+	ClientOrderID string    `json:"client_order_id,omitempty"`
+	Ticker        string    `json:"ticker"`
+	Side          string    `json:"side"`   // "yes" or "no"
+	Action        string    `json:"action"` // "buy" or "sell"
+	PriceCents    int       `json:"price_cents"`
+	Count         int       `json:"count"`
+	IsTaker       bool      `json:"is_taker"`
+	Ts            time.Time `json:"ts"`
+}
+
+// OrderUpdateEvent is published whenever Kalshi reports a change to one of
+// our orders on the user_orders channel. Counts and costs are cumulative
+// for the order, so the latest update is always authoritative.
+type OrderUpdateEvent struct {
+	OrderID        string `json:"order_id"`
+	ClientOrderID  string `json:"client_order_id,omitempty"`
+	Ticker         string `json:"ticker"`
+	Side           string `json:"side"`
+	Status         string `json:"status"` // "resting", "canceled", "executed"
+	FillCount      int    `json:"fill_count"`
+	RemainingCount int    `json:"remaining_count"`
+	FillCostCents  int    `json:"fill_cost_cents"` // taker + maker fill cost
+	FeesCents      int    `json:"fees_cents"`      // taker + maker fees
+}
+
+// Terminal reports whether the order can no longer fill.
+func (u OrderUpdateEvent) Terminal() bool {
+	return u.Status == "canceled" || u.Status == "executed"
+}
+
 // OrderIntent is published by a strategy when it wants to place an order.
 // The execution service subscribes and handles risk checks + placement.
 type OrderIntent struct {
@@ -71,6 +144,14 @@ type OrderIntent struct {
 	LimitPct float64 `json:"limit_pct"`
 	Reason   string  `json:"reason"`
 
+	// ModelPct is the model's probability (0-100) that Side wins, used to
+	// size the order. Zero leaves the intent unsized (one contract); slams
+	// are sized as a certainty whatever it holds.
+	ModelPct float64 `json:"model_pct,omitempty"`
+
+	// Count is the number of contracts, set by execution's sizing.
+	Count int `json:"count,omitempty"`
+
 	// Context for idempotency: orders are deduped per (ticker, home_score, away_score).
 	HomeScore int `json:"home_score"`
 	AwayScore int `json:"away_score"`
@@ -81,6 +162,34 @@ type OrderIntent struct {
 
 	// Slam bypasses idempotency entirely (used for game-finish orders).
 	Slam bool `json:"slam,omitempty"`
+
+	// Requote asks execution to move the game's resting orders on
+	// Ticker/Side to LimitPct. No new order is placed.
+	Requote bool `json:"requote,omitempty"`
+
+	// TTLSeconds overrides the sport's order expiry for this intent. Zero
+	// uses the sport's TTL; slams never expire.
+	TTLSeconds int `json:"ttl_seconds,omitempty"`
+
+	// StrategyID names the strategy that produced the intent. Empty for
+	// the sport's live strategy; shadow intents are never published.
+	StrategyID string `json:"strategy_id,omitempty"`
+}
+
+// HaltEvent is the kill switch: once received, no further intents are
+// approved and resting orders are canceled. It is a latch — trading only
+// resumes after a restart with the trigger cleared.
+type HaltEvent struct {
+	Reason string    `json:"reason"`
+	Source string    `json:"source"` // "admin", "signal", "file", ...
+	At     time.Time `json:"at"`
+}
+
+// BreakerResetEvent re-arms a sport's loss breakers. An empty League
+// resets the sport breaker and every league breaker.
+type BreakerResetEvent struct {
+	Sport  Sport  `json:"sport"`
+	League string `json:"league,omitempty"`
 }
 
 // WSStatusEvent signals Kalshi WebSocket connect/disconnect to sport processes.
@@ -72,6 +72,18 @@ func UnmarshalEvent(data []byte) (events.Event, error) {
 			return evt, fmt.Errorf("unmarshal ws_status: %w", err)
 		}
 		evt.Payload = ws
+	case events.EventHalt:
+		var h events.HaltEvent
+		if err := json.Unmarshal(env.Payload, &h); err != nil {
+			return evt, fmt.Errorf("unmarshal halt: %w", err)
+		}
+		evt.Payload = h
+	case events.EventBreakerReset:
+		var r events.BreakerResetEvent
+		if err := json.Unmarshal(env.Payload, &r); err != nil {
+			return evt, fmt.Errorf("unmarshal breaker_reset: %w", err)
+		}
+		evt.Payload = r
 	default:
 		return evt, fmt.Errorf("unknown event type: %s", env.Type)
 	}
@@ -35,6 +35,10 @@ type sportClient struct {
 type Server struct {
 	mu      sync.Mutex
 	clients map[*sportClient]struct{}
+
+	// halt is the serialized kill-switch event once one has fired. It is
+	// replayed to every client that connects afterwards.
+	halt []byte
 }
 
 func NewServer(bus *events.Bus) *Server {
@@ -44,6 +48,8 @@ func NewServer(bus *events.Bus) *Server {
 	bus.Subscribe(events.EventGameUpdate, s.forward)
 	bus.Subscribe(events.EventMarketData, s.forward)
 	bus.Subscribe(events.EventWSStatus, s.forward)
+	bus.Subscribe(events.EventHalt, s.forward)
+	bus.Subscribe(events.EventBreakerReset, s.forward)
 	return s
 }
 
@@ -59,13 +65,24 @@ func (s *Server) forward(evt events.Event) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	halt := evt.Type == events.EventHalt
+	if halt {
+		s.halt = data
+	}
+
 	for c := range s.clients {
-		if evt.Type != events.EventMarketData && evt.Type != events.EventWSStatus && c.sport != evt.Sport {
+		if !halt && evt.Type != events.EventMarketData && evt.Type != events.EventWSStatus && c.sport != evt.Sport {
 			continue
 		}
 		select {
 		case c.send <- data:
 		default:
+			if halt {
+				// Never drop a halt: force a reconnect, which replays it.
+				telemetry.Errorf("fanout: halt not queued for slow client sport=%s — disconnecting it", c.sport)
+				c.conn.Close()
+				continue
+			}
 			telemetry.Warnf("fanout: dropping message for slow client sport=%s", c.sport)
 		}
 	}
@@ -96,6 +113,9 @@ func (s *Server) HandleWS(w http.ResponseWriter, r *http.Request) {
 
 	s.mu.Lock()
 	s.clients[c] = struct{}{}
+	if s.halt != nil {
+		c.send <- s.halt
+	}
 	s.mu.Unlock()
 
 	telemetry.Plainf("Fanout: Client Connected [%s] at %s", strings.ToUpper(string(sport)[:1])+string(sport)[1:], time.Now().Format("15:04:05"))
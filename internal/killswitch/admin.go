@@ -0,0 +1,59 @@
+package killswitch
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// TokenHeader carries the shared secret every admin request must present.
+const TokenHeader = "X-Admin-Token"
+
+// RequireToken wraps an admin handler so that only requests carrying token
+// in TokenHeader reach it; the rest get 401.
+func RequireToken(token string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := r.Header.Get(TokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// Handler serves the admin endpoints:
+//
+//	POST /admin/halt?reason=...   trip the switch
+//	GET  /admin/status            current halt state
+func (k *Switch) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /admin/halt", func(w http.ResponseWriter, r *http.Request) {
+		reason := r.URL.Query().Get("reason")
+		if reason == "" {
+			reason = "admin endpoint"
+		}
+		tripped := k.Trigger("admin", reason)
+		k.writeStatus(w, tripped)
+	})
+	mux.HandleFunc("GET /admin/status", func(w http.ResponseWriter, r *http.Request) {
+		k.writeStatus(w, false)
+	})
+	return mux
+}
+
+func (k *Switch) writeStatus(w http.ResponseWriter, tripped bool) {
+	resp := struct {
+		Halted  bool      `json:"halted"`
+		Tripped bool      `json:"tripped,omitempty"` // this request tripped it
+		Source  string    `json:"source,omitempty"`
+		Reason  string    `json:"reason,omitempty"`
+		At      time.Time `json:"at,omitzero"`
+	}{Tripped: tripped}
+	if h, ok := k.Halted(); ok {
+		resp.Halted, resp.Source, resp.Reason, resp.At = true, h.Source, h.Reason, h.At
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
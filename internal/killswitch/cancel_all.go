@@ -0,0 +1,69 @@
+package killswitch
+
+import (
+	"context"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// sweepDelay is the wait before the second cancel sweep, which catches
+// orders a sport process had in flight when the halt arrived.
+const sweepDelay = 5 * time.Second
+
+// Account is satisfied by *kalshi_http.Client.
+type Account interface {
+	GetOrders(ctx context.Context, status string) ([]kalshi_http.OrderDetail, error)
+	BatchCancelOrders(ctx context.Context, orderIDs []string) (int, error)
+	GetPositions(ctx context.Context) (*kalshi_http.PositionResponse, error)
+}
+
+// CancelAll cancels every resting order on the account — including ones
+// left by processes that are no longer running — sweeps again after
+// sweepDelay, then logs the exposure left on the book.
+func CancelAll(ctx context.Context, acct Account) {
+	for sweep := 1; sweep <= 2; sweep++ {
+		if sweep > 1 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(sweepDelay):
+			}
+		}
+		resting, err := acct.GetOrders(ctx, "resting")
+		if err != nil {
+			telemetry.Errorf("[KILL] list resting orders: %v", err)
+			continue
+		}
+		if len(resting) == 0 {
+			continue
+		}
+		ids := make([]string, len(resting))
+		for i, o := range resting {
+			ids[i] = o.OrderID
+		}
+		n, err := acct.BatchCancelOrders(ctx, ids)
+		if err != nil {
+			telemetry.Errorf("[KILL] cancel sweep %d: %v (%d/%d canceled)", sweep, err, n, len(ids))
+			continue
+		}
+		telemetry.Infof("[KILL] cancel sweep %d: %d/%d resting orders canceled", sweep, n, len(ids))
+	}
+
+	positions, err := acct.GetPositions(ctx)
+	if err != nil {
+		telemetry.Errorf("[KILL] final exposure unavailable: %v", err)
+		return
+	}
+	total, markets := 0, 0
+	for _, p := range positions.MarketPositions {
+		if p.Position == 0 {
+			continue
+		}
+		markets++
+		total += p.MarketExposure
+		telemetry.Infof("[KILL]   %s  %+d contracts  exposure $%.2f", p.Ticker, p.Position, float64(p.MarketExposure)/100.0)
+	}
+	telemetry.Infof("[KILL] final exposure $%.2f across %d markets", float64(total)/100.0, markets)
+}
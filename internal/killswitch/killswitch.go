@@ -0,0 +1,110 @@
+// Package killswitch is the emergency halt shared by the central and sport
+// processes. A Switch latches once: every trigger after the first is a
+// no-op, and trading only resumes after a restart with the trigger cleared.
+package killswitch
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/events"
+	"github.com/charleschow/hft-trading/internal/telemetry"
+)
+
+// filePollInterval is how often the sentinel file is checked.
+const filePollInterval = time.Second
+
+// Switch fans a single halt out to its registered handlers.
+type Switch struct {
+	mu       sync.Mutex
+	halt     *events.HaltEvent
+	handlers []func(events.HaltEvent)
+}
+
+func New() *Switch {
+	return &Switch{}
+}
+
+// OnHalt registers fn to run when the switch trips. Handlers run in
+// registration order on the triggering goroutine, so they must not block.
+func (k *Switch) OnHalt(fn func(events.HaltEvent)) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.handlers = append(k.handlers, fn)
+}
+
+// Trigger trips the switch. Returns false if it had already tripped.
+func (k *Switch) Trigger(source, reason string) bool {
+	return k.apply(events.HaltEvent{Reason: reason, Source: source, At: time.Now()})
+}
+
+// Apply trips the switch with a halt received from elsewhere (e.g. the
+// fanout control message), keeping its original source and time.
+func (k *Switch) Apply(h events.HaltEvent) bool {
+	return k.apply(h)
+}
+
+func (k *Switch) apply(h events.HaltEvent) bool {
+	k.mu.Lock()
+	if k.halt != nil {
+		k.mu.Unlock()
+		return false
+	}
+	k.halt = &h
+	handlers := append([]func(events.HaltEvent){}, k.handlers...)
+	k.mu.Unlock()
+
+	telemetry.Errorf("[KILL] trading halted by %s: %s", h.Source, h.Reason)
+	for _, fn := range handlers {
+		fn(h)
+	}
+	return true
+}
+
+// Halted returns the halt that tripped the switch, if any.
+func (k *Switch) Halted() (events.HaltEvent, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.halt == nil {
+		return events.HaltEvent{}, false
+	}
+	return *k.halt, true
+}
+
+// WatchSignal trips the switch on SIGUSR1 until ctx is cancelled.
+func (k *Switch) WatchSignal(ctx context.Context) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1)
+	defer signal.Stop(ch)
+
+	select {
+	case <-ctx.Done():
+	case <-ch:
+		k.Trigger("signal", "SIGUSR1")
+	}
+}
+
+// WatchFile trips the switch as soon as path exists, including at startup,
+// until ctx is cancelled. An empty path disables the watch.
+func (k *Switch) WatchFile(ctx context.Context, path string) {
+	if path == "" {
+		return
+	}
+	t := time.NewTicker(filePollInterval)
+	defer t.Stop()
+	for {
+		if _, err := os.Stat(path); err == nil {
+			k.Trigger("file", "sentinel file "+path)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
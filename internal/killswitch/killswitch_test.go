@@ -0,0 +1,205 @@
+package killswitch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charleschow/hft-trading/internal/adapters/outbound/kalshi_http"
+	"github.com/charleschow/hft-trading/internal/events"
+)
+
+func TestSwitchLatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		trips   []func(k *Switch) bool
+		want    []bool
+		wantSrc string
+	}{
+		{
+			name: "first trigger wins",
+			trips: []func(k *Switch) bool{
+				func(k *Switch) bool { return k.Trigger("admin", "a") },
+				func(k *Switch) bool { return k.Trigger("file", "b") },
+			},
+			want:    []bool{true, false},
+			wantSrc: "admin",
+		},
+		{
+			name: "applied halt keeps its source",
+			trips: []func(k *Switch) bool{
+				func(k *Switch) bool { return k.Apply(events.HaltEvent{Source: "central", Reason: "fanout"}) },
+				func(k *Switch) bool { return k.Trigger("signal", "SIGUSR1") },
+			},
+			want:    []bool{true, false},
+			wantSrc: "central",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := New()
+			if _, ok := k.Halted(); ok {
+				t.Fatal("new switch is halted")
+			}
+			var calls []string
+			k.OnHalt(func(h events.HaltEvent) { calls = append(calls, "first:"+h.Source) })
+			k.OnHalt(func(h events.HaltEvent) { calls = append(calls, "second:"+h.Source) })
+
+			var got []bool
+			for _, trip := range tt.trips {
+				got = append(got, trip(k))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("trips returned %v, want %v", got, tt.want)
+			}
+			if want := []string{"first:" + tt.wantSrc, "second:" + tt.wantSrc}; !slices.Equal(calls, want) {
+				t.Errorf("handlers ran %v, want %v", calls, want)
+			}
+			if h, ok := k.Halted(); !ok || h.Source != tt.wantSrc {
+				t.Errorf("Halted = %+v, %v; want source %s", h, ok, tt.wantSrc)
+			}
+		})
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "HALT")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	k := New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	k.WatchFile(ctx, path)
+	if h, ok := k.Halted(); !ok || h.Source != "file" {
+		t.Errorf("sentinel present at startup: Halted = %+v, %v", h, ok)
+	}
+
+	k = New()
+	k.WatchFile(ctx, "")
+	if _, ok := k.Halted(); ok {
+		t.Error("empty path tripped the switch")
+	}
+}
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		sent       string
+		method     string
+		path       string
+		wantCode   int
+		wantHalted bool
+	}{
+		{"no token configured", "", "", "POST", "/admin/halt", http.StatusUnauthorized, false},
+		{"missing token", "s3cret", "", "POST", "/admin/halt", http.StatusUnauthorized, false},
+		{"wrong token", "s3cret", "guess", "POST", "/admin/halt", http.StatusUnauthorized, false},
+		{"halt", "s3cret", "s3cret", "POST", "/admin/halt?reason=test", http.StatusOK, true},
+		{"status", "s3cret", "s3cret", "GET", "/admin/status", http.StatusOK, false},
+		{"halt needs POST", "s3cret", "s3cret", "GET", "/admin/halt", http.StatusMethodNotAllowed, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := New()
+			h := RequireToken(tt.configured, k.Handler())
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.sent != "" {
+				req.Header.Set(TokenHeader, tt.sent)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("code %d, want %d", rec.Code, tt.wantCode)
+			}
+			if _, halted := k.Halted(); halted != tt.wantHalted {
+				t.Errorf("halted %v, want %v", halted, tt.wantHalted)
+			}
+			if rec.Code != http.StatusOK {
+				return
+			}
+			var status struct {
+				Halted, Tripped bool
+				Reason          string
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+				t.Fatal(err)
+			}
+			if status.Halted != tt.wantHalted || status.Tripped != tt.wantHalted || (tt.wantHalted && status.Reason != "test") {
+				t.Errorf("status %+v", status)
+			}
+		})
+	}
+}
+
+// fakeAccount serves a list of resting orders per sweep.
+type fakeAccount struct {
+	mu       sync.Mutex
+	sweeps   [][]string
+	canceled [][]string
+	after    func() // runs after each cancel
+}
+
+func (a *fakeAccount) GetOrders(context.Context, string) ([]kalshi_http.OrderDetail, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.sweeps) == 0 {
+		return nil, nil
+	}
+	var out []kalshi_http.OrderDetail
+	for _, id := range a.sweeps[0] {
+		out = append(out, kalshi_http.OrderDetail{OrderID: id})
+	}
+	a.sweeps = a.sweeps[1:]
+	return out, nil
+}
+
+func (a *fakeAccount) BatchCancelOrders(_ context.Context, ids []string) (int, error) {
+	a.mu.Lock()
+	a.canceled = append(a.canceled, ids)
+	a.mu.Unlock()
+	if a.after != nil {
+		a.after()
+	}
+	return len(ids), nil
+}
+
+func (a *fakeAccount) GetPositions(context.Context) (*kalshi_http.PositionResponse, error) {
+	return &kalshi_http.PositionResponse{}, nil
+}
+
+func TestCancelAll(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		sweeps [][]string
+		stop   bool // cancel the context after the first sweep
+		want   [][]string
+	}{
+		{"second sweep catches late orders", [][]string{{"a", "b"}, {"c"}}, false, [][]string{{"a", "b"}, {"c"}}},
+		{"stopped before the second sweep", [][]string{{"a"}, {"c"}}, true, [][]string{{"a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			acct := &fakeAccount{sweeps: tt.sweeps}
+			if tt.stop {
+				acct.after = cancel
+			}
+			CancelAll(ctx, acct)
+			if !slices.EqualFunc(acct.canceled, tt.want, slices.Equal) {
+				t.Errorf("canceled %v, want %v", acct.canceled, tt.want)
+			}
+		})
+	}
+}
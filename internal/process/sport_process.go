@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/charleschow/hft-trading/internal/adapters/inbound/kalshi_ws"
 	"github.com/charleschow/hft-trading/internal/adapters/kalshi_auth"
@@ -14,7 +15,9 @@ import (
 	"github.com/charleschow/hft-trading/internal/config"
 	"github.com/charleschow/hft-trading/internal/core/display"
 	"github.com/charleschow/hft-trading/internal/core/execution"
+	"github.com/charleschow/hft-trading/internal/core/execution/paper"
 	"github.com/charleschow/hft-trading/internal/core/overturn"
+	"github.com/charleschow/hft-trading/internal/core/shadow"
 	"github.com/charleschow/hft-trading/internal/core/state/game"
 	"github.com/charleschow/hft-trading/internal/core/state/store"
 	"github.com/charleschow/hft-trading/internal/core/strategy"
@@ -22,6 +25,7 @@ import (
 	"github.com/charleschow/hft-trading/internal/core/tracking"
 	"github.com/charleschow/hft-trading/internal/events"
 	"github.com/charleschow/hft-trading/internal/fanout"
+	"github.com/charleschow/hft-trading/internal/killswitch"
 	"github.com/charleschow/hft-trading/internal/telemetry"
 )
 
@@ -34,6 +38,10 @@ type SportProcessConfig struct {
 	// BuildStrategy returns the sport-specific strategy implementation.
 	BuildStrategy func(cfg *config.Config) strategy.Strategy
 
+	// BuildShadows optionally returns strategies to run as shadows of the
+	// live one. Their decisions are logged to cfg.ShadowDBPath, never traded.
+	BuildShadows func(cfg *config.Config) []strategy.Shadow
+
 	// BuildPregameProvider returns a function that fetches pregame odds.
 	// The engine calls this at startup and on periodic refresh.
 	BuildPregameProvider func(cfg *config.Config) strategy.PregameProvider
@@ -76,6 +84,17 @@ func Run(spc SportProcessConfig) {
 		telemetry.Infof("[Kalshi] balance: $%.2f", float64(balance)/100.0)
 	}
 
+	// ── Order routing (live Kalshi or paper exchange) ─────────
+	var orderPlacer execution.OrderPlacer = kalshiClient
+	var orderPoller tracking.OrderPoller = kalshiClient
+	var paperExchange *paper.Exchange
+	if cfg.TradingMode == "paper" {
+		paperExchange = paper.NewExchange(gameStore, bus, cfg.PaperBalanceCents)
+		orderPlacer = paperExchange
+		orderPoller = paperExchange
+		telemetry.Infof("[PAPER] paper trading enabled — starting balance $%.2f", float64(cfg.PaperBalanceCents)/100.0)
+	}
+
 	// ── Ticker resolver ────────────────────────────────────────
 	tickerResolver := ticker.NewResolver(kalshiClient, cfg.TickersConfigDir, spc.Sport)
 
@@ -83,9 +102,29 @@ func Run(spc SportProcessConfig) {
 	kalshiWS := kalshi_ws.NewClient(cfg.KalshiWSURL, kalshiSigner, bus)
 
 	// ── Strategy (sport-specific via closure) ──────────────────
+	if err := game.LoadStrategyParams(cfg.StrategyParamsPath); err != nil {
+		telemetry.Warnf("%s strategy params: %v (using defaults)", label, err)
+	}
+
 	registry := strategy.NewRegistry()
 	registry.Register(spc.Sport, spc.BuildStrategy(cfg))
 
+	var decisionLog *shadow.Store
+	if spc.BuildShadows != nil {
+		for _, sh := range spc.BuildShadows(cfg) {
+			registry.RegisterShadow(spc.Sport, sh.ID, sh.Strategy)
+			telemetry.Infof("%s shadow strategy %q registered", label, sh.ID)
+		}
+	}
+	if len(registry.Shadows(spc.Sport)) > 0 {
+		decisionLog, err = shadow.OpenStore(cfg.ShadowDBPath)
+		if err != nil {
+			telemetry.Errorf("%s decision log: %v", label, err)
+			os.Exit(1)
+		}
+		defer decisionLog.Close()
+	}
+
 	// ── Observers ──────────────────────────────────────────────
 	var observers []game.GameObserver
 	var trainingCloser io.Closer
@@ -115,7 +154,7 @@ func Run(spc SportProcessConfig) {
 		os.Exit(1)
 	}
 	defer trackingStore.Close()
-	orderTracker := tracking.NewTracker(trackingStore, kalshiClient)
+	orderTracker := tracking.NewTracker(trackingStore, orderPoller)
 	observers = append(observers, orderTracker)
 
 	// ── Overturn observer ─────────────────────────────────────
@@ -127,13 +166,91 @@ func Run(spc SportProcessConfig) {
 	defer otStore.Close()
 	observers = append(observers, overturn.NewObserver(otStore))
 
+	// ── Execution ──────────────────────────────────────────────
+	riskLimits, err := config.LoadRiskLimits(cfg.RiskLimitsPath)
+	if err != nil {
+		telemetry.Errorf("Failed to load risk limits: %v", err)
+		os.Exit(1)
+	}
+
+	laneRouter := execution.NewLaneRouter()
+	execution.RegisterLanesFromConfig(laneRouter, riskLimits, spc.Sport, spc.SportKey)
+	execService := execution.NewService(bus, laneRouter, orderPlacer, gameStore, orderTracker)
+	execService.SetBankroll(riskLimits.Global.DefaultBankrollCents)
+
+	lossBreaker, err := execution.NewLossBreaker(laneRouter, gameStore, riskLimits, spc.Sport, spc.SportKey)
+	if err != nil {
+		telemetry.Errorf("%s loss breaker: %v", label, err)
+		os.Exit(1)
+	}
+	orderTracker.OnSettle(lossBreaker.OnSettle)
+	if realized, err := orderTracker.RealizedPnLSince(spc.SportKey, lossBreaker.DayStart()); err != nil {
+		telemetry.Warnf("%s loss breaker: restore realized P&L: %v", label, err)
+	} else {
+		lossBreaker.Restore(realized)
+	}
+	if err := lossBreaker.UseStateStore(orderTracker); err != nil {
+		telemetry.Warnf("%s loss breaker: %v", label, err)
+	}
+	bus.Subscribe(events.EventBreakerReset, func(evt events.Event) error {
+		if r, ok := evt.Payload.(events.BreakerResetEvent); ok && r.Sport == spc.Sport {
+			go lossBreaker.Reset(r.League)
+		}
+		return nil
+	})
+
+	idemStore, err := execution.OpenIdempotencyStore(cfg.IdempotencyDBPath)
+	if err != nil {
+		telemetry.Errorf("%s idempotency store: %v", label, err)
+		os.Exit(1)
+	}
+	defer idemStore.Close()
+	if err := execService.UseIdempotencyStore(idemStore, spc.Sport); err != nil {
+		telemetry.Errorf("%s idempotency restore: %v", label, err)
+		os.Exit(1)
+	}
+	observers = append(observers, idemStore)
+
+	// Runs after the overturn observer so the PENDING row exists
+	// before its cancel counts are written.
+	observers = append(observers, execution.NewOverturnCanceler(execService, otStore, cfg.OverturnReplaceOnReject))
+	observers = append(observers, execService.OrderGroupObserver())
+
 	// ── Engine ─────────────────────────────────────────────────
 	engine := strategy.NewEngine(bus, gameStore, registry, tickerResolver, kalshiWS, observers)
+	if decisionLog != nil {
+		engine.SetDecisionLog(decisionLog)
+	}
+	engine.SetMarketLeadRecorder(orderTracker)
 
 	// ── Context ──────────────────────────────────────────────
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	if paperExchange != nil {
+		go paperExchange.Run(ctx)
+	}
+	if br, ok := orderPlacer.(execution.BalanceReader); ok && riskLimits.Global.LiveBankroll {
+		go execService.WatchBankroll(ctx, br, time.Minute)
+	}
+	go lossBreaker.Run(ctx, 5*time.Second)
+	go game.WatchStrategyParams(ctx, cfg.StrategyParamsPath, 5*time.Second)
+
+	// ── Kill switch ────────────────────────────────────────────
+	// Halts arrive from the central process over fanout; SIGUSR1 and the
+	// sentinel file also work locally in case fanout is down. Armed before
+	// games are initialized and reconciled so a halt covers startup too.
+	kill := killswitch.New()
+	kill.OnHalt(execService.Halt)
+	bus.Subscribe(events.EventHalt, func(evt events.Event) error {
+		if h, ok := evt.Payload.(events.HaltEvent); ok {
+			kill.Apply(h)
+		}
+		return nil
+	})
+	go kill.WatchSignal(ctx)
+	go kill.WatchFile(ctx, cfg.KillSwitchFile)
+
 	// ── Initialize games (blocks until complete) ─────────────
 	if spc.BuildPregameProvider != nil {
 		provider := spc.BuildPregameProvider(cfg)
@@ -142,16 +259,17 @@ func Run(spc SportProcessConfig) {
 		telemetry.Warnf("No pregame provider configured for %s — no games will be initialized", spc.SportKey)
 	}
 
-	// ── Execution ──────────────────────────────────────────────
-	riskLimits, err := config.LoadRiskLimits(cfg.RiskLimitsPath)
-	if err != nil {
-		telemetry.Errorf("Failed to load risk limits: %v", err)
-		os.Exit(1)
+	// ── Reconcile exposure left by a previous run ─────────────
+	// Paper mode starts from an empty book, so there is nothing to restore.
+	if paperExchange == nil {
+		owns := func(t string) bool { return tickerResolver.OwnsTicker(spc.Sport, t) }
+		if _, err := execService.Reconcile(ctx, kalshiClient, owns); err != nil {
+			telemetry.Warnf("[RECONCILE] startup reconciliation failed: %v", err)
+		}
 	}
 
-	laneRouter := execution.NewLaneRouter()
-	execution.RegisterLanesFromConfig(laneRouter, riskLimits, spc.Sport, spc.SportKey)
-	_ = execution.NewService(bus, laneRouter, kalshiClient, gameStore, orderTracker)
+	// ── Per-game order groups (exchange-enforced burst limits) ──
+	go execService.PrepareOrderGroups(ctx)
 
 	// ── Fanout client & Kalshi WS (after init completes) ─────
 	telemetry.Infof("Connecting to fanout for %s games (%s)...", spc.SportKey, cfg.FanoutAddr)
@@ -87,6 +87,9 @@ var Metrics = struct {
 	WSParseErrors      Counter
 	WSReconnects       Counter
 	WSLatency          *LatencyTracker
+
+	// Kalshi WebSocket metrics
+	OrderbookSeqGaps Counter
 }{
 	WebhookLatency:  NewLatencyTracker(1000),
 	OrderE2ELatency: NewLatencyTracker(1000),